@@ -0,0 +1,453 @@
+// Package arena runs competitions between bot processes that play over a line-based TCP protocol.
+//
+// A bot connects and says hello with its name, and optionally the JSON format:
+//
+//	hello mybot
+//	hello mybot json
+//
+// Every line sent to a bot is a message type followed by named values. In the text format values are written
+// as key=value, lists are comma separated and spaces in strings are written as underscores. In the JSON format
+// each line is an object with the type and the same keys:
+//
+//	welcome name=mybot format=text
+//	match match=1 seat=2 players=alice,bob,mybot stack=1000 small_blind=5 big_blind=10 hands=100 time_ms=1000
+//	hand hand=1 button=0 stacks=1000,1000,1000 hole=AS,KD
+//	action seat=1 street=preflop type=small_blind amount=5 total=5 all_in=false
+//	street street=flop board=2H,7H,9C
+//	turn street=flop pot=60 to_call=0 min_raise=10 bet=0 stack=980 board=2H,7H,9C hole=AS,KD time_ms=1000
+//	showdown seat=2 hole=AS,KD cards=AS,KD,9C,7H,2H rank=High_Card rank_order=10
+//	result hand=1 board=2H,7H,9C,3S,QC winners=2 net=-20,-20,40
+//	end match=1
+//	error message=no_action_within_1s
+//
+// A bot answers every turn, in order, with one line: fold, check, call, "bet <amount>" or "raise <amount>",
+// where the amount is the total bet on the street, or {"action": "raise", "amount": 60} in the JSON format.
+// A bot that does not answer within time_ms folds, or checks if it can, and its late answer is discarded.
+// Showdowns are sent ranked by EvaluateHands, best first.
+package arena
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"math/rand"
+	"net"
+	"slices"
+	"sort"
+	"strconv"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	"github.com/YoungsoonLee/poker/poker"
+	"github.com/YoungsoonLee/poker/table"
+)
+
+// z95 is the z-score of a 95% confidence interval.
+const z95 = 1.96
+
+// Config configures a competition.
+// The dealer waits for Bots bots to connect and seats them all at one table. Each of the Matches plays Hands hands,
+// and the seats are rotated between matches. Stacks are reset to Stack before every hand, like in a cash game simulation.
+// A bot has ActionTimeout to answer each turn. Seed makes the cards reproducible; if it is 0 the current time is used.
+// Logf, if set, logs the bots joining and the matches starting.
+type Config struct {
+	Bots          int
+	Matches       int
+	Hands         int
+	Stack         int
+	SmallBlind    int
+	BigBlind      int
+	ActionTimeout time.Duration
+	Seed          int64
+	Logf          func(format string, args ...any)
+}
+
+// Validate checks the configuration and returns an error describing the first problem found.
+func (c Config) Validate() error {
+	if c.Bots < 2 || c.Bots > 10 {
+		return fmt.Errorf("need 2 to 10 bots, got %d", c.Bots)
+	}
+	if c.Matches < 1 {
+		return fmt.Errorf("invalid number of matches: %d", c.Matches)
+	}
+	if c.Hands < 1 {
+		return fmt.Errorf("invalid number of hands: %d", c.Hands)
+	}
+	if c.SmallBlind < 0 || c.BigBlind < 1 || c.SmallBlind > c.BigBlind {
+		return fmt.Errorf("invalid blinds: %d/%d", c.SmallBlind, c.BigBlind)
+	}
+	if c.Stack < c.BigBlind {
+		return fmt.Errorf("stack %d is smaller than the big blind %d", c.Stack, c.BigBlind)
+	}
+	if c.ActionTimeout <= 0 {
+		return fmt.Errorf("invalid action timeout: %v", c.ActionTimeout)
+	}
+
+	return nil
+}
+
+func (c Config) logf(format string, args ...any) {
+	if c.Logf != nil {
+		c.Logf(format, args...)
+	}
+}
+
+// BotStats is the performance of one bot over the competition.
+// Wins counts the hands the bot won chips in, and BB100 is its win rate in big blinds per 100 hands
+// with CI95 the half width of its 95% confidence interval. Timeouts and Invalid count the turns it folded
+// for answering too late or with an invalid action.
+type BotStats struct {
+	Name         string
+	Hands        int
+	Wins         int
+	Net          int
+	BB100        float64
+	CI95         float64
+	Timeouts     int
+	Invalid      int
+	Disconnected bool
+
+	sum   float64
+	sumSq float64
+}
+
+// Report is the result of a competition. Bots are ordered by their net chips, best first.
+type Report struct {
+	Matches int
+	Hands   int
+	Bots    []BotStats
+}
+
+// WriteTable writes the result table with the place of every bot.
+func (r *Report) WriteTable(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(tw, "Place\tBot\tHands\tWon\tNet\tbb/100\t95% CI\tTimeouts\tInvalid\t")
+	for i, b := range r.Bots {
+		name := b.Name
+		if b.Disconnected {
+			name += " (disconnected)"
+		}
+		fmt.Fprintf(tw, "%d\t%s\t%d\t%d\t%d\t%.2f\t±%.2f\t%d\t%d\t\n", i+1, name, b.Hands, b.Wins, b.Net, b.BB100, b.CI95, b.Timeouts, b.Invalid)
+	}
+
+	return tw.Flush()
+}
+
+// WriteCSV writes the result table as CSV with a header row.
+func (r *Report) WriteCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write([]string{"place", "bot", "hands", "won", "net", "bb100", "ci95", "timeouts", "invalid", "disconnected"}); err != nil {
+		return err
+	}
+
+	for i, b := range r.Bots {
+		record := []string{
+			strconv.Itoa(i + 1),
+			b.Name,
+			strconv.Itoa(b.Hands),
+			strconv.Itoa(b.Wins),
+			strconv.Itoa(b.Net),
+			strconv.FormatFloat(b.BB100, 'f', 2, 64),
+			strconv.FormatFloat(b.CI95, 'f', 2, 64),
+			strconv.Itoa(b.Timeouts),
+			strconv.Itoa(b.Invalid),
+			strconv.FormatBool(b.Disconnected),
+		}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+// Run accepts bots on the listener until the table is full, plays the matches between them and reports the results.
+// Bots connecting once the table is full are turned away. The listener is closed when Run returns.
+func Run(ctx context.Context, lis net.Listener, cfg Config) (*Report, error) {
+	defer lis.Close()
+
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	done := make(chan struct{})
+	defer close(done)
+
+	bots, err := accept(ctx, lis, cfg, done)
+	for _, b := range bots {
+		defer b.conn.Close()
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	seed := cfg.Seed
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+	rng := rand.New(rand.NewSource(seed))
+
+	report := &Report{}
+	for _, b := range bots {
+		report.Bots = append(report.Bots, BotStats{Name: b.name})
+	}
+
+	for m := 0; m < cfg.Matches; m++ {
+		cfg.logf("Match %d of %d\n", m+1, cfg.Matches)
+		if err := playMatch(ctx, cfg, m, bots, rng, report); err != nil {
+			return nil, err
+		}
+		report.Matches++
+	}
+
+	report.finish(cfg, bots)
+
+	return report, nil
+}
+
+// lobby holds the bots that joined until the table is full.
+type lobby struct {
+	mu   sync.Mutex
+	size int
+	bots []*bot
+	full chan struct{}
+}
+
+// add seats a bot that said hello, and welcomes it.
+func (l *lobby) add(b *bot) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if len(l.bots) == l.size {
+		return errors.New("the arena is full")
+	}
+	for _, other := range l.bots {
+		if other.name == b.name {
+			return fmt.Errorf("name %s is taken", b.name)
+		}
+	}
+
+	b.send(newMessage("welcome", "name", b.name, "format", b.format))
+	l.bots = append(l.bots, b)
+	if len(l.bots) == l.size {
+		close(l.full)
+	}
+
+	return nil
+}
+
+// accept accepts connections until the configured number of bots said hello, in the order they joined.
+func accept(ctx context.Context, lis net.Listener, cfg Config, done <-chan struct{}) ([]*bot, error) {
+	l := &lobby{size: cfg.Bots, full: make(chan struct{})}
+
+	go func() {
+		for {
+			conn, err := lis.Accept()
+			if err != nil {
+				return
+			}
+
+			go func() {
+				b, err := handshake(conn, cfg.ActionTimeout, done)
+				if err == nil {
+					err = l.add(b)
+				}
+				if err != nil {
+					conn.SetWriteDeadline(time.Now().Add(writeTimeout))
+					fmt.Fprintln(conn, newMessage("error", "message", err.Error()).text())
+					conn.Close()
+					return
+				}
+				cfg.logf("Bot %s joined from %s\n", b.name, conn.RemoteAddr())
+			}()
+		}
+	}()
+
+	select {
+	case <-l.full:
+		return l.bots, nil
+	case <-ctx.Done():
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		// bots saying hello from now on are turned away
+		l.size = len(l.bots)
+		return l.bots, ctx.Err()
+	}
+}
+
+// playMatch plays one match, with the seats rotated by the match number.
+func playMatch(ctx context.Context, cfg Config, match int, bots []*bot, rng *rand.Rand, report *Report) error {
+	n := len(bots)
+	order := make([]*bot, n)
+	index := make([]int, n)
+	seats := make([]*table.Seat, n)
+	names := make([]string, n)
+	for i := range seats {
+		index[i] = (i + match) % n
+		order[i] = bots[index[i]]
+		seats[i] = &table.Seat{Name: order[i].name, Strategy: order[i]}
+		names[i] = order[i].name
+	}
+
+	t, err := table.New(seats, cfg.SmallBlind, cfg.BigBlind, rng)
+	if err != nil {
+		return err
+	}
+	t.Observer = func(s table.Snapshot) {
+		observe(order, s)
+	}
+
+	for i, b := range order {
+		b.send(newMessage("match",
+			"match", match+1,
+			"seat", i,
+			"players", names,
+			"stack", cfg.Stack,
+			"small_blind", cfg.SmallBlind,
+			"big_blind", cfg.BigBlind,
+			"hands", cfg.Hands,
+			"time_ms", cfg.ActionTimeout.Milliseconds(),
+		))
+	}
+
+	for h := 0; h < cfg.Hands; h++ {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		for _, s := range t.Seats {
+			s.Stack = cfg.Stack
+		}
+
+		result, err := t.PlayHand()
+		if err != nil {
+			return err
+		}
+
+		sendResult(order, result)
+		report.add(cfg, index, result)
+	}
+
+	for _, b := range order {
+		b.send(newMessage("end", "match", match+1))
+	}
+
+	return nil
+}
+
+// observe sends the hole cards, actions and streets of a hand in play to the bots.
+func observe(order []*bot, s table.Snapshot) {
+	if s.Action != nil {
+		a := s.Action
+		m := newMessage("action", "seat", a.Seat, "street", a.Street.String(), "type", a.Type.String(), "amount", a.Amount, "total", a.Total, "all_in", a.AllIn)
+		for _, b := range order {
+			b.send(m)
+		}
+		return
+	}
+
+	if s.Street != table.Preflop {
+		m := newMessage("street", "street", s.Street.String(), "board", s.Board)
+		for _, b := range order {
+			b.send(m)
+		}
+		return
+	}
+
+	// every seat has chips at the start of a hand, so the players are the seats in order
+	stacks := make([]int, len(order))
+	for _, p := range s.Players {
+		stacks[p.Seat] = p.Stack
+	}
+	for _, p := range s.Players {
+		order[p.Seat].send(newMessage("hand", "hand", s.HandNo, "button", s.Button, "stacks", stacks, "hole", p.Hole))
+	}
+}
+
+// sendResult sends the showdown, ranked by EvaluateHands, and the result of a hand to the bots.
+func sendResult(order []*bot, result *table.Result) {
+	var msgs []message
+
+	hands := make(poker.Hands, len(result.Showdown))
+	for i, s := range result.Showdown {
+		hands[i] = s.Hand
+	}
+	// the best hands are identified by their seats
+	for _, e := range poker.EvaluateHands(hands) {
+		p, _ := result.Player(e.HandID)
+		msgs = append(msgs, newMessage("showdown", "seat", e.HandID, "hole", p.Hole, "cards", e.Card, "rank", e.Rank, "rank_order", e.RankOrder))
+	}
+
+	var winners []int
+	for _, p := range result.Pots {
+		for _, w := range p.Winners {
+			if !slices.Contains(winners, w) {
+				winners = append(winners, w)
+			}
+		}
+	}
+	sort.Ints(winners)
+
+	net := make([]int, len(order))
+	for _, p := range result.Players {
+		net[p.Seat] = p.Net()
+	}
+	msgs = append(msgs, newMessage("result", "hand", result.HandNo, "board", result.Board, "winners", winners, "net", net))
+
+	for _, b := range order {
+		for _, m := range msgs {
+			b.send(m)
+		}
+	}
+}
+
+// add adds the net chips of a hand to the stats of the bots. index maps the seats to the bots.
+func (r *Report) add(cfg Config, index []int, result *table.Result) {
+	r.Hands++
+	for _, p := range result.Players {
+		b := &r.Bots[index[p.Seat]]
+		net := p.Net()
+
+		b.Hands++
+		b.Net += net
+		if net > 0 {
+			b.Wins++
+		}
+
+		bb := float64(net) / float64(cfg.BigBlind)
+		b.sum += bb
+		b.sumSq += bb * bb
+	}
+}
+
+// finish computes the win rates, copies the counters of the bots and orders them by their net chips.
+func (r *Report) finish(cfg Config, bots []*bot) {
+	for i := range r.Bots {
+		b := &r.Bots[i]
+		b.Timeouts = bots[i].timeouts
+		b.Invalid = bots[i].invalid
+		b.Disconnected = bots[i].gone
+
+		if b.Hands == 0 {
+			continue
+		}
+
+		n := float64(b.Hands)
+		mean := b.sum / n
+		b.BB100 = mean * 100
+
+		if b.Hands > 1 {
+			variance := (b.sumSq - n*mean*mean) / (n - 1)
+			b.CI95 = z95 * math.Sqrt(math.Max(variance, 0)/n) * 100
+		}
+	}
+
+	sort.SliceStable(r.Bots, func(i, j int) bool {
+		return r.Bots[i].Net > r.Bots[j].Net
+	})
+}
@@ -0,0 +1,227 @@
+package arena
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"strings"
+	"testing"
+	"time"
+)
+
+// testConfig plays quick matches between three bots.
+var testConfig = Config{
+	Bots:          3,
+	Matches:       2,
+	Hands:         5,
+	Stack:         100,
+	SmallBlind:    5,
+	BigBlind:      10,
+	ActionTimeout: 50 * time.Millisecond,
+	Seed:          1,
+}
+
+// listen returns a listener on a free local port.
+func listen(t *testing.T) net.Listener {
+	t.Helper()
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("net.Listen() error = %v", err)
+	}
+
+	return lis
+}
+
+// runBot connects a scripted bot that says hello and answers every turn with answer, unless it is nil.
+// The lines it was sent are returned on the channel when the connection is closed.
+func runBot(t *testing.T, addr, hello string, answer func(turn string) string) <-chan []string {
+	t.Helper()
+
+	conn, err := net.Dial("tcp", addr)
+	if err != nil {
+		t.Fatalf("net.Dial() error = %v", err)
+	}
+
+	out := make(chan []string, 1)
+	go func() {
+		defer conn.Close()
+		fmt.Fprintln(conn, hello)
+
+		var lines []string
+		sc := bufio.NewScanner(conn)
+		for sc.Scan() {
+			line := sc.Text()
+			lines = append(lines, line)
+			if answer != nil && (strings.HasPrefix(line, "turn ") || strings.HasPrefix(line, `{"type":"turn"`)) {
+				fmt.Fprintln(conn, answer(line))
+			}
+		}
+		out <- lines
+	}()
+
+	return out
+}
+
+// count returns the number of lines of the given type.
+func count(lines []string, typ string) int {
+	n := 0
+	for _, line := range lines {
+		if strings.HasPrefix(line, typ+" ") || strings.HasPrefix(line, `{"type":"`+typ+`"`) {
+			n++
+		}
+	}
+
+	return n
+}
+
+func TestRun(t *testing.T) {
+	lis := listen(t)
+	addr := lis.Addr().String()
+
+	type result struct {
+		report *Report
+		err    error
+	}
+	results := make(chan result, 1)
+	go func() {
+		report, err := Run(context.Background(), lis, testConfig)
+		results <- result{report, err}
+	}()
+
+	caller := runBot(t, addr, "hello caller", func(string) string { return "call" })
+	folder := runBot(t, addr, "hello folder json", func(string) string { return `{"action": "fold"}` })
+	sleeper := runBot(t, addr, "hello sleeper", nil)
+
+	r := <-results
+	if r.err != nil {
+		t.Fatalf("Run() error = %v", r.err)
+	}
+
+	net := 0
+	for i, b := range r.report.Bots {
+		net += b.Net
+		if b.Hands != 10 {
+			t.Errorf("%s played %d hands, want 10", b.Name, b.Hands)
+		}
+		if i > 0 && b.Net > r.report.Bots[i-1].Net {
+			t.Errorf("bots are not ordered by their net chips: %+v", r.report.Bots)
+		}
+		if wantTimeouts := b.Name == "sleeper"; (b.Timeouts > 0) != wantTimeouts || b.Invalid != 0 {
+			t.Errorf("%s has %d timeouts and %d invalid actions", b.Name, b.Timeouts, b.Invalid)
+		}
+	}
+	if r.report.Matches != 2 || r.report.Hands != 10 || net != 0 {
+		t.Errorf("Run() = %d matches, %d hands and %d net chips, want 2, 10 and 0", r.report.Matches, r.report.Hands, net)
+	}
+
+	lines := <-caller
+	if lines[0] != "welcome name=caller format=text" || count(lines, "match") != 2 || count(lines, "hand") != 10 || count(lines, "result") != 10 || count(lines, "end") != 2 {
+		t.Errorf("caller was sent %d lines starting with %q, want a welcome, 2 matches and 10 hands", len(lines), lines[0])
+	}
+
+	for _, line := range <-folder {
+		var m map[string]any
+		if err := json.Unmarshal([]byte(line), &m); err != nil {
+			t.Fatalf("folder was sent %q, want JSON: %v", line, err)
+		}
+		if m["type"] == "hand" && len(m["hole"].([]any)) != 2 {
+			t.Errorf("folder was sent %q, want 2 hole cards", line)
+		}
+	}
+
+	if lines := <-sleeper; count(lines, "error") == 0 {
+		t.Errorf("sleeper was not told about its timeouts")
+	}
+}
+
+func TestRun_Hello(t *testing.T) {
+	lis := listen(t)
+	addr := lis.Addr().String()
+
+	cfg := testConfig
+	cfg.Bots, cfg.Matches, cfg.Hands = 2, 1, 1
+	done := make(chan error, 1)
+	go func() {
+		_, err := Run(context.Background(), lis, cfg)
+		done <- err
+	}()
+
+	tests := []struct {
+		name  string
+		hello string
+		want  string
+	}{
+		{name: "no name", hello: "hello", want: `error message=expected_"hello_<name>"_or_"hello_<name>_json"`},
+		{name: "unknown format", hello: "hello bot xml", want: `error message=unknown_format:_"xml"`},
+		{name: "comma in name", hello: "hello a,b", want: "error message=a_name_of_1_to_32_characters_without_commas_is_required"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if lines := <-runBot(t, addr, tt.hello, nil); len(lines) != 1 || lines[0] != tt.want {
+				t.Errorf("lines = %q, want %q", lines, tt.want)
+			}
+		})
+	}
+
+	first := runBot(t, addr, "hello bot", func(string) string { return "call" })
+	// wait until the first bot is welcomed before another one takes its name
+	time.Sleep(50 * time.Millisecond)
+	if lines := <-runBot(t, addr, "hello bot", nil); len(lines) != 1 || lines[0] != "error message=name_bot_is_taken" {
+		t.Errorf("lines = %q, want the name to be taken", lines)
+	}
+
+	second := runBot(t, addr, "hello other", func(string) string { return "call" })
+	if err := <-done; err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	<-first
+	<-second
+
+	// the listener is closed when the competition is over
+	if _, err := net.Dial("tcp", addr); err == nil {
+		t.Errorf("net.Dial() after Run() succeeded, want the listener closed")
+	}
+}
+
+func TestRun_Cancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := Run(ctx, listen(t), testConfig); err != context.Canceled {
+		t.Errorf("Run() error = %v, want %v", err, context.Canceled)
+	}
+}
+
+func TestReport_Write(t *testing.T) {
+	r := &Report{
+		Matches: 1,
+		Hands:   100,
+		Bots: []BotStats{
+			{Name: "tag", Hands: 100, Wins: 30, Net: 500, BB100: 50, CI95: 12.5},
+			{Name: "sleeper", Hands: 100, Wins: 5, Net: -500, BB100: -50, CI95: 3, Timeouts: 80, Disconnected: true},
+		},
+	}
+
+	var table bytes.Buffer
+	if err := r.WriteTable(&table); err != nil {
+		t.Fatalf("Report.WriteTable() error = %v", err)
+	}
+	if got := table.String(); !strings.Contains(got, "sleeper (disconnected)") || strings.Count(got, "\n") != 3 {
+		t.Errorf("Report.WriteTable() = %q", got)
+	}
+
+	var csv bytes.Buffer
+	if err := r.WriteCSV(&csv); err != nil {
+		t.Fatalf("Report.WriteCSV() error = %v", err)
+	}
+	want := "place,bot,hands,won,net,bb100,ci95,timeouts,invalid,disconnected\n" +
+		"1,tag,100,30,500,50.00,12.50,0,0,false\n" +
+		"2,sleeper,100,5,-500,-50.00,3.00,80,0,true\n"
+	if got := csv.String(); got != want {
+		t.Errorf("Report.WriteCSV() = %q, want %q", got, want)
+	}
+}
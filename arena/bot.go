@@ -0,0 +1,177 @@
+package arena
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/YoungsoonLee/poker/table"
+)
+
+const (
+	// helloTimeout is how long a new connection has to say hello.
+	helloTimeout = 10 * time.Second
+	// writeTimeout is how long a message may take to be written before the bot is disconnected.
+	writeTimeout = 5 * time.Second
+	// maxLineLength is the longest line accepted from a bot.
+	maxLineLength = 4096
+	// maxNameLength is the longest bot name accepted.
+	maxNameLength = 32
+)
+
+// bot is a connected bot process. It is the strategy of its seat: every turn is sent to it and its answer is awaited.
+// Turns must be answered in order, so the answer to a turn that timed out is discarded when it arrives.
+type bot struct {
+	name    string
+	format  string
+	conn    net.Conn
+	lines   chan string
+	timeout time.Duration
+	done    <-chan struct{}
+
+	owed     int
+	gone     bool
+	timeouts int
+	invalid  int
+}
+
+// handshake reads the hello line of a new connection, "hello <name>" or "hello <name> json", and starts reading its answers.
+func handshake(conn net.Conn, timeout time.Duration, done <-chan struct{}) (*bot, error) {
+	conn.SetReadDeadline(time.Now().Add(helloTimeout))
+
+	sc := bufio.NewScanner(conn)
+	sc.Buffer(make([]byte, 0, 256), maxLineLength)
+	if !sc.Scan() {
+		if err := sc.Err(); err != nil {
+			return nil, err
+		}
+		return nil, errors.New("connection closed before hello")
+	}
+
+	parts := strings.Fields(sc.Text())
+	if len(parts) < 2 || len(parts) > 3 || parts[0] != "hello" {
+		return nil, errors.New(`expected "hello <name>" or "hello <name> json"`)
+	}
+
+	name := parts[1]
+	if len(name) > maxNameLength || strings.Contains(name, ",") {
+		return nil, fmt.Errorf("a name of 1 to %d characters without commas is required", maxNameLength)
+	}
+
+	format := FormatText
+	if len(parts) == 3 {
+		format = parts[2]
+		if format != FormatText && format != FormatJSON {
+			return nil, fmt.Errorf("unknown format: %q", format)
+		}
+	}
+
+	conn.SetReadDeadline(time.Time{})
+
+	b := &bot{
+		name:    name,
+		format:  format,
+		conn:    conn,
+		lines:   make(chan string, 16),
+		timeout: timeout,
+		done:    done,
+	}
+	go b.readLoop(sc)
+
+	return b, nil
+}
+
+// readLoop passes the lines of the bot on until the connection is closed.
+func (b *bot) readLoop(sc *bufio.Scanner) {
+	defer close(b.lines)
+
+	for sc.Scan() {
+		line := strings.TrimSpace(sc.Text())
+		if line == "" {
+			continue
+		}
+		select {
+		case b.lines <- line:
+		case <-b.done:
+			return
+		}
+	}
+}
+
+// send writes a message to the bot. A bot that cannot be written to is disconnected.
+func (b *bot) send(m message) {
+	if b.gone {
+		return
+	}
+
+	b.conn.SetWriteDeadline(time.Now().Add(writeTimeout))
+	if _, err := b.conn.Write(append(m.encode(b.format), '\n')); err != nil {
+		b.disconnect()
+	}
+}
+
+// sendError tells the bot about a problem with one of its lines.
+func (b *bot) sendError(err error) {
+	b.send(newMessage("error", "message", err.Error()))
+}
+
+func (b *bot) disconnect() {
+	b.gone = true
+	b.conn.Close()
+}
+
+// Act sends the turn to the bot and waits for its answer.
+// The bot folds, or checks if it can, when it does not answer in time, answers with an invalid action or is disconnected.
+func (b *bot) Act(v table.View) table.Action {
+	fold := table.Action{Type: table.Fold}
+
+	b.send(newMessage("turn",
+		"street", v.Street.String(),
+		"pot", v.Pot,
+		"to_call", v.ToCall,
+		"min_raise", v.MinRaise,
+		"bet", v.Bet,
+		"stack", v.Stack,
+		"board", v.Board,
+		"hole", v.Hole,
+		"time_ms", b.timeout.Milliseconds(),
+	))
+	if b.gone {
+		return fold
+	}
+
+	timer := time.NewTimer(b.timeout)
+	defer timer.Stop()
+
+	for {
+		select {
+		case line, ok := <-b.lines:
+			if !ok {
+				b.disconnect()
+				return fold
+			}
+			if b.owed > 0 {
+				b.owed--
+				continue
+			}
+
+			a, err := parseAction(line, b.format)
+			if err != nil {
+				b.invalid++
+				b.sendError(err)
+				return fold
+			}
+			return a
+		case <-timer.C:
+			b.timeouts++
+			b.owed++
+			b.sendError(fmt.Errorf("no action within %v", b.timeout))
+			return fold
+		case <-b.done:
+			return fold
+		}
+	}
+}
@@ -0,0 +1,158 @@
+package arena
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/YoungsoonLee/poker/table"
+	"github.com/YoungsoonLee/poker/types"
+)
+
+// Formats of the messages sent to a bot.
+const (
+	FormatText = "text"
+	FormatJSON = "json"
+)
+
+// message is a line sent to a bot: a type followed by named values.
+type message struct {
+	typ    string
+	fields []field
+}
+
+// field is a named value of a message. Values are ints, bools, strings, lists of ints or lists of cards.
+type field struct {
+	key   string
+	value any
+}
+
+// newMessage returns a message of the given type with the given key and value pairs.
+func newMessage(typ string, kv ...any) message {
+	m := message{typ: typ}
+	for i := 0; i+1 < len(kv); i += 2 {
+		m.fields = append(m.fields, field{key: kv[i].(string), value: kv[i+1]})
+	}
+
+	return m
+}
+
+// encode returns the message as a line in the given format, without the newline.
+func (m message) encode(format string) []byte {
+	if format == FormatJSON {
+		return m.json()
+	}
+
+	return []byte(m.text())
+}
+
+// text returns the message like "turn hand=3 pot=30 board=AS,KD,QH".
+// Lists are comma separated, and spaces in strings are written as underscores.
+func (m message) text() string {
+	var b strings.Builder
+	b.WriteString(m.typ)
+	for _, f := range m.fields {
+		b.WriteString(" ")
+		b.WriteString(f.key)
+		b.WriteString("=")
+
+		switch v := f.value.(type) {
+		case string:
+			b.WriteString(strings.ReplaceAll(v, " ", "_"))
+		case []types.Card:
+			b.WriteString(strings.Join(cardStrings(v), ","))
+		case []string:
+			b.WriteString(strings.Join(v, ","))
+		case []int:
+			s := make([]string, len(v))
+			for i, n := range v {
+				s[i] = strconv.Itoa(n)
+			}
+			b.WriteString(strings.Join(s, ","))
+		default:
+			fmt.Fprint(&b, v)
+		}
+	}
+
+	return b.String()
+}
+
+// json returns the message as a JSON object with the type and values in order, like {"type":"turn","hand":3}.
+func (m message) json() []byte {
+	var b bytes.Buffer
+	b.WriteString(`{"type":`)
+	typ, _ := json.Marshal(m.typ)
+	b.Write(typ)
+
+	for _, f := range m.fields {
+		key, _ := json.Marshal(f.key)
+		b.WriteString(",")
+		b.Write(key)
+		b.WriteString(":")
+
+		v := f.value
+		if cards, ok := v.([]types.Card); ok {
+			v = cardStrings(cards)
+		}
+		value, err := json.Marshal(v)
+		if err != nil {
+			value = []byte("null")
+		}
+		b.Write(value)
+	}
+	b.WriteString("}")
+
+	return b.Bytes()
+}
+
+// reply is the JSON form of an action.
+type reply struct {
+	Action string `json:"action"`
+	Amount int    `json:"amount"`
+}
+
+// parseAction parses the answer of a bot to its turn: "fold", "check", "call", "bet 40" or "raise 60" in the text format,
+// or {"action": "raise", "amount": 60} in the JSON format. Amounts are the total bet on the street.
+func parseAction(line, format string) (table.Action, error) {
+	var r reply
+	if format == FormatJSON {
+		if err := json.Unmarshal([]byte(line), &r); err != nil {
+			return table.Action{}, fmt.Errorf("invalid action: %v", err)
+		}
+	} else {
+		parts := strings.Fields(line)
+		if len(parts) == 0 || len(parts) > 2 {
+			return table.Action{}, fmt.Errorf("invalid action: %q", line)
+		}
+		r.Action = parts[0]
+		if len(parts) == 2 {
+			amount, err := strconv.Atoi(parts[1])
+			if err != nil {
+				return table.Action{}, fmt.Errorf("invalid amount: %q", parts[1])
+			}
+			r.Amount = amount
+		}
+	}
+
+	for _, t := range []table.ActionType{table.Fold, table.Check, table.Call, table.Bet, table.Raise} {
+		if strings.EqualFold(r.Action, t.String()) {
+			if (t == table.Bet || t == table.Raise) && r.Amount <= 0 {
+				return table.Action{}, fmt.Errorf("%s needs a positive amount", t)
+			}
+			return table.Action{Type: t, Amount: r.Amount}, nil
+		}
+	}
+
+	return table.Action{}, fmt.Errorf("unknown action: %q", r.Action)
+}
+
+func cardStrings(cards []types.Card) []string {
+	s := make([]string, len(cards))
+	for i, c := range cards {
+		s[i] = c.String()
+	}
+
+	return s
+}
@@ -0,0 +1,64 @@
+package arena
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/YoungsoonLee/poker/table"
+	"github.com/YoungsoonLee/poker/types"
+)
+
+func TestMessage_Encode(t *testing.T) {
+	board, err := types.ParseCards("2h7h9c")
+	if err != nil {
+		t.Fatalf("types.ParseCards() error = %v", err)
+	}
+	m := newMessage("showdown", "seat", 2, "cards", board, "rank", "High Card", "net", []int{-20, 40}, "all_in", false)
+
+	tests := []struct {
+		name   string
+		format string
+		want   string
+	}{
+		{name: "text", format: FormatText, want: "showdown seat=2 cards=2H,7H,9C rank=High_Card net=-20,40 all_in=false"},
+		{name: "json", format: FormatJSON, want: `{"type":"showdown","seat":2,"cards":["2H","7H","9C"],"rank":"High Card","net":[-20,40],"all_in":false}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := string(m.encode(tt.format)); got != tt.want {
+				t.Errorf("message.encode() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseAction(t *testing.T) {
+	tests := []struct {
+		name    string
+		line    string
+		format  string
+		want    table.Action
+		wantErr bool
+	}{
+		{name: "fold", line: "fold", format: FormatText, want: table.Action{Type: table.Fold}},
+		{name: "upper case", line: "CALL", format: FormatText, want: table.Action{Type: table.Call}},
+		{name: "raise", line: "raise 60", format: FormatText, want: table.Action{Type: table.Raise, Amount: 60}},
+		{name: "bet without amount", line: "bet", format: FormatText, wantErr: true},
+		{name: "invalid amount", line: "bet lots", format: FormatText, wantErr: true},
+		{name: "unknown action", line: "allin", format: FormatText, wantErr: true},
+		{name: "json", line: `{"action": "bet", "amount": 40}`, format: FormatJSON, want: table.Action{Type: table.Bet, Amount: 40}},
+		{name: "json check", line: `{"action": "check"}`, format: FormatJSON, want: table.Action{Type: table.Check}},
+		{name: "text in json format", line: "call", format: FormatJSON, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseAction(tt.line, tt.format)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseAction() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseAction() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
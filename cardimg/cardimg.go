@@ -0,0 +1,235 @@
+// Package cardimg draws hands, boards and evaluated showdowns as SVG and PNG images,
+// e.g. for blog posts and training material.
+//
+// An image is a title and rows of cards. Each row has a label, like "Hand 1" or "Board", and a note,
+// like the rank of the hand, and the rows of the winners of a showdown are highlighted.
+// PNG images are drawn with the standard image packages and a built-in pixel font.
+package cardimg
+
+import (
+	"fmt"
+	"image/color"
+
+	"github.com/YoungsoonLee/poker/poker"
+	"github.com/YoungsoonLee/poker/types"
+)
+
+// Geometry of an image in pixels.
+const (
+	cardWidth   = 60
+	cardHeight  = 84
+	cardGap     = 8
+	cardRadius  = 6
+	margin      = 16
+	titleHeight = 32
+	labelHeight = 24
+	rowPadding  = 8
+	rowGap      = 12
+	minWidth    = 320
+	// textScale and titleScale are the sizes of the pixel font of labels and titles, which is 5 by 7 pixels unscaled.
+	textScale  = 2
+	titleScale = 3
+)
+
+// Colors of an image: a green felt with gold highlights, and white cards with red and black suits.
+var (
+	feltColor      = color.RGBA{0x1b, 0x5e, 0x20, 0xff}
+	highlightColor = color.RGBA{0x2e, 0x7d, 0x32, 0xff}
+	goldColor      = color.RGBA{0xff, 0xd5, 0x4f, 0xff}
+	textColor      = color.RGBA{0xff, 0xff, 0xff, 0xff}
+	cardColor      = color.RGBA{0xff, 0xff, 0xff, 0xff}
+	borderColor    = color.RGBA{0x9e, 0x9e, 0x9e, 0xff}
+	redColor       = color.RGBA{0xc6, 0x28, 0x28, 0xff}
+	blackColor     = color.RGBA{0x21, 0x21, 0x21, 0xff}
+)
+
+// Row is a row of cards with a label and a note. Highlight marks the rows of the winners.
+type Row struct {
+	Label     string
+	Note      string
+	Cards     []types.Card
+	Highlight bool
+}
+
+// Image is a title and rows of cards.
+type Image struct {
+	Title string
+	Rows  []Row
+}
+
+// HandRow returns a row of cards. If there are 5 to 7 cards it is noted with the rank of their best hand.
+func HandRow(label string, cards []types.Card) Row {
+	row := Row{Label: label, Cards: cards}
+	if len(cards) <= 7 {
+		if hand, err := poker.BestHand(0, cards); err == nil {
+			row.Note, _ = hand.Evaluate()
+		}
+	}
+
+	return row
+}
+
+// ResultRows returns a row per hand of the results of EvaluateHands, strongest first,
+// with the best five cards of each hand and the winners highlighted.
+func ResultRows(results []poker.HandResult) []Row {
+	rows := make([]Row, len(results))
+	for i, r := range results {
+		rows[i] = Row{
+			Label:     fmt.Sprintf("Hand %d", r.HandID),
+			Note:      r.Rank,
+			Cards:     r.Card,
+			Highlight: r.Score == results[0].Score,
+		}
+	}
+
+	return rows
+}
+
+// Showdown returns an image of hands and a board, e.g. Texas Hold'em hole cards and the community cards.
+// The board comes first if there is one. A single hand is shown with the rank of its best hand if it can make one.
+// Two or more hands are ranked with EvaluateHands on their best five cards with the board, strongest first,
+// and the winners are highlighted. A card may only be used once.
+func Showdown(title string, hands [][]types.Card, board []types.Card) (Image, error) {
+	img := Image{Title: title}
+
+	seen := make(map[types.Card]bool)
+	for _, cards := range append([][]types.Card{board}, hands...) {
+		for _, c := range cards {
+			if seen[c] {
+				return Image{}, fmt.Errorf("card %s is used more than once", c)
+			}
+			seen[c] = true
+		}
+	}
+
+	if len(board) > 0 {
+		img.Rows = append(img.Rows, Row{Label: "Board", Cards: board})
+	}
+
+	switch len(hands) {
+	case 0:
+		if len(board) == 0 {
+			return Image{}, fmt.Errorf("no cards to draw")
+		}
+		return img, nil
+	case 1:
+		row := HandRow("Hand 1", hands[0])
+		if len(board) > 0 {
+			row.Note = HandRow("", append(append([]types.Card(nil), hands[0]...), board...)).Note
+		}
+		img.Rows = append(img.Rows, row)
+		return img, nil
+	}
+
+	best := make(poker.Hands, len(hands))
+	for i, cards := range hands {
+		all := append(append([]types.Card(nil), cards...), board...)
+		if len(all) > 7 {
+			return Image{}, fmt.Errorf("hand %d: should have at most 7 cards with the board, got %d", i+1, len(all))
+		}
+
+		hand, err := poker.BestHand(i+1, all)
+		if err != nil {
+			return Image{}, fmt.Errorf("hand %d: %v", i+1, err)
+		}
+		best[i] = hand
+	}
+
+	// the rows show the cards of each hand, ordered by its best five cards with the board
+	results := poker.EvaluateHands(best)
+	for _, r := range results {
+		img.Rows = append(img.Rows, Row{
+			Label:     fmt.Sprintf("Hand %d", r.HandID),
+			Note:      r.Rank,
+			Cards:     hands[r.HandID-1],
+			Highlight: r.Score == results[0].Score,
+		})
+	}
+
+	return img, nil
+}
+
+// layout is the position of everything in an image.
+type layout struct {
+	width, height int
+	titleY        int
+	rows          []rowLayout
+}
+
+// rowLayout is the position of a row: the box around it, the baseline of its label and the top left of its cards.
+type rowLayout struct {
+	boxY, boxHeight int
+	labelY          int
+	cardsY          int
+	cardsX          []int
+}
+
+// layout computes the position of the title, rows and cards.
+func (img Image) layout() layout {
+	l := layout{width: max(minWidth, 2*margin+textWidth(img.Title, titleScale))}
+	for _, row := range img.Rows {
+		cards := len(row.Cards)*(cardWidth+cardGap) - cardGap
+		if w := 2*margin + 2*rowPadding + max(cards, textWidth(row.label(), textScale)); w > l.width {
+			l.width = w
+		}
+	}
+
+	y := margin
+	if img.Title != "" {
+		l.titleY = y + titleHeight/2
+		y += titleHeight
+	}
+
+	for i, row := range img.Rows {
+		if i > 0 {
+			y += rowGap
+		}
+
+		r := rowLayout{boxY: y, boxHeight: 2*rowPadding + labelHeight + cardHeight}
+		r.labelY = y + rowPadding + labelHeight/2
+		r.cardsY = y + rowPadding + labelHeight
+		for k := range row.Cards {
+			r.cardsX = append(r.cardsX, margin+rowPadding+k*(cardWidth+cardGap))
+		}
+
+		l.rows = append(l.rows, r)
+		y += r.boxHeight
+	}
+	l.height = y + margin
+
+	return l
+}
+
+// label returns the text above the cards of a row.
+func (r Row) label() string {
+	s := r.Label
+	if r.Note != "" {
+		if s != "" {
+			s += ": "
+		}
+		s += r.Note
+	}
+	if r.Highlight {
+		s += " (winner)"
+	}
+
+	return s
+}
+
+// suitColor returns the color of the suit of a card.
+func suitColor(c types.Card) color.RGBA {
+	if c.Suit == "H" || c.Suit == "D" {
+		return redColor
+	}
+
+	return blackColor
+}
+
+// displayRank returns the rank of a card as it is printed on a card, with 10 instead of T.
+func displayRank(c types.Card) string {
+	if c.Rank == "T" {
+		return "10"
+	}
+
+	return c.Rank
+}
@@ -0,0 +1,107 @@
+package cardimg
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/YoungsoonLee/poker/poker"
+	"github.com/YoungsoonLee/poker/types"
+)
+
+// mustCards parses a card string like "AsKd" into cards.
+func mustCards(t *testing.T, s string) []types.Card {
+	t.Helper()
+
+	cards, err := types.ParseCards(s)
+	if err != nil {
+		t.Fatalf("types.ParseCards() error = %v", err)
+	}
+
+	return cards
+}
+
+func TestShowdown(t *testing.T) {
+	tests := []struct {
+		name       string
+		hands      []string
+		board      string
+		wantLabels []string
+		wantErr    bool
+	}{
+		{
+			name:       "board only",
+			board:      "2h7h9c",
+			wantLabels: []string{"Board"},
+		},
+		{
+			name:       "single hand",
+			hands:      []string{"AsKsQsJsTs"},
+			wantLabels: []string{"Hand 1: Royal Flush"},
+		},
+		{
+			name:       "hole cards with a board",
+			hands:      []string{"QsQd", "AhKh"},
+			board:      "2h7h9cTs3h",
+			wantLabels: []string{"Board", "Hand 2: Flush (winner)", "Hand 1: One Pair"},
+		},
+		{
+			name:       "split pot",
+			hands:      []string{"Td3c", "Tc4d"},
+			board:      "AsKsQsJh2h",
+			wantLabels: []string{"Board", "Hand 1: Straight (winner)", "Hand 2: Straight (winner)"},
+		},
+		{name: "nothing", wantErr: true},
+		{name: "card used twice", hands: []string{"AhKh", "AhQd"}, board: "2h7h9c", wantErr: true},
+		{name: "too few cards", hands: []string{"AhKh", "QsQd"}, board: "2h", wantErr: true},
+		{name: "too many cards", hands: []string{"AhKhQhJh", "QsQd"}, board: "2h7h9c3c", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var hands [][]types.Card
+			for _, h := range tt.hands {
+				hands = append(hands, mustCards(t, h))
+			}
+
+			img, err := Showdown("", hands, mustCards(t, tt.board))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Showdown() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			var labels []string
+			for _, row := range img.Rows {
+				labels = append(labels, row.label())
+			}
+			if !reflect.DeepEqual(labels, tt.wantLabels) {
+				t.Errorf("Showdown() rows = %q, want %q", labels, tt.wantLabels)
+			}
+		})
+	}
+}
+
+func TestResultRows(t *testing.T) {
+	results := poker.EvaluateHands(poker.Hands{
+		{HandID: 1, Cards: mustCards(t, "3s4h5d6c7s")},
+		{HandID: 2, Cards: mustCards(t, "2c2d2h3c3d")},
+	})
+
+	rows := ResultRows(results)
+	if len(rows) != 2 || rows[0].Label != "Hand 2" || !rows[0].Highlight || rows[1].Highlight || rows[1].Note != "Straight" {
+		t.Errorf("ResultRows() = %+v, want hand 2 highlighted before hand 1", rows)
+	}
+}
+
+func TestImage_Layout(t *testing.T) {
+	img := Image{Title: "Showdown", Rows: []Row{HandRow("Board", mustCards(t, "2h7h9cTs3h")), HandRow("Hand 1", mustCards(t, "AhKh"))}}
+	l := img.layout()
+
+	// five cards are wider than the minimum width, and the rows are stacked under the title
+	if want := 2*margin + 2*rowPadding + 5*cardWidth + 4*cardGap; l.width != want {
+		t.Errorf("layout() width = %d, want %d", l.width, want)
+	}
+	if want := 2*margin + titleHeight + 2*(2*rowPadding+labelHeight+cardHeight) + rowGap; l.height != want {
+		t.Errorf("layout() height = %d, want %d", l.height, want)
+	}
+	if len(l.rows[1].cardsX) != 2 || l.rows[1].cardsY <= l.rows[0].cardsY+cardHeight {
+		t.Errorf("layout() rows = %+v", l.rows)
+	}
+}
@@ -0,0 +1,87 @@
+package cardimg
+
+import (
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"io"
+
+	"github.com/YoungsoonLee/poker/types"
+)
+
+// Sizes of the rank and suit drawn on a card with the pixel font.
+const (
+	rankScale = 3
+	suitScale = 4
+	suitSize  = 9 * suitScale
+)
+
+// WritePNG writes the image as a PNG.
+func (img Image) WritePNG(w io.Writer) error {
+	return png.Encode(w, img.Draw())
+}
+
+// Draw draws the image with the standard image packages.
+func (img Image) Draw() *image.RGBA {
+	l := img.layout()
+
+	m := image.NewRGBA(image.Rect(0, 0, l.width, l.height))
+	fill(m, m.Bounds(), feltColor)
+
+	if img.Title != "" {
+		drawText(m, margin, l.titleY-glyphHeight*titleScale/2, img.Title, titleScale, textColor)
+	}
+
+	for i, row := range img.Rows {
+		r := l.rows[i]
+
+		labelColor := textColor
+		if row.Highlight {
+			box := image.Rect(margin, r.boxY, l.width-margin, r.boxY+r.boxHeight)
+			fillRound(m, box, cardRadius, goldColor)
+			fillRound(m, box.Inset(2), cardRadius-2, highlightColor)
+			labelColor = goldColor
+		}
+
+		drawText(m, margin+rowPadding, r.labelY-glyphHeight*textScale/2, row.label(), textScale, labelColor)
+		for k, c := range row.Cards {
+			drawCard(m, r.cardsX[k], r.cardsY, c)
+		}
+	}
+
+	return m
+}
+
+// drawCard draws a card with its top left corner at x and y: the rank in the corner and the suit in the middle.
+func drawCard(m *image.RGBA, x, y int, c types.Card) {
+	rect := image.Rect(x, y, x+cardWidth, y+cardHeight)
+	fillRound(m, rect, cardRadius, borderColor)
+	fillRound(m, rect.Inset(1), cardRadius-1, cardColor)
+
+	col := suitColor(c)
+	drawText(m, x+5, y+5, displayRank(c), rankScale, col)
+	if suit, ok := suitGlyphs[c.Suit]; ok {
+		drawBitmap(m, x+(cardWidth-suitSize)/2, y+cardHeight-suitSize-8, suit, suitScale, col)
+	}
+}
+
+// fill fills a rectangle with a color.
+func fill(m *image.RGBA, r image.Rectangle, c color.Color) {
+	draw.Draw(m, r, &image.Uniform{C: c}, image.Point{}, draw.Src)
+}
+
+// fillRound fills a rectangle with rounded corners of the given radius with a color.
+func fillRound(m *image.RGBA, r image.Rectangle, radius int, c color.Color) {
+	for y := r.Min.Y; y < r.Max.Y; y++ {
+		for x := r.Min.X; x < r.Max.X; x++ {
+			// the distance from the center of the nearest corner circle, if the pixel is in a corner
+			dx := max(r.Min.X+radius-x-1, x-(r.Max.X-radius), 0)
+			dy := max(r.Min.Y+radius-y-1, y-(r.Max.Y-radius), 0)
+			if dx*dx+dy*dy > radius*radius {
+				continue
+			}
+			m.Set(x, y, c)
+		}
+	}
+}
@@ -0,0 +1,66 @@
+package cardimg
+
+import (
+	"bytes"
+	"image/png"
+	"testing"
+)
+
+func TestImage_WritePNG(t *testing.T) {
+	img := Image{Rows: []Row{{Label: "Hand 1", Cards: mustCards(t, "AhKs"), Highlight: true}}}
+
+	var b bytes.Buffer
+	if err := img.WritePNG(&b); err != nil {
+		t.Fatalf("Image.WritePNG() error = %v", err)
+	}
+
+	m, err := png.Decode(&b)
+	if err != nil {
+		t.Fatalf("png.Decode() error = %v", err)
+	}
+
+	l := img.layout()
+	if got := m.Bounds().Size(); got.X != l.width || got.Y != l.height {
+		t.Fatalf("size = %v, want %dx%d", got, l.width, l.height)
+	}
+
+	r := l.rows[0]
+	tests := []struct {
+		name string
+		x, y int
+		want any
+	}{
+		{name: "felt", x: 1, y: 1, want: feltColor},
+		{name: "highlight border", x: margin + 10, y: r.boxY, want: goldColor},
+		{name: "highlight", x: margin + 4, y: r.boxY + r.boxHeight/2, want: highlightColor},
+		{name: "card", x: r.cardsX[0] + cardWidth - 4, y: r.cardsY + 4, want: cardColor},
+		{name: "rounded corner of a card", x: r.cardsX[0], y: r.cardsY, want: highlightColor},
+		{name: "card border", x: r.cardsX[0] + cardWidth/2, y: r.cardsY, want: borderColor},
+		{name: "heart", x: r.cardsX[0] + cardWidth/2, y: r.cardsY + cardHeight - suitSize - 8 + 3*suitScale, want: redColor},
+		{name: "spade", x: r.cardsX[1] + cardWidth/2, y: r.cardsY + cardHeight - suitSize - 8 + 3*suitScale, want: blackColor},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := m.At(tt.x, tt.y); got != tt.want {
+				t.Errorf("pixel at %d,%d = %v, want %v", tt.x, tt.y, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTextWidth(t *testing.T) {
+	tests := []struct {
+		s     string
+		scale int
+		want  int
+	}{
+		{s: "", scale: 2, want: 0},
+		{s: "A", scale: 2, want: 10},
+		{s: "10", scale: 3, want: 33},
+	}
+	for _, tt := range tests {
+		if got := textWidth(tt.s, tt.scale); got != tt.want {
+			t.Errorf("textWidth(%q, %d) = %d, want %d", tt.s, tt.scale, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package cardimg
+
+import (
+	"bufio"
+	"encoding/xml"
+	"fmt"
+	"image/color"
+	"io"
+	"strings"
+)
+
+// suitSymbols maps the suit of a card to the symbol drawn in the SVG.
+var suitSymbols = map[string]string{"S": "♠", "H": "♥", "D": "♦", "C": "♣"}
+
+// WriteSVG writes the image as an SVG document. Text is drawn with the sans-serif font of the viewer.
+func (img Image) WriteSVG(w io.Writer) error {
+	l := img.layout()
+	b := bufio.NewWriter(w)
+
+	fmt.Fprintf(b, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" font-family="Helvetica, Arial, sans-serif">`+"\n",
+		l.width, l.height, l.width, l.height)
+	fmt.Fprintf(b, `<rect width="%d" height="%d" fill="%s"/>`+"\n", l.width, l.height, hex(feltColor))
+
+	if img.Title != "" {
+		fmt.Fprintf(b, `<text x="%d" y="%d" font-size="22" font-weight="bold" fill="%s" dominant-baseline="middle">%s</text>`+"\n",
+			margin, l.titleY, hex(textColor), escape(img.Title))
+	}
+
+	for i, row := range img.Rows {
+		r := l.rows[i]
+
+		labelColor := textColor
+		if row.Highlight {
+			fmt.Fprintf(b, `<rect x="%d" y="%d" width="%d" height="%d" rx="%d" fill="%s" stroke="%s" stroke-width="2"/>`+"\n",
+				margin+1, r.boxY+1, l.width-2*margin-2, r.boxHeight-2, cardRadius, hex(highlightColor), hex(goldColor))
+			labelColor = goldColor
+		}
+
+		fmt.Fprintf(b, `<text x="%d" y="%d" font-size="15" fill="%s" dominant-baseline="middle">%s</text>`+"\n",
+			margin+rowPadding, r.labelY, hex(labelColor), escape(row.label()))
+
+		for k, c := range row.Cards {
+			x, y := r.cardsX[k], r.cardsY
+			col := hex(suitColor(c))
+			fmt.Fprintf(b, `<g><rect x="%d" y="%d" width="%d" height="%d" rx="%d" fill="%s" stroke="%s"/>`,
+				x, y, cardWidth, cardHeight, cardRadius, hex(cardColor), hex(borderColor))
+			fmt.Fprintf(b, `<text x="%d" y="%d" font-size="18" font-weight="bold" fill="%s">%s</text>`,
+				x+6, y+22, col, displayRank(c))
+			fmt.Fprintf(b, `<text x="%d" y="%d" font-size="38" text-anchor="middle" fill="%s">%s</text></g>`+"\n",
+				x+cardWidth/2, y+cardHeight-14, col, suitSymbols[c.Suit])
+		}
+	}
+
+	fmt.Fprintln(b, "</svg>")
+
+	return b.Flush()
+}
+
+// hex returns a color like "#1b5e20".
+func hex(c color.RGBA) string {
+	return fmt.Sprintf("#%02x%02x%02x", c.R, c.G, c.B)
+}
+
+// escape escapes a text for XML.
+func escape(s string) string {
+	var b strings.Builder
+	_ = xml.EscapeText(&b, []byte(s))
+
+	return b.String()
+}
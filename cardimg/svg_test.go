@@ -0,0 +1,62 @@
+package cardimg
+
+import (
+	"bytes"
+	"encoding/xml"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/YoungsoonLee/poker/types"
+)
+
+func TestImage_WriteSVG(t *testing.T) {
+	img, err := Showdown("A & B", [][]types.Card{mustCards(t, "AhKh"), mustCards(t, "QsQd")}, mustCards(t, "2h7h9cTs3h"))
+	if err != nil {
+		t.Fatalf("Showdown() error = %v", err)
+	}
+
+	var b bytes.Buffer
+	if err := img.WriteSVG(&b); err != nil {
+		t.Fatalf("Image.WriteSVG() error = %v", err)
+	}
+
+	// the document is well-formed XML with a card per rect, besides the background and the highlight of the winner
+	rects, texts := 0, []string{}
+	dec := xml.NewDecoder(&b)
+	var inText bool
+	for {
+		tok, err := dec.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("xml.Decoder.Token() error = %v", err)
+		}
+
+		switch tok := tok.(type) {
+		case xml.StartElement:
+			inText = tok.Name.Local == "text"
+			if tok.Name.Local == "rect" {
+				rects++
+			}
+		case xml.CharData:
+			if inText {
+				texts = append(texts, string(tok))
+			}
+		case xml.EndElement:
+			inText = false
+		}
+	}
+
+	if want := 1 + 1 + 9; rects != want {
+		t.Errorf("rects = %d, want %d", rects, want)
+	}
+
+	got := strings.Join(texts, "|")
+	for _, want := range []string{"A & B", "Board", "Hand 1: Flush (winner)", "10", "♠", "♥"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("texts = %s, want %q", got, want)
+		}
+	}
+}
@@ -0,0 +1,173 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	"github.com/YoungsoonLee/poker/poker"
+	"github.com/YoungsoonLee/poker/types"
+	"github.com/spf13/cobra"
+)
+
+// adviseCmd returns a Cobra command for finding the best cards to hold from a five-card draw hand.
+// Every hold is valued by enumerating every redraw against a video poker paytable, or against the final hand of an opponent.
+func adviseCmd() *cobra.Command {
+	var paytableName, minPair, opponent string
+	var top int
+
+	c := &cobra.Command{
+		Use:   "advise [cards]",
+		Short: "Advise: Show the expected value of every hold of a five-card draw hand and the optimal hold",
+		Long: "Advise: Show the expected value of each of the 32 ways to hold and discard the cards of a five-card draw hand, ex) AsKsQsJs2d.\n" +
+			"Every redraw from the rest of the deck is enumerated and valued by a video poker paytable, or with --opponent by the share of the pot\n" +
+			"against the final cards of an opponent, whose cards cannot be drawn.",
+		Args:        cobra.MinimumNArgs(1),
+		Annotations: outputAnnotations(),
+
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cards, err := types.ParseCards(strings.Join(args, " "))
+			if err != nil {
+				return err
+			}
+
+			var objective poker.DrawObjective
+			if opponent != "" {
+				if cmd.Flags().Changed("paytable") || cmd.Flags().Changed("min-pair") {
+					return errors.New("give --opponent or a paytable, not both")
+				}
+
+				opponentCards, err := types.ParseCards(opponent)
+				if err != nil {
+					return err
+				}
+				if objective, err = poker.NewOpponentEquity(opponentCards); err != nil {
+					return err
+				}
+			} else {
+				paytable, err := poker.ParsePaytable(paytableName)
+				if err != nil {
+					return err
+				}
+				if cmd.Flags().Changed("min-pair") {
+					if _, ok := types.RankMap[strings.ToUpper(minPair)]; !ok {
+						return fmt.Errorf("invalid rank: %s", minPair)
+					}
+					paytable.MinPair = strings.ToUpper(minPair)
+				}
+				objective = paytable
+			}
+
+			holds, err := poker.Hand{Cards: cards}.AdviseDraw(objective)
+			if err != nil {
+				return err
+			}
+
+			if outputFormat != outputText {
+				return writeReport(cmd.OutOrStdout(), newAdviseOutput(cards, holds, top))
+			}
+
+			logHolds(cards, holds, top)
+			return nil
+		},
+	}
+
+	c.Flags().StringVar(&paytableName, "paytable", poker.JacksOrBetter.Name, "Paytable: jacks (9/6 Jacks or Better), tens (6/5 Tens or Better) or 9 comma separated pays from Royal Flush to One Pair")
+	c.Flags().StringVar(&minPair, "min-pair", "", "Lowest pair paid by the paytable, ex) J (default the pair of the paytable)")
+	c.Flags().StringVar(&opponent, "opponent", "", "Final five cards of an opponent, ex) 3c3d3h7s8s, to value holds by the share of the pot instead of a paytable")
+	c.Flags().IntVar(&top, "top", 32, "Number of holds to show, from the optimal one")
+	return c
+}
+
+// logHolds logs the optimal hold and the EV table of the holds of a hand, showing at most top holds.
+func logHolds(cards []types.Card, holds []poker.Hold, top int) {
+	r := cardRenderer(log.Writer())
+
+	best := holds[0]
+	if len(best.Discards) == 0 {
+		log.Printf("Optimal hold: stand pat with %s, EV: %.4f\n", renderCards(r, cards), best.EV)
+	} else {
+		log.Printf("Optimal hold: %s, Discard: %s, EV: %.4f\n", renderHold(r, best.Keep), renderCards(r, discarded(cards, best.Discards)), best.EV)
+	}
+
+	for i, h := range holds {
+		if i >= top {
+			break
+		}
+		log.Printf("Hold [%d]. Keep: %s, Draw: %d, EV: %.4f, Redraws: %d\n", i+1, renderHold(r, h.Keep), len(h.Discards), h.EV, h.Draws)
+	}
+}
+
+// adviseOutput is the EV of the holds of a hand in the json and csv output formats, from the optimal hold,
+// with one csv record per hold. Cards are concatenated like "ASKS" in the csv.
+type adviseOutput struct {
+	Cards []string     `json:"cards"`
+	Holds []holdOutput `json:"holds"`
+}
+
+// holdOutput is a hold with its kept and discarded cards, its EV and the number of redraws it was valued over.
+type holdOutput struct {
+	Keep    []string `json:"keep"`
+	Discard []string `json:"discard"`
+	EV      float64  `json:"ev"`
+	Redraws int      `json:"redraws"`
+}
+
+// newAdviseOutput converts the holds of the cards to their json and csv form, keeping at most top holds.
+func newAdviseOutput(cards []types.Card, holds []poker.Hold, top int) adviseOutput {
+	out := adviseOutput{Cards: cardNames(cards), Holds: []holdOutput{}}
+	for i, h := range holds {
+		if i >= top {
+			break
+		}
+		out.Holds = append(out.Holds, holdOutput{Keep: cardNames(h.Keep), Discard: cardNames(discarded(cards, h.Discards)), EV: h.EV, Redraws: h.Draws})
+	}
+
+	return out
+}
+
+// csvHeader implements report.
+func (o adviseOutput) csvHeader() []string {
+	return []string{"hold", "keep", "discard", "ev", "redraws"}
+}
+
+// csvRecords implements report with one record per hold.
+func (o adviseOutput) csvRecords() [][]string {
+	records := make([][]string, len(o.Holds))
+	for i, h := range o.Holds {
+		records[i] = []string{strconv.Itoa(i + 1), strings.Join(h.Keep, ""), strings.Join(h.Discard, ""), formatFloat(h.EV), strconv.Itoa(h.Redraws)}
+	}
+
+	return records
+}
+
+// cardNames returns the two character form of the cards, and an empty list for no cards.
+func cardNames(cards []types.Card) []string {
+	names := make([]string, len(cards))
+	for i, c := range cards {
+		names[i] = c.String()
+	}
+
+	return names
+}
+
+// renderHold renders the kept cards of a hold, or "nothing" when every card is thrown away.
+func renderHold(r types.Renderer, keep []types.Card) string {
+	if len(keep) == 0 {
+		return "nothing"
+	}
+
+	return renderCards(r, keep)
+}
+
+// discarded returns the cards at the discarded indexes.
+func discarded(cards []types.Card, discards []int) []types.Card {
+	out := make([]types.Card, len(discards))
+	for i, index := range discards {
+		out[i] = cards[index]
+	}
+
+	return out
+}
@@ -0,0 +1,83 @@
+package cmd
+
+import (
+	"context"
+	"log"
+	"net"
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/YoungsoonLee/poker/arena"
+	"github.com/spf13/cobra"
+)
+
+// arenaCmd returns a Cobra command for running a competition between bot processes over TCP.
+// It waits for the bots to connect, plays the matches and writes the result table to stdout.
+// The result table can also be written as CSV with the --csv flag.
+func arenaCmd() *cobra.Command {
+	var addr, csvPath string
+	cfg := arena.Config{Logf: log.Printf}
+
+	c := &cobra.Command{
+		Use:   "arena",
+		Short: "Arena: Run a competition between bot processes that connect over TCP",
+		Long: "Arena: Run a competition between bot processes that connect over TCP.\n" +
+			"Bots say \"hello <name>\" and answer every turn with fold, check, call, bet <amount> or raise <amount>.\n" +
+			"The protocol is documented in the arena package, and examples/arenabot is a sample bot.",
+
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := cfg.Validate(); err != nil {
+				return err
+			}
+
+			lis, err := net.Listen("tcp", addr)
+			if err != nil {
+				return err
+			}
+
+			ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+			defer stop()
+
+			log.Printf("Waiting for %d bots on %s\n", cfg.Bots, lis.Addr())
+			report, err := arena.Run(ctx, lis, cfg)
+			if err != nil {
+				return err
+			}
+
+			log.Printf("Played %d matches, %d hands\n", report.Matches, report.Hands)
+			if err := report.WriteTable(os.Stdout); err != nil {
+				return err
+			}
+
+			if csvPath == "" {
+				return nil
+			}
+
+			f, err := os.Create(csvPath)
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+
+			if err := report.WriteCSV(f); err != nil {
+				return err
+			}
+			log.Printf("Result table written to %s\n", csvPath)
+
+			return nil
+		},
+	}
+
+	c.Flags().StringVar(&addr, "addr", "localhost:9000", "Address to listen for bots on")
+	c.Flags().IntVar(&cfg.Bots, "bots", 2, "Number of bots to wait for, who all sit at one table")
+	c.Flags().IntVar(&cfg.Matches, "matches", 1, "Number of matches, with the seats rotated between matches")
+	c.Flags().IntVar(&cfg.Hands, "hands", 1000, "Number of hands per match")
+	c.Flags().IntVar(&cfg.Stack, "stack", 1000, "Stack of every bot, reset before every hand")
+	c.Flags().IntVar(&cfg.SmallBlind, "sb", 5, "Small blind")
+	c.Flags().IntVar(&cfg.BigBlind, "bb", 10, "Big blind")
+	c.Flags().DurationVar(&cfg.ActionTimeout, "timeout", time.Second, "Time a bot has to answer each turn")
+	c.Flags().Int64Var(&cfg.Seed, "seed", 0, "Random seed for reproducible cards (default current time)")
+	c.Flags().StringVar(&csvPath, "csv", "", "Write the result table as CSV to this file")
+	return c
+}
@@ -0,0 +1,225 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"math/rand"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/YoungsoonLee/poker/draw"
+	"github.com/YoungsoonLee/poker/table"
+	"github.com/YoungsoonLee/poker/types"
+	"github.com/manifoldco/promptui"
+	"github.com/spf13/cobra"
+)
+
+const (
+	drawFold  = "Fold"
+	drawCheck = "Check"
+	drawCall  = "Call"
+	drawBet   = "Bet"
+	drawRaise = "Raise"
+)
+
+// drawCmd returns a Cobra command for playing Fixed-Limit Five-Card Draw between bots, or against them through prompts.
+// Stacks carry over from hand to hand until the hands are played or fewer than two seats have chips.
+func drawCmd() *cobra.Command {
+	var bots []string
+	var human string
+	var hands, stack int
+	var stakes draw.Stakes
+	var seed int64
+	var show bool
+
+	c := &cobra.Command{
+		Use:   "draw",
+		Short: "Draw: Play Five-Card Draw between bots or against them",
+		Long: "Draw: Play Fixed-Limit Five-Card Draw between bots, or against them with --human, and report the net chips of every seat.\n" +
+			"Available bots: " + strings.Join(draw.StrategyNames(), ", "),
+		Annotations: outputAnnotations(),
+
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if seed == 0 {
+				seed = time.Now().UnixNano()
+			}
+			rng := rand.New(rand.NewSource(seed))
+
+			var seats []*draw.Seat
+			var h *humanDraw
+			if human != "" {
+				h = &humanDraw{renderer: cardRenderer(log.Writer())}
+				seats = append(seats, &draw.Seat{Name: human, Stack: stack, Strategy: h})
+			}
+			for _, name := range bots {
+				strategy, err := draw.NewStrategy(name, rng)
+				if err != nil {
+					return err
+				}
+				seats = append(seats, &draw.Seat{Name: name, Stack: stack, Strategy: strategy})
+			}
+
+			t, err := draw.New(seats, stakes, rng)
+			if err != nil {
+				return err
+			}
+
+			for i := 0; i < hands; i++ {
+				result, err := t.PlayHand()
+				if errors.Is(err, table.ErrNotEnoughPlayers) {
+					break
+				}
+				if err != nil {
+					return err
+				}
+				if h != nil && h.err != nil {
+					return h.err
+				}
+
+				if show || h != nil {
+					logDrawHand(result)
+				}
+			}
+
+			if outputFormat != outputText {
+				out := playOutput{Game: "draw", Hands: t.HandNo(), Seats: []playSeatOutput{}}
+				for i, s := range seats {
+					net := s.Stack - stack
+					out.Seats = append(out.Seats, playSeatOutput{Seat: i, Name: s.Name, Stack: s.Stack, Net: net, BB100: float64(net) * 100 / float64(2*stakes.BigBlind) / float64(max(t.HandNo(), 1))})
+				}
+				return writeReport(cmd.OutOrStdout(), out)
+			}
+
+			log.Printf("Played %d hands of Five-Card Draw\n", t.HandNo())
+			for i, s := range seats {
+				log.Printf("Seat %d. Player: %s, Stack: %d, Net: %d\n", i, s.Name, s.Stack, s.Stack-stack)
+			}
+
+			return nil
+		},
+	}
+
+	c.Flags().StringSliceVar(&bots, "bots", []string{"calling", "random"}, "Bots to seat, one per seat, at most 8 seats in total")
+	c.Flags().StringVar(&human, "human", "", "Name of a human player seated first and prompted for every decision")
+	c.Flags().IntVar(&hands, "hands", 1000, "Number of hands to play")
+	c.Flags().IntVar(&stack, "stack", 1000, "Starting stack of every seat")
+	c.Flags().IntVar(&stakes.Ante, "ante", 0, "Ante")
+	c.Flags().IntVar(&stakes.SmallBlind, "sb", 5, "Small blind")
+	c.Flags().IntVar(&stakes.BigBlind, "bb", 10, "Big blind, and the bet before the draw. Bets after the draw are twice as big")
+	c.Flags().Int64Var(&seed, "seed", 0, "Random seed for reproducible runs (default current time)")
+	c.Flags().BoolVar(&show, "show", false, "Log the cards and the showdown of every hand")
+	return c
+}
+
+// humanDraw is a draw.Strategy that prompts for every decision.
+// A failed prompt folds the hand and stops the game with the error.
+type humanDraw struct {
+	renderer types.Renderer
+	err      error
+}
+
+// Act implements draw.Strategy.
+func (h *humanDraw) Act(v draw.View) table.Action {
+	if h.err != nil {
+		return table.Action{Type: table.Fold}
+	}
+
+	items := []string{drawFold, drawCheck}
+	if v.ToCall > 0 {
+		items[1] = drawCall
+	}
+	if v.CanRaise && v.Stack > v.ToCall {
+		if v.ToCall == 0 {
+			items = append(items, drawBet)
+		} else {
+			items = append(items, drawRaise)
+		}
+	}
+
+	prompt := promptui.Select{
+		Label:  fmt.Sprintf("%s. Cards: %s, Pot: %d, To call: %d, Bet: %d, Stack: %d", v.Round, renderCards(h.renderer, v.Cards), v.Pot, v.ToCall, v.BetSize, v.Stack),
+		Items:  items,
+		Stdout: promptStdout(),
+	}
+	_, choice, err := prompt.Run()
+	if err != nil {
+		h.err = err
+		return table.Action{Type: table.Fold}
+	}
+
+	switch choice {
+	case drawCheck, drawCall:
+		return table.Action{Type: table.Call}
+	case drawBet, drawRaise:
+		return table.Action{Type: table.Raise}
+	default:
+		return table.Action{Type: table.Fold}
+	}
+}
+
+// Discard implements draw.Strategy. The positions of the cards to throw away are entered from 1 to 5,
+// separated by spaces, and an empty input stands pat.
+func (h *humanDraw) Discard(v draw.View) []int {
+	if h.err != nil {
+		return nil
+	}
+
+	prompt := promptui.Prompt{
+		Label: fmt.Sprintf("Cards: %s. Positions to discard (1-5, empty to stand pat)", renderCards(h.renderer, v.Cards)),
+		Validate: func(input string) error {
+			_, err := parseDiscards(input, len(v.Cards))
+			return err
+		},
+		Stdout: promptStdout(),
+	}
+	result, err := prompt.Run()
+	if err != nil {
+		h.err = err
+		return nil
+	}
+
+	discards, _ := parseDiscards(result, len(v.Cards))
+	return discards
+}
+
+// parseDiscards parses positions from 1 to n separated by spaces or commas into card indexes.
+func parseDiscards(s string, n int) ([]int, error) {
+	var discards []int
+	for _, field := range strings.FieldsFunc(s, func(r rune) bool { return r == ' ' || r == ',' }) {
+		pos, err := strconv.Atoi(field)
+		if err != nil || pos < 1 || pos > n {
+			return nil, fmt.Errorf("invalid position: %s. positions should be from 1 to %d", field, n)
+		}
+		discards = append(discards, pos-1)
+	}
+
+	return discards, nil
+}
+
+// logDrawHand logs the cards of every player in a draw hand, and the hands shown at showdown.
+func logDrawHand(result *draw.Result) {
+	renderer := cardRenderer(log.Writer())
+
+	log.Printf("Hand %d. Button: seat %d\n", result.HandNo, result.Button)
+	for _, p := range result.Players {
+		status := ""
+		if p.Folded {
+			status = ", Folded"
+		}
+		log.Printf("Seat %d. Dealt: %s, Discarded: %d, Cards: %s%s, Net: %d\n", p.Seat, renderCards(renderer, p.Dealt), len(p.Discarded), renderCards(renderer, p.Cards), status, p.Net())
+	}
+
+	for _, s := range result.Showdown {
+		winner := ""
+		for _, pot := range result.Pots {
+			if slices.Contains(pot.Winners, s.Seat) {
+				winner = ", Winner"
+				break
+			}
+		}
+		log.Printf("Showdown. Seat %d, Rank: %s, Cards: %s%s\n", s.Seat, s.Rank, renderCards(renderer, s.Hand.Cards), winner)
+	}
+}
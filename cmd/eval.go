@@ -0,0 +1,231 @@
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/YoungsoonLee/poker/poker"
+	"github.com/YoungsoonLee/poker/types"
+	"github.com/spf13/cobra"
+)
+
+// evalFlushInterval is how often eval writes the buffered results.
+const evalFlushInterval = 100 * time.Millisecond
+
+// evalCmd returns a Cobra command for evaluating hands without prompting, so it can be used in pipelines.
+// Every argument, or every line of the file or the standard input, is a hand or a showdown of comma separated hands.
+// Lines are evaluated on a pool of workers and the results are written in the order of the lines, so files of any size stream through.
+// Lines that cannot be parsed are logged and skipped, and the command fails after the last line if any did.
+func evalCmd() *cobra.Command {
+	var file, variantName string
+	var workers int
+	var progress time.Duration
+
+	c := &cobra.Command{
+		Use:   "eval [hands]",
+		Short: "Eval: Evaluate hands from arguments, a file or the standard input, one hand or showdown per line",
+		Long: "Eval: Evaluate hands from arguments, a file or the standard input, one hand or showdown per line.\n" +
+			"A showdown is comma separated hands, ex) 3s4h5d6c7s,9H3CTSQSAD. Blank lines and lines starting with # are skipped.\n" +
+			"Results are written as every line is read, and lines that cannot be evaluated are reported with their line number.",
+		Annotations: outputAnnotations(),
+
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) > 0 && file != "" {
+				return errors.New("give hands as arguments or with --file, not both")
+			}
+
+			variant, err := poker.ParseVariant(variantName)
+			if err != nil {
+				return err
+			}
+
+			in := cmd.InOrStdin()
+			switch {
+			case len(args) > 0:
+				in = strings.NewReader(strings.Join(args, "\n"))
+			case file != "" && file != "-":
+				f, err := os.Open(file)
+				if err != nil {
+					return err
+				}
+				defer f.Close()
+				in = f
+			}
+
+			ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+			defer stop()
+
+			lines, failed, err := evalLines(ctx, in, newEvalWriter(cmd.OutOrStdout()), variant, workers, progress)
+			log.Printf("Evaluated %d lines, %d failed\n", lines, failed)
+			if err != nil {
+				return err
+			}
+
+			if failed > 0 {
+				cmd.SilenceUsage = true
+				return fmt.Errorf("%d of %d lines failed", failed, lines)
+			}
+
+			return nil
+		},
+	}
+
+	c.Flags().StringVarP(&file, "file", "f", "", "File with a hand or showdown per line, or - for the standard input")
+	c.Flags().StringVar(&variantName, "variant", "standard", "Rules of the hands: standard, short-deck, short-deck-trips, where trips also beat a straight, jokers, bug or deuces-wild")
+	c.Flags().IntVar(&workers, "workers", 0, "Number of parallel workers (default number of CPUs)")
+	c.Flags().DurationVar(&progress, "progress", 0, "Log the progress at this interval, ex) 5s")
+	return c
+}
+
+// evalLines evaluates every line of in on a pool of workers by the rules of the variant, and writes the results in the order of the lines as they are evaluated.
+// With a progress interval the number of lines evaluated so far is logged at every interval.
+// It returns the number of lines with hands and the number of them that failed.
+// An error is only returned if in cannot be read, the results cannot be written or ctx is done.
+func evalLines(ctx context.Context, in io.Reader, w *evalWriter, variant poker.Variant, workers int, progress time.Duration) (int, int, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var lines, failed atomic.Int64
+	jobs := make(chan poker.StreamJob)
+	readErr := make(chan error, 1)
+	go func() {
+		defer close(jobs)
+
+		scanner := bufio.NewScanner(in)
+		for n := 1; scanner.Scan(); n++ {
+			line := strings.TrimSpace(scanner.Text())
+			if line == "" || strings.HasPrefix(line, "#") {
+				continue
+			}
+			lines.Add(1)
+
+			hands, err := variant.ParseHands(line)
+			if err != nil {
+				log.Printf("line %d: %v\n", n, err)
+				failed.Add(1)
+				continue
+			}
+
+			select {
+			case jobs <- poker.StreamJob{ID: n, Hands: hands, Variant: variant}:
+			case <-ctx.Done():
+				return
+			}
+		}
+		readErr <- scanner.Err()
+	}()
+
+	var tick <-chan time.Time
+	if progress > 0 {
+		ticker := time.NewTicker(progress)
+		defer ticker.Stop()
+		tick = ticker.C
+	}
+
+	// the results are buffered and flushed at a short interval, so they stream through pipelines without a write per line
+	flush := time.NewTicker(evalFlushInterval)
+	defer flush.Stop()
+
+	start, evaluated := time.Now(), 0
+	results := poker.EvaluateStream(ctx, jobs, workers)
+	for results != nil {
+		select {
+		case r, ok := <-results:
+			if !ok {
+				results = nil
+				continue
+			}
+			if err := w.write(r.ID, r.Results); err != nil {
+				return int(lines.Load()), int(failed.Load()), err
+			}
+			evaluated++
+		case <-flush.C:
+			if err := w.flush(); err != nil {
+				return int(lines.Load()), int(failed.Load()), err
+			}
+		case <-tick:
+			log.Printf("Progress: %d lines evaluated, %d failed, %.0f lines/s\n", evaluated, failed.Load(), float64(evaluated)/time.Since(start).Seconds())
+		}
+	}
+
+	if err := w.flush(); err != nil {
+		return int(lines.Load()), int(failed.Load()), err
+	}
+	if err := ctx.Err(); err != nil {
+		return int(lines.Load()), int(failed.Load()), err
+	}
+
+	return int(lines.Load()), int(failed.Load()), <-readErr
+}
+
+// evalWriter writes the results of every line in the format of the --output flag.
+// json is a document per line, and csv a single table with the line number in the first column.
+type evalWriter struct {
+	render types.Renderer
+	out    *bufio.Writer
+	csv    *csv.Writer
+	header bool
+}
+
+// newEvalWriter returns an evalWriter that buffers the results until they are flushed.
+func newEvalWriter(out io.Writer) *evalWriter {
+	b := bufio.NewWriter(out)
+	return &evalWriter{render: cardRenderer(out), out: b, csv: csv.NewWriter(b)}
+}
+
+// flush writes the buffered results.
+func (w *evalWriter) flush() error {
+	w.csv.Flush()
+	if err := w.csv.Error(); err != nil {
+		return err
+	}
+
+	return w.out.Flush()
+}
+
+// write writes the results of the hands on the given line.
+func (w *evalWriter) write(line int, results []poker.HandResult) error {
+	out := newResultsOutput(results)
+	out.Line = line
+
+	switch outputFormat {
+	case outputJSON:
+		return json.NewEncoder(w.out).Encode(out)
+	case outputCSV:
+		if !w.header {
+			if err := w.csv.Write(append([]string{"line"}, csvHeader...)); err != nil {
+				return err
+			}
+			w.header = true
+		}
+		for _, h := range out.Hands {
+			if err := w.csv.Write(append([]string{strconv.Itoa(line)}, h.csvRecord()...)); err != nil {
+				return err
+			}
+		}
+		return nil
+	default:
+		for i, h := range out.Hands {
+			winner := ""
+			if h.Winner && len(out.Hands) > 1 {
+				winner = ", Winner"
+			}
+			if _, err := fmt.Fprintf(w.out, "Line %d. ID:%d, Rank: %s, RankOrder: %d, Cards: %s%s\n", line, h.HandID, h.Rank, h.RankOrder, renderCards(w.render, results[i].Card), winner); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
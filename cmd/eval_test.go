@@ -0,0 +1,219 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/YoungsoonLee/poker/poker"
+)
+
+// withOutput sets the --output and --render flags for a test, and restores them when it ends.
+func withOutput(t *testing.T, format string) {
+	t.Helper()
+
+	oldFormat, oldStyle := outputFormat, renderStyle
+	outputFormat, renderStyle = format, renderAuto
+	t.Cleanup(func() { outputFormat, renderStyle = oldFormat, oldStyle })
+}
+
+// captureLog returns a buffer with the output of the log package during a test.
+func captureLog(t *testing.T) *bytes.Buffer {
+	t.Helper()
+
+	var buf bytes.Buffer
+	old := log.Writer()
+	log.SetOutput(&buf)
+	t.Cleanup(func() { log.SetOutput(old) })
+
+	return &buf
+}
+
+// randomLines returns n lines of showdowns between two random hands.
+func randomLines(n int) string {
+	rng := rand.New(rand.NewSource(1))
+
+	var b strings.Builder
+	for i := 0; i < n; i++ {
+		deck := poker.NewDeck(rng)
+		deck.Shuffle()
+		cards, _ := deck.Deal(10)
+		for k, c := range cards {
+			if k == 5 {
+				b.WriteString(",")
+			}
+			b.WriteString(c.String())
+		}
+		b.WriteString("\n")
+	}
+
+	return b.String()
+}
+
+// jsonLines returns the line numbers of the json documents written by eval.
+func jsonLines(t *testing.T, out string) []int {
+	t.Helper()
+
+	var lines []int
+	dec := json.NewDecoder(strings.NewReader(out))
+	for dec.More() {
+		var r resultsOutput
+		if err := dec.Decode(&r); err != nil {
+			t.Fatalf("Decode() error = %v", err)
+		}
+		lines = append(lines, r.Line)
+	}
+
+	return lines
+}
+
+func TestEvalLines_Failed(t *testing.T) {
+	withOutput(t, outputText)
+	logs := captureLog(t)
+
+	in := "# a comment\n" +
+		"ASKSQSJSTS\n" +
+		"not a hand\n" +
+		"2H3H4H5H6H,ASADAHACKD\n" +
+		"\n" +
+		"ASKSQSJSTS,ASKDQHJCTH,2C\n"
+
+	var out bytes.Buffer
+	lines, failed, err := evalLines(context.Background(), strings.NewReader(in), newEvalWriter(&out), poker.Standard, 4, 0)
+	if err != nil {
+		t.Fatalf("evalLines() error = %v", err)
+	}
+	if lines != 4 || failed != 2 {
+		t.Errorf("evalLines() = %d lines, %d failed, want 4 lines, 2 failed", lines, failed)
+	}
+
+	for _, want := range []string{"line 3: ", "line 6: "} {
+		if !strings.Contains(logs.String(), want) {
+			t.Errorf("log = %q, want an error for %q", logs.String(), want)
+		}
+	}
+
+	want := "Line 2. ID:1, Rank: Royal Flush, RankOrder: 1, Cards: AS KS QS JS TS\n" +
+		"Line 4. ID:1, Rank: Straight Flush, RankOrder: 2, Cards: 2H 3H 4H 5H 6H, Winner\n" +
+		"Line 4. ID:2, Rank: Four of a Kind, RankOrder: 3, Cards: AS AD AH AC KD\n"
+	if out.String() != want {
+		t.Errorf("output = %q, want %q", out.String(), want)
+	}
+}
+
+func TestEvalLines_Order(t *testing.T) {
+	withOutput(t, outputJSON)
+
+	const n = 500
+	var out bytes.Buffer
+	lines, failed, err := evalLines(context.Background(), strings.NewReader(randomLines(n)), newEvalWriter(&out), poker.Standard, 8, 0)
+	if err != nil {
+		t.Fatalf("evalLines() error = %v", err)
+	}
+	if lines != n || failed != 0 {
+		t.Fatalf("evalLines() = %d lines, %d failed, want %d lines, 0 failed", lines, failed, n)
+	}
+
+	got := jsonLines(t, out.String())
+	if len(got) != n {
+		t.Fatalf("output has %d documents, want %d", len(got), n)
+	}
+	for i, line := range got {
+		if line != i+1 {
+			t.Fatalf("document %d is line %d, want line %d", i, line, i+1)
+		}
+	}
+}
+
+func TestEvalLines_CSVHeader(t *testing.T) {
+	withOutput(t, outputCSV)
+
+	var out bytes.Buffer
+	w := newEvalWriter(&out)
+	if _, _, err := evalLines(context.Background(), strings.NewReader(randomLines(20)), w, poker.Standard, 4, 0); err != nil {
+		t.Fatalf("evalLines() error = %v", err)
+	}
+
+	records, err := csv.NewReader(&out).ReadAll()
+	if err != nil {
+		t.Fatalf("ReadAll() error = %v", err)
+	}
+	if len(records) != 1+2*20 {
+		t.Fatalf("csv has %d records, want a header and 40 hands", len(records))
+	}
+
+	header := strings.Join(append([]string{"line"}, csvHeader...), ",")
+	for i, r := range records {
+		if (strings.Join(r, ",") == header) != (i == 0) {
+			t.Errorf("record %d = %v, want the header only as the first record", i, r)
+		}
+		if i > 0 && r[0] != fmt.Sprint((i+1)/2) {
+			t.Errorf("record %d is line %s, want line %d", i, r[0], (i+1)/2)
+		}
+	}
+}
+
+func TestEvalCmd_Workers(t *testing.T) {
+	withOutput(t, outputJSON)
+	captureLog(t)
+
+	const n = 300
+	file := filepath.Join(t.TempDir(), "hands.txt")
+	if err := os.WriteFile(file, []byte(randomLines(n)), 0o644); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+
+	var out bytes.Buffer
+	c := evalCmd()
+	c.SetOut(&out)
+	c.SetArgs([]string{"--file", file, "--workers", "6", "--progress", "1ms"})
+	if err := c.Execute(); err != nil {
+		t.Fatalf("Execute() error = %v", err)
+	}
+
+	got := jsonLines(t, out.String())
+	if len(got) != n {
+		t.Fatalf("output has %d documents, want %d", len(got), n)
+	}
+	for i, line := range got {
+		if line != i+1 {
+			t.Fatalf("document %d is line %d, want line %d", i, line, i+1)
+		}
+	}
+}
+
+func TestEvalLines_Canceled(t *testing.T) {
+	withOutput(t, outputText)
+	captureLog(t)
+
+	before := runtime.NumGoroutine()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	var out bytes.Buffer
+	_, _, err := evalLines(ctx, strings.NewReader(randomLines(2000)), newEvalWriter(&out), poker.Standard, 4, 0)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("evalLines() error = %v, want %v", err, context.Canceled)
+	}
+
+	// the reader and the workers stop instead of staying blocked on the jobs
+	deadline := time.Now().Add(time.Second)
+	for runtime.NumGoroutine() > before && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if n := runtime.NumGoroutine(); n > before {
+		t.Errorf("%d goroutines are left after evalLines, want %d", n, before)
+	}
+}
@@ -0,0 +1,82 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/YoungsoonLee/poker/live"
+	"github.com/spf13/cobra"
+)
+
+// hostCmd returns a Cobra command for hosting real-time games over WebSocket.
+// The server stops gracefully on an interrupt.
+func hostCmd() *cobra.Command {
+	var addr string
+	cfg := live.Config{Room: live.DefaultRoomConfig}
+
+	c := &cobra.Command{
+		Use:   "host",
+		Short: "Host: Host real-time Texas Hold'em games over WebSocket",
+		Long: "Host: Host real-time Texas Hold'em games over WebSocket.\n" +
+			"Clients connect to ws://<addr>/ws and join rooms by name with JSON messages, see the live package.",
+
+		RunE: func(cmd *cobra.Command, args []string) error {
+			lobby, err := live.NewLobby(cfg)
+			if err != nil {
+				return err
+			}
+			defer lobby.Close()
+
+			mux := http.NewServeMux()
+			mux.Handle("/ws", lobby)
+			srv := &http.Server{
+				Addr:              addr,
+				Handler:           mux,
+				ReadHeaderTimeout: 10 * time.Second,
+			}
+
+			ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+			defer stop()
+
+			errs := make(chan error, 1)
+			go func() {
+				log.Printf("Hosting games on ws://%s/ws\n", addr)
+				errs <- srv.ListenAndServe()
+			}()
+
+			select {
+			case err := <-errs:
+				return err
+			case <-ctx.Done():
+			}
+
+			log.Printf("Shutting down\n")
+			shutdown, cancel := context.WithTimeout(context.Background(), serveShutdownTimeout)
+			defer cancel()
+			if err := srv.Shutdown(shutdown); err != nil {
+				return err
+			}
+			if err := <-errs; err != nil && !errors.Is(err, http.ErrServerClosed) {
+				return err
+			}
+
+			return nil
+		},
+	}
+
+	c.Flags().StringVar(&addr, "addr", "localhost:8081", "Address to listen on")
+	c.Flags().IntVar(&cfg.Room.Seats, "seats", cfg.Room.Seats, "Seats of every room")
+	c.Flags().IntVar(&cfg.Room.SmallBlind, "sb", cfg.Room.SmallBlind, "Small blind")
+	c.Flags().IntVar(&cfg.Room.BigBlind, "bb", cfg.Room.BigBlind, "Big blind")
+	c.Flags().IntVar(&cfg.Room.Stack, "stack", cfg.Room.Stack, "Chips every player sits down with")
+	c.Flags().DurationVar(&cfg.Room.ActionTimeout, "action-timeout", cfg.Room.ActionTimeout, "Time a player has to act before folding")
+	c.Flags().DurationVar(&cfg.Room.HandDelay, "hand-delay", cfg.Room.HandDelay, "Pause between hands")
+	c.Flags().DurationVar(&cfg.Room.ReconnectTimeout, "reconnect-timeout", cfg.Room.ReconnectTimeout, "Time a disconnected player keeps their seat")
+	c.Flags().StringSliceVar(&cfg.AllowOrigins, "origin", nil, "Origins browsers may connect from, ex) http://localhost:3000, or * for any origin")
+	return c
+}
@@ -0,0 +1,184 @@
+package cmd
+
+import (
+	"errors"
+	"log"
+	"math/rand"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/YoungsoonLee/poker/icm"
+	"github.com/YoungsoonLee/poker/poker"
+	"github.com/YoungsoonLee/poker/types"
+	"github.com/spf13/cobra"
+)
+
+// icmCmd returns a Cobra command for calculating the Independent Chip Model equity of every player.
+// With --hole and --call-range it also decides whether the hero should push all-in or fold.
+func icmCmd() *cobra.Command {
+	var stacks, posted []int
+	var payouts []float64
+	var hero, caller, ante, iterations int
+	var hole, callRange string
+	var seed int64
+
+	c := &cobra.Command{
+		Use:         "icm",
+		Short:       "ICM: Calculate the tournament equity of stacks, and push or fold decisions",
+		Annotations: outputAnnotations(),
+
+		RunE: func(cmd *cobra.Command, args []string) error {
+			equities, err := icm.Equity(stacks, payouts)
+			if err != nil {
+				return err
+			}
+
+			total := 0
+			for _, s := range stacks {
+				total += s
+			}
+
+			out := icmOutput{Players: []icmPlayerOutput{}}
+			for i, equity := range equities {
+				out.Players = append(out.Players, icmPlayerOutput{Player: i + 1, Stack: stacks[i], Chips: float64(stacks[i]) / float64(total), Equity: equity})
+				if outputFormat == outputText {
+					log.Printf("Player %d, Stack: %d, Chips: %.2f%%, ICM Equity: %.2f\n", i+1, stacks[i], float64(stacks[i])*100/float64(total), equity)
+				}
+			}
+
+			if hole == "" {
+				return writeICMOutput(cmd, out)
+			}
+
+			cards, err := types.ParseCards(hole)
+			if err != nil {
+				return err
+			}
+
+			if callRange == "" {
+				return errors.New("--call-range is required with --hole")
+			}
+			r, err := poker.ParseRange(callRange)
+			if err != nil {
+				return err
+			}
+
+			if seed == 0 {
+				seed = time.Now().UnixNano()
+			}
+
+			d, err := icm.PushFold(icm.Spot{
+				Stacks:     stacks,
+				Payouts:    payouts,
+				Hero:       hero - 1,
+				Caller:     caller - 1,
+				Posted:     posted,
+				Ante:       ante,
+				Hole:       cards,
+				CallRange:  r,
+				Iterations: iterations,
+			}, rand.New(rand.NewSource(seed)))
+			if err != nil {
+				return err
+			}
+
+			decision := "Fold"
+			if d.Push {
+				decision = "Push"
+			}
+
+			out.Decision = &icmDecisionOutput{
+				Hero:             hero,
+				Hole:             hole,
+				Decision:         strings.ToLower(decision),
+				CallProbability:  d.CallProbability,
+				EquityWhenCalled: d.EquityWhenCalled,
+				PushEV:           d.PushEV,
+				FoldEV:           d.FoldEV,
+				PushChipEV:       d.PushChipEV,
+				FoldChipEV:       d.FoldChipEV,
+			}
+			if outputFormat != outputText {
+				return writeICMOutput(cmd, out)
+			}
+
+			log.Printf("Call Probability: %.2f%%, Equity When Called: %.2f%%\n", d.CallProbability*100, d.EquityWhenCalled*100)
+			log.Printf("Push EV: %.4f, Fold EV: %.4f, Push Chip EV: %.1f, Fold Chip EV: %.1f\n", d.PushEV, d.FoldEV, d.PushChipEV, d.FoldChipEV)
+			log.Printf("Decision: %s %s\n", decision, hole)
+
+			return nil
+		},
+	}
+
+	c.Flags().IntSliceVar(&stacks, "stacks", nil, "Stacks of the players, ex) 5000,3000,2000")
+	c.Flags().Float64SliceVar(&payouts, "payouts", nil, "Payouts starting with the winner, ex) 50,30,20")
+	c.Flags().StringVar(&hole, "hole", "", "Hole cards of the hero for a push or fold decision, ex) AsKd")
+	c.Flags().StringVar(&callRange, "call-range", "", "Range the caller calls an all-in with, ex) 22+,A2s+,KTo+")
+	c.Flags().IntVar(&hero, "hero", 1, "Player number of the hero")
+	c.Flags().IntVar(&caller, "caller", 2, "Player number of the caller")
+	c.Flags().IntSliceVar(&posted, "posted", nil, "Blinds posted by each player, ex) 100,200,0")
+	c.Flags().IntVar(&ante, "ante", 0, "Ante posted by every player")
+	c.Flags().IntVar(&iterations, "iterations", 20000, "Number of run-outs for the equity when called")
+	c.Flags().Int64Var(&seed, "seed", 0, "Random seed for reproducible runs (default current time)")
+	_ = c.MarkFlagRequired("stacks")
+	_ = c.MarkFlagRequired("payouts")
+	return c
+}
+
+// icmOutput is the ICM equity of every player in the json and csv output formats, with the push or fold decision
+// of the hero when --hole is given. Chips is the share of the chips in play of a player.
+// The csv has one record per player, and the decision columns are only filled in on the record of the hero.
+type icmOutput struct {
+	Players  []icmPlayerOutput  `json:"players"`
+	Decision *icmDecisionOutput `json:"decision,omitempty"`
+}
+
+// icmPlayerOutput is the stack and the ICM equity of a player.
+type icmPlayerOutput struct {
+	Player int     `json:"player"`
+	Stack  int     `json:"stack"`
+	Chips  float64 `json:"chips"`
+	Equity float64 `json:"equity"`
+}
+
+// icmDecisionOutput is the push or fold decision of the hero, "push" or "fold", with the values of icm.Decision.
+type icmDecisionOutput struct {
+	Hero             int     `json:"hero"`
+	Hole             string  `json:"hole"`
+	Decision         string  `json:"decision"`
+	CallProbability  float64 `json:"call_probability"`
+	EquityWhenCalled float64 `json:"equity_when_called"`
+	PushEV           float64 `json:"push_ev"`
+	FoldEV           float64 `json:"fold_ev"`
+	PushChipEV       float64 `json:"push_chip_ev"`
+	FoldChipEV       float64 `json:"fold_chip_ev"`
+}
+
+// writeICMOutput writes the output of the icm command in the json and csv formats. It does nothing for text,
+// which is logged as it is calculated.
+func writeICMOutput(cmd *cobra.Command, out icmOutput) error {
+	if outputFormat == outputText {
+		return nil
+	}
+
+	return writeReport(cmd.OutOrStdout(), out)
+}
+
+// csvHeader implements report.
+func (o icmOutput) csvHeader() []string {
+	return []string{"player", "stack", "chips", "equity", "decision", "push_ev", "fold_ev"}
+}
+
+// csvRecords implements report with one record per player.
+func (o icmOutput) csvRecords() [][]string {
+	records := make([][]string, len(o.Players))
+	for i, p := range o.Players {
+		records[i] = []string{strconv.Itoa(p.Player), strconv.Itoa(p.Stack), formatFloat(p.Chips), formatFloat(p.Equity), "", "", ""}
+		if d := o.Decision; d != nil && d.Hero == p.Player {
+			records[i][4], records[i][5], records[i][6] = d.Decision, formatFloat(d.PushEV), formatFloat(d.FoldEV)
+		}
+	}
+
+	return records
+}
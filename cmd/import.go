@@ -0,0 +1,119 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strconv"
+
+	"github.com/YoungsoonLee/poker/history"
+	"github.com/spf13/cobra"
+)
+
+// importCmd returns a Cobra command for converting PokerStars text hand histories into line-delimited JSON hand histories.
+// Hands that cannot be parsed are skipped and logged with their line number, and so are the inconsistencies found in the imported hands.
+func importCmd() *cobra.Command {
+	var outPath string
+
+	c := &cobra.Command{
+		Use:   "import [files]",
+		Short: "Import: Convert PokerStars text hand histories into JSON hand histories",
+		Long: "Import: Convert PokerStars text hand histories into JSON hand histories.\n" +
+			"Reads the standard input when no file is given, and writes to the standard output unless --out is set.\n" +
+			"With --output json or csv the counts of the import are written to the standard output, so the hands need --out.",
+		Annotations: outputAnnotations(),
+
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if outputFormat != outputText && outPath == "" {
+				return fmt.Errorf("--output %s writes the import counts to the standard output, give --out for the hands", outputFormat)
+			}
+
+			out := io.Writer(os.Stdout)
+			if outPath != "" {
+				f, err := os.Create(outPath)
+				if err != nil {
+					return err
+				}
+				defer f.Close()
+				out = f
+			}
+			w := history.NewWriter(out)
+
+			if len(args) == 0 {
+				args = []string{"-"}
+			}
+
+			var hands, failed, warnings int
+			for _, path := range args {
+				in := io.Reader(os.Stdin)
+				if path != "-" {
+					f, err := os.Open(path)
+					if err != nil {
+						return err
+					}
+					defer f.Close()
+					in = f
+				}
+
+				r := history.NewPokerStarsReader(in)
+				for {
+					h, err := r.Read()
+					if err == io.EOF {
+						break
+					}
+
+					var parseErr *history.ParseError
+					if errors.As(err, &parseErr) {
+						failed++
+						log.Printf("%s: %v\n", path, err)
+						continue
+					}
+					if err != nil {
+						return err
+					}
+
+					for _, warning := range h.Warnings {
+						warnings++
+						log.Printf("%s: hand #%s: %s\n", path, h.ID, warning)
+					}
+
+					if err := w.Write(h); err != nil {
+						return err
+					}
+					hands++
+				}
+			}
+
+			if outputFormat != outputText {
+				return writeReport(cmd.OutOrStdout(), importOutput{Hands: hands, Failed: failed, Warnings: warnings})
+			}
+
+			log.Printf("Imported %d hands, %d hands could not be parsed, %d warnings\n", hands, failed, warnings)
+
+			return nil
+		},
+	}
+
+	c.Flags().StringVar(&outPath, "out", "", "Write the hands as line-delimited JSON to this file (default standard output)")
+	return c
+}
+
+// importOutput is the counts of an import in the json and csv output formats: the hands imported,
+// the hands that could not be parsed and the warnings about the imported hands.
+type importOutput struct {
+	Hands    int `json:"hands"`
+	Failed   int `json:"failed"`
+	Warnings int `json:"warnings"`
+}
+
+// csvHeader implements report.
+func (o importOutput) csvHeader() []string {
+	return []string{"hands", "failed", "warnings"}
+}
+
+// csvRecords implements report with a single record.
+func (o importOutput) csvRecords() [][]string {
+	return [][]string{{strconv.Itoa(o.Hands), strconv.Itoa(o.Failed), strconv.Itoa(o.Warnings)}}
+}
@@ -0,0 +1,197 @@
+package cmd
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/YoungsoonLee/poker/poker"
+	"github.com/spf13/cobra"
+)
+
+// Output formats of the --output flag.
+const (
+	outputText = "text"
+	outputJSON = "json"
+	outputCSV  = "csv"
+)
+
+// outputAnnotation marks the commands that support the json and csv output formats.
+// Every command reporting results has it; the servers serve, host and arena, the interactive replay and render,
+// which writes images, only write text.
+const outputAnnotation = "output"
+
+// outputFormat is the value of the global --output flag.
+var outputFormat string
+
+// outputAnnotations returns the annotations of a command that supports every output format.
+func outputAnnotations() map[string]string {
+	return map[string]string{outputAnnotation: "true"}
+}
+
+// validateOutput checks the --output flag, and that the command supports the json and csv formats.
+func validateOutput(cmd *cobra.Command, args []string) error {
+	switch outputFormat {
+	case outputText:
+		return nil
+	case outputJSON, outputCSV:
+		if cmd.Annotations[outputAnnotation] == "" {
+			return fmt.Errorf("--output %s is not supported by %s, which only writes text", outputFormat, cmd.Name())
+		}
+		return nil
+	default:
+		return fmt.Errorf("invalid --output: %s. output should be text, json or csv", outputFormat)
+	}
+}
+
+// handOutput is a hand result in the json and csv output formats.
+type handOutput struct {
+	HandID    int      `json:"hand_id"`
+	Cards     []string `json:"cards"`
+	Rank      string   `json:"rank"`
+	RankOrder int      `json:"rank_order"`
+	Score     int      `json:"score"`
+	Winner    bool     `json:"winner"`
+}
+
+// resultsOutput is the json document of evaluated hands.
+// Hands are ordered from the strongest, and Winners lists the ids of the hands that tie for the best score.
+// Line is the input line of the hands when they are read by eval.
+type resultsOutput struct {
+	Line    int          `json:"line,omitempty"`
+	Hands   []handOutput `json:"hands"`
+	Winners []int        `json:"winners"`
+}
+
+// newResultsOutput converts the results of EvaluateHands to their json and csv form.
+func newResultsOutput(results []poker.HandResult) resultsOutput {
+	out := resultsOutput{Hands: []handOutput{}, Winners: []int{}}
+	for _, r := range results {
+		h := handOutput{HandID: r.HandID, Rank: r.Rank, RankOrder: r.RankOrder, Score: r.Score, Winner: r.Score == results[0].Score}
+		for _, c := range r.Card {
+			h.Cards = append(h.Cards, c.String())
+		}
+		if h.Winner {
+			out.Winners = append(out.Winners, r.HandID)
+		}
+		out.Hands = append(out.Hands, h)
+	}
+
+	return out
+}
+
+// csvHeader is the header of the csv output format of hand results.
+var csvHeader = []string{"hand_id", "cards", "rank", "rank_order", "score", "winner"}
+
+// csvRecord returns the csv record of a hand, with the cards concatenated like "KDQDKSKC3H".
+func (h handOutput) csvRecord() []string {
+	return []string{strconv.Itoa(h.HandID), strings.Join(h.Cards, ""), h.Rank, strconv.Itoa(h.RankOrder), strconv.Itoa(h.Score), strconv.FormatBool(h.Winner)}
+}
+
+// csvHeader implements report.
+func (o resultsOutput) csvHeader() []string {
+	return csvHeader
+}
+
+// csvRecords implements report with one record per hand.
+func (o resultsOutput) csvRecords() [][]string {
+	records := make([][]string, len(o.Hands))
+	for i, h := range o.Hands {
+		records[i] = h.csvRecord()
+	}
+
+	return records
+}
+
+// report is the json and csv form of the results of a command.
+// The value itself is the json document, and the csv has a header and one record per row, like one per player.
+type report interface {
+	csvHeader() []string
+	csvRecords() [][]string
+}
+
+// writeReport writes the report to w as json or csv, depending on the --output flag.
+func writeReport(w io.Writer, r report) error {
+	switch outputFormat {
+	case outputJSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(r)
+	case outputCSV:
+		cw := csv.NewWriter(w)
+		if err := cw.Write(r.csvHeader()); err != nil {
+			return err
+		}
+		if err := cw.WriteAll(r.csvRecords()); err != nil {
+			return err
+		}
+		return cw.Error()
+	default:
+		return fmt.Errorf("invalid --output: %s", outputFormat)
+	}
+}
+
+// writeResults writes the results of EvaluateHands to w as json or csv, depending on the --output flag.
+func writeResults(w io.Writer, results []poker.HandResult) error {
+	return writeReport(w, newResultsOutput(results))
+}
+
+// formatFloat formats a number of a csv record without rounding it.
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
+
+// playOutput is the result of the hands played by the stud and draw commands in the json and csv output formats,
+// with one csv record per seat. BB100 is the net of a seat in big bets per 100 hands.
+type playOutput struct {
+	Game  string           `json:"game"`
+	Hands int              `json:"hands"`
+	Seats []playSeatOutput `json:"seats"`
+}
+
+// playSeatOutput is the stack of a seat after the last hand and its net chips over all the hands.
+type playSeatOutput struct {
+	Seat  int     `json:"seat"`
+	Name  string  `json:"name"`
+	Stack int     `json:"stack"`
+	Net   int     `json:"net"`
+	BB100 float64 `json:"bb_100"`
+}
+
+// csvHeader implements report.
+func (o playOutput) csvHeader() []string {
+	return []string{"game", "seat", "name", "stack", "net", "bb_100"}
+}
+
+// csvRecords implements report with one record per seat.
+func (o playOutput) csvRecords() [][]string {
+	records := make([][]string, len(o.Seats))
+	for i, s := range o.Seats {
+		records[i] = []string{o.Game, strconv.Itoa(s.Seat), s.Name, strconv.Itoa(s.Stack), strconv.Itoa(s.Net), formatFloat(s.BB100)}
+	}
+
+	return records
+}
+
+// promptStdout returns the writer of the prompts: the standard error with the json and csv formats,
+// which keep the standard output for the results, and nil for the standard output of promptui otherwise.
+func promptStdout() io.WriteCloser {
+	if outputFormat != outputText {
+		return os.Stderr
+	}
+
+	return nil
+}
+
+// writeOutput writes the results to the standard output of the command, and exits if they cannot be written.
+func writeOutput(cmd *cobra.Command, results []poker.HandResult) {
+	if err := writeResults(cmd.OutOrStdout(), results); err != nil {
+		log.Printf("Output failed %v\n", err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,208 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/YoungsoonLee/poker/poker"
+	"github.com/YoungsoonLee/poker/types"
+	"github.com/spf13/cobra"
+)
+
+// evaluate returns the results of EvaluateHands for hands like "KDQDKSKC3H", numbered from 1.
+func evaluate(t *testing.T, hands ...string) []poker.HandResult {
+	t.Helper()
+
+	var hs poker.Hands
+	for i, h := range hands {
+		cards, err := types.ParseCards(h)
+		if err != nil {
+			t.Fatalf("ParseCards() error = %v", err)
+		}
+		hs = append(hs, poker.Hand{HandID: i + 1, Cards: cards})
+	}
+
+	return poker.EvaluateHands(hs)
+}
+
+func TestValidateOutput(t *testing.T) {
+	tests := []struct {
+		name      string
+		format    string
+		annotated bool
+		wantErr   bool
+	}{
+		{name: "text", format: outputText},
+		{name: "text of an annotated command", format: outputText, annotated: true},
+		{name: "json", format: outputJSON, annotated: true},
+		{name: "csv", format: outputCSV, annotated: true},
+		{name: "json of a text only command", format: outputJSON, wantErr: true},
+		{name: "csv of a text only command", format: outputCSV, wantErr: true},
+		{name: "invalid format", format: "xml", annotated: true, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			withOutput(t, tt.format)
+			cmd := &cobra.Command{Use: "test"}
+			if tt.annotated {
+				cmd.Annotations = outputAnnotations()
+			}
+
+			if err := validateOutput(cmd, nil); (err != nil) != tt.wantErr {
+				t.Errorf("validateOutput() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestWriteResults_JSON(t *testing.T) {
+	withOutput(t, outputJSON)
+
+	var buf bytes.Buffer
+	if err := writeResults(&buf, evaluate(t, "2S2H5C7D9S", "KDQDKSKC3H", "2C2D5H7S9C")); err != nil {
+		t.Fatalf("writeResults() error = %v", err)
+	}
+
+	var got resultsOutput
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if len(got.Hands) != 3 {
+		t.Fatalf("writeResults() = %d hands, want 3", len(got.Hands))
+	}
+	if h := got.Hands[0]; h.HandID != 2 || !h.Winner || len(h.Cards) != 5 {
+		t.Errorf("first hand = %+v, want the winning hand 2 with 5 cards", h)
+	}
+	if got.Hands[1].Winner || got.Hands[2].Winner {
+		t.Errorf("hands = %+v, want only the first hand to win", got.Hands)
+	}
+	if !reflect.DeepEqual(got.Winners, []int{2}) {
+		t.Errorf("winners = %v, want [2]", got.Winners)
+	}
+}
+
+func TestWriteResults_CSV(t *testing.T) {
+	withOutput(t, outputCSV)
+
+	results := evaluate(t, "2S2H5C7D9S", "2C2D5H7S9C", "KDQDKSKC3H")
+	var buf bytes.Buffer
+	if err := writeResults(&buf, results); err != nil {
+		t.Fatalf("writeResults() error = %v", err)
+	}
+
+	records, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("ReadAll() error = %v", err)
+	}
+	if len(records) != 4 {
+		t.Fatalf("csv has %d records, want a header and 3 hands", len(records))
+	}
+	if !reflect.DeepEqual(records[0], csvHeader) {
+		t.Errorf("header = %v, want %v", records[0], csvHeader)
+	}
+	if r := records[1]; r[0] != "3" || r[1] != "KDQDKSKC3H" || r[5] != "true" {
+		t.Errorf("first record = %v, want the winning hand 3 with its cards concatenated", r)
+	}
+	// the two pairs of deuces tie, and neither wins against the kings
+	if records[2][4] != records[3][4] || records[2][5] != "false" || records[3][5] != "false" {
+		t.Errorf("records = %v, want two tied losing hands", records[2:])
+	}
+}
+
+func TestICMCmd_Output(t *testing.T) {
+	tests := []struct {
+		name   string
+		format string
+		args   []string
+		check  func(t *testing.T, out []byte)
+	}{
+		{
+			name:   "csv without a decision",
+			format: outputCSV,
+			args:   []string{"--stacks", "5000,3000,2000", "--payouts", "50,30,20"},
+			check: func(t *testing.T, out []byte) {
+				records, err := csv.NewReader(bytes.NewReader(out)).ReadAll()
+				if err != nil {
+					t.Fatalf("ReadAll() error = %v", err)
+				}
+				if len(records) != 4 {
+					t.Fatalf("csv has %d records, want a header and 3 players", len(records))
+				}
+				for _, r := range records[1:] {
+					if r[4] != "" || r[5] != "" || r[6] != "" {
+						t.Errorf("record = %v, want empty decision columns", r)
+					}
+				}
+			},
+		},
+		{
+			name:   "csv with the decision of the hero",
+			format: outputCSV,
+			args: []string{"--stacks", "5000,3000,2000", "--payouts", "50,30,20", "--hole", "AsKd",
+				"--call-range", "22+", "--hero", "2", "--caller", "3", "--iterations", "200", "--seed", "1"},
+			check: func(t *testing.T, out []byte) {
+				records, err := csv.NewReader(bytes.NewReader(out)).ReadAll()
+				if err != nil {
+					t.Fatalf("ReadAll() error = %v", err)
+				}
+				if len(records) != 4 {
+					t.Fatalf("csv has %d records, want a header and 3 players", len(records))
+				}
+				for i, r := range records[1:] {
+					if hero := i == 1; (r[4] != "") != hero || (r[5] != "") != hero {
+						t.Errorf("record of player %d = %v, want the decision only for the hero", i+1, r)
+					}
+				}
+			},
+		},
+		{
+			name:   "json with the decision of the hero",
+			format: outputJSON,
+			args: []string{"--stacks", "5000,3000,2000", "--payouts", "50,30,20", "--hole", "AsKd",
+				"--call-range", "22+", "--iterations", "200", "--seed", "1"},
+			check: func(t *testing.T, out []byte) {
+				var got icmOutput
+				if err := json.Unmarshal(out, &got); err != nil {
+					t.Fatalf("Unmarshal() error = %v", err)
+				}
+				if len(got.Players) != 3 || got.Decision == nil || got.Decision.Hero != 1 {
+					t.Errorf("icm output = %+v, want 3 players and the decision of player 1", got)
+				}
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			withOutput(t, tt.format)
+			logs := captureLog(t)
+
+			var out bytes.Buffer
+			c := icmCmd()
+			c.SetOut(&out)
+			c.SetArgs(tt.args)
+			if err := c.Execute(); err != nil {
+				t.Fatalf("Execute() error = %v", err)
+			}
+
+			tt.check(t, out.Bytes())
+			if logs.Len() != 0 {
+				t.Errorf("log = %q, want nothing besides the %s output", logs, tt.format)
+			}
+		})
+	}
+}
+
+func TestPlayOutput_CSVRecords(t *testing.T) {
+	out := playOutput{Game: "razz", Hands: 10, Seats: []playSeatOutput{
+		{Seat: 1, Name: "calling", Stack: 950, Net: -50, BB100: -50},
+		{Seat: 2, Name: "maniac", Stack: 1050, Net: 50, BB100: 12.5},
+	}}
+
+	want := [][]string{{"razz", "1", "calling", "950", "-50", "-50"}, {"razz", "2", "maniac", "1050", "50", "12.5"}}
+	if got := out.csvRecords(); !reflect.DeepEqual(got, want) {
+		t.Errorf("csvRecords() = %v, want %v", got, want)
+	}
+}
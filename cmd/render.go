@@ -0,0 +1,150 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/YoungsoonLee/poker/cardimg"
+	"github.com/YoungsoonLee/poker/types"
+	"github.com/spf13/cobra"
+)
+
+// renderAuto renders cards with suit symbols and colors on a terminal, and in the plain form otherwise.
+const renderAuto = "auto"
+
+// renderStyle is the value of the global --render flag.
+var renderStyle string
+
+// validateRender checks the --render flag.
+func validateRender(cmd *cobra.Command, args []string) error {
+	if renderStyle == renderAuto {
+		return nil
+	}
+
+	if _, err := types.ParseStyle(renderStyle); err != nil {
+		return fmt.Errorf("invalid --render: %s. render should be auto, plain, unicode or box", renderStyle)
+	}
+
+	return nil
+}
+
+// cardRenderer returns the renderer of the cards written to w, following the --render flag.
+// Colors are only used on a terminal, and never when the NO_COLOR environment variable is set.
+func cardRenderer(w io.Writer) types.Renderer {
+	tty := isTerminal(w)
+	r := types.Renderer{Style: types.Plain, Color: tty && os.Getenv("NO_COLOR") == ""}
+
+	switch {
+	case renderStyle != renderAuto:
+		r.Style, _ = types.ParseStyle(renderStyle)
+	case tty:
+		r.Style = types.Unicode
+	}
+
+	return r
+}
+
+// renderCards renders cards for a log line. Boxes start on a line of their own.
+func renderCards(r types.Renderer, cards []types.Card) string {
+	if r.Style == types.Box {
+		return "\n" + r.Cards(cards)
+	}
+
+	return r.Cards(cards)
+}
+
+// isTerminal reports whether w is a terminal.
+func isTerminal(w io.Writer) bool {
+	f, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+
+	fi, err := f.Stat()
+	if err != nil {
+		return false
+	}
+
+	return fi.Mode()&os.ModeCharDevice != 0
+}
+
+// renderCmd returns a Cobra command for drawing hands and a board as an SVG or PNG image.
+// Two or more hands are ranked with EvaluateHands and the winners are highlighted.
+func renderCmd() *cobra.Command {
+	var board, title, format, path string
+
+	c := &cobra.Command{
+		Use:   "render [hands]",
+		Short: "Render: Draw hands and a board as an SVG or PNG image, with the winners highlighted",
+		Long: "Render: Draw hands and a board as an SVG or PNG image, with the winners highlighted.\n" +
+			"Every argument is a hand or comma separated hands. Two or more hands are ranked on their best five cards with the board.",
+
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if format == "" {
+				format = "svg"
+				if strings.EqualFold(filepath.Ext(path), ".png") {
+					format = "png"
+				}
+			}
+			if format != "svg" && format != "png" {
+				return fmt.Errorf("invalid --format: %s. format should be svg or png", format)
+			}
+
+			var hands [][]types.Card
+			for _, arg := range args {
+				for _, s := range strings.Split(arg, ",") {
+					cards, err := types.ParseCards(s)
+					if err != nil {
+						return fmt.Errorf("hand %d: %v", len(hands)+1, err)
+					}
+					hands = append(hands, cards)
+				}
+			}
+
+			boardCards, err := types.ParseCards(board)
+			if err != nil {
+				return fmt.Errorf("board: %v", err)
+			}
+
+			img, err := cardimg.Showdown(title, hands, boardCards)
+			if err != nil {
+				return err
+			}
+
+			w := cmd.OutOrStdout()
+			if path != "" {
+				f, err := os.Create(path)
+				if err != nil {
+					return err
+				}
+				defer f.Close()
+				w = f
+			}
+
+			if format == "png" {
+				err = img.WritePNG(w)
+			} else {
+				err = img.WriteSVG(w)
+			}
+			if err != nil {
+				return err
+			}
+
+			if path != "" {
+				log.Printf("Image written to %s\n", path)
+			}
+
+			return nil
+		},
+	}
+
+	c.Flags().StringVar(&board, "board", "", "Board cards drawn above the hands, ex) 2h7h9cTs3h")
+	c.Flags().StringVar(&title, "title", "", "Title of the image")
+	c.Flags().StringVar(&format, "format", "", "Image format: svg or png (default png for a .png file and svg otherwise)")
+	c.Flags().StringVarP(&path, "file", "f", "", "Write the image to this file instead of stdout")
+	return c
+}
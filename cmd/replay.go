@@ -0,0 +1,259 @@
+package cmd
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/YoungsoonLee/poker/history"
+	"github.com/YoungsoonLee/poker/table"
+	"github.com/manifoldco/promptui"
+	"github.com/spf13/cobra"
+)
+
+// replay controls offered after every step.
+const (
+	replayNext         = "Next"
+	replayPrevious     = "Previous"
+	replayNextHand     = "Next Hand"
+	replayPreviousHand = "Previous Hand"
+	replayQuit         = "Quit"
+)
+
+// replayTerminal is read for the prompts when the hands come from the standard input, which is then used up.
+var replayTerminal = "/dev/tty"
+
+// replayCmd returns a Cobra command for stepping through recorded or imported hands.
+// Every step shows the board, the pot, the stacks and the best hand of every player whose cards are known.
+// Without --all the replay is controlled from a prompt with next, previous and jump to street.
+func replayCmd() *cobra.Command {
+	var handID string
+	var all bool
+
+	c := &cobra.Command{
+		Use:   "replay [file]",
+		Short: "Replay: Step through the actions of JSON or PokerStars hand histories",
+		Long: "Replay: Step through the actions of JSON or PokerStars hand histories.\n" +
+			"Reads the standard input when no file is given, and then prompts on the terminal.",
+		Args: cobra.MaximumNArgs(1),
+
+		RunE: func(cmd *cobra.Command, args []string) error {
+			path := "-"
+			if len(args) == 1 {
+				path = args[0]
+			}
+
+			hands, err := readHands(path)
+			if err != nil {
+				return err
+			}
+			if len(hands) == 0 {
+				return errors.New("no hands to replay")
+			}
+
+			current := 0
+			if handID != "" {
+				current = -1
+				for i, h := range hands {
+					if h.ID == handID || fmt.Sprint(h.HandNo) == handID {
+						current = i
+						break
+					}
+				}
+				if current < 0 {
+					return fmt.Errorf("hand %s not found", handID)
+				}
+			}
+
+			if all {
+				for _, h := range hands[current:] {
+					steps, err := history.Replay(h)
+					if err != nil {
+						return err
+					}
+					for i := range steps {
+						printStep(h, steps, i)
+					}
+				}
+				return nil
+			}
+
+			// the hands used up the standard input, so the prompts read the terminal instead
+			var prompts io.ReadCloser
+			if path == "-" {
+				tty, err := os.Open(replayTerminal)
+				if err != nil {
+					return fmt.Errorf("no terminal for the prompts after reading the hands from the standard input, give a file or use --all: %w", err)
+				}
+				defer tty.Close()
+				prompts = tty
+			}
+
+			return replayHands(hands, current, prompts)
+		},
+	}
+
+	c.Flags().StringVar(&handID, "hand", "", "ID or number of the hand to start with")
+	c.Flags().BoolVar(&all, "all", false, "Print every step without prompting")
+	return c
+}
+
+// replayHands replays the hands from a prompt, starting with the given hand.
+// The prompt reads in, or the standard input if in is nil.
+func replayHands(hands []history.Hand, current int, in io.ReadCloser) error {
+	streets := []string{table.Preflop.String(), table.Flop.String(), table.Turn.String(), table.River.String(), table.Showdown.String()}
+
+	for {
+		h := hands[current]
+		steps, err := history.Replay(h)
+		if err != nil {
+			return err
+		}
+
+		step := 0
+		for {
+			printStep(h, steps, step)
+
+			items := []string{replayNext, replayPrevious}
+			for _, street := range streets {
+				if i := history.FindStreet(steps, street); i >= 0 {
+					items = append(items, strings.ToUpper(street[:1])+street[1:])
+				}
+			}
+			items = append(items, replayNextHand, replayPreviousHand, replayQuit)
+
+			prompt := promptui.Select{
+				Label: fmt.Sprintf("Hand %d/%d, Step %d/%d", current+1, len(hands), step+1, len(steps)),
+				Items: items,
+				Stdin: in,
+			}
+			_, choice, err := prompt.Run()
+			if err != nil {
+				return err
+			}
+
+			switch choice {
+			case replayNext:
+				if step < len(steps)-1 {
+					step++
+				}
+				continue
+			case replayPrevious:
+				if step > 0 {
+					step--
+				}
+				continue
+			case replayNextHand:
+				if current < len(hands)-1 {
+					current++
+				}
+			case replayPreviousHand:
+				if current > 0 {
+					current--
+				}
+			case replayQuit:
+				return nil
+			default:
+				step = history.FindStreet(steps, strings.ToLower(choice))
+				continue
+			}
+			break
+		}
+	}
+}
+
+// printStep logs the state of the hand at the given step.
+func printStep(h history.Hand, steps []history.Step, i int) {
+	s := steps[i]
+
+	board := strings.Join(s.Board, " ")
+	if board == "" {
+		board = "-"
+	}
+
+	log.Printf("Hand #%s, Step %d/%d, Street: %s, Board: %s, Pot: %s\n", handName(h), i+1, len(steps), s.Street, board, h.Amount(s.Pot))
+	log.Printf("> %s\n", s.Description)
+
+	for _, p := range s.Players {
+		hole := strings.Join(p.Hole, " ")
+		if hole == "" {
+			hole = "?? ??"
+		}
+
+		status := ""
+		switch {
+		case p.Folded:
+			status = ", Folded"
+		case p.AllIn:
+			status = ", All-in"
+		}
+
+		hand := ""
+		if p.Rank != "" {
+			hand = fmt.Sprintf(", Best: %s (%s)", strings.Join(p.Best, " "), p.Rank)
+		}
+
+		button := ""
+		if p.Seat == h.Button {
+			button = " (button)"
+		}
+
+		log.Printf("Seat %d: %s%s, Cards: %s, Stack: %s, Bet: %s%s%s\n", p.Seat+1, p.Name, button, hole, h.Amount(p.Stack), h.Amount(p.Bet), status, hand)
+	}
+}
+
+// handName returns the ID of the hand, or its number if it has no ID.
+func handName(h history.Hand) string {
+	if h.ID != "" {
+		return h.ID
+	}
+
+	return fmt.Sprint(h.HandNo)
+}
+
+// readHands reads the hands of a JSON or PokerStars hand history file, or of the standard input if path is "-".
+// The format is detected from the first line. PokerStars hands that cannot be parsed are logged and skipped.
+func readHands(path string) ([]history.Hand, error) {
+	in := io.Reader(os.Stdin)
+	if path != "-" {
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		in = f
+	}
+
+	// the first character that is not white space tells JSON lines from PokerStars text
+	br := bufio.NewReader(in)
+	peek, err := br.Peek(4096)
+	if err != nil && err != io.EOF && err != bufio.ErrBufferFull {
+		return nil, err
+	}
+	if text := strings.TrimSpace(string(peek)); strings.HasPrefix(text, "{") {
+		return history.ReadAll(br)
+	}
+
+	var hands []history.Hand
+	r := history.NewPokerStarsReader(br)
+	for {
+		h, err := r.Read()
+		if err == io.EOF {
+			return hands, nil
+		}
+
+		var parseErr *history.ParseError
+		if errors.As(err, &parseErr) {
+			log.Printf("%s: %v\n", path, err)
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		hands = append(hands, h)
+	}
+}
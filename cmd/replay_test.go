@@ -0,0 +1,107 @@
+package cmd
+
+import (
+	"bytes"
+	"io"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/YoungsoonLee/poker/history"
+	"github.com/YoungsoonLee/poker/table"
+)
+
+// withStdin replaces the standard input with a file holding s during a test.
+func withStdin(t *testing.T, s string) {
+	t.Helper()
+
+	file := filepath.Join(t.TempDir(), "stdin")
+	if err := os.WriteFile(file, []byte(s), 0o644); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+	f, err := os.Open(file)
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+
+	old := os.Stdin
+	os.Stdin = f
+	t.Cleanup(func() {
+		os.Stdin = old
+		f.Close()
+	})
+}
+
+// historyLines returns a JSON hand history of one hand between calling stations.
+func historyLines(t *testing.T) string {
+	t.Helper()
+
+	seats := []*table.Seat{{Name: "A", Stack: 100, Strategy: table.CallingStation{}}, {Name: "B", Stack: 100, Strategy: table.CallingStation{}}}
+	tb, err := table.New(seats, 1, 2, rand.New(rand.NewSource(1)))
+	if err != nil {
+		t.Fatalf("table.New() error = %v", err)
+	}
+	result, err := tb.PlayHand()
+	if err != nil {
+		t.Fatalf("PlayHand() error = %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err := history.NewWriter(&buf).WriteResult("1", result); err != nil {
+		t.Fatalf("WriteResult() error = %v", err)
+	}
+
+	return buf.String()
+}
+
+func TestReplayCmd_Stdin(t *testing.T) {
+	captureLog(t)
+
+	// the prompts draw on the standard output
+	devNull, err := os.OpenFile(os.DevNull, os.O_WRONLY, 0)
+	if err != nil {
+		t.Fatalf("OpenFile() error = %v", err)
+	}
+	stdout := os.Stdout
+	os.Stdout = devNull
+	t.Cleanup(func() {
+		os.Stdout = stdout
+		devNull.Close()
+	})
+
+	// the prompt moves down to the last item, Quit, and chooses it
+	quit := filepath.Join(t.TempDir(), "tty")
+	if err := os.WriteFile(quit, []byte(strings.Repeat("\x0e", 20)+"\r"), 0o644); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		terminal string
+		args     []string
+		wantErr  bool
+	}{
+		{name: "prompts read the terminal", terminal: quit},
+		{name: "no terminal", terminal: filepath.Join(t.TempDir(), "missing"), wantErr: true},
+		{name: "no terminal needed with all", terminal: filepath.Join(t.TempDir(), "missing"), args: []string{"--all"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			withStdin(t, historyLines(t))
+
+			old := replayTerminal
+			replayTerminal = tt.terminal
+			t.Cleanup(func() { replayTerminal = old })
+
+			c := replayCmd()
+			c.SetOut(io.Discard)
+			c.SetErr(io.Discard)
+			c.SetArgs(tt.args)
+			if err := c.Execute(); (err != nil) != tt.wantErr {
+				t.Errorf("Execute() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -18,6 +18,13 @@ var rootCmd = &cobra.Command{
 	Use:   "poker-cli {command} ",
 	Short: "This is cli tool for pocker game.",
 	Long:  `This is cli tool for pocker game.`,
+
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		if err := validateOutput(cmd, args); err != nil {
+			return err
+		}
+		return validateRender(cmd, args)
+	},
 }
 
 // Execute adds all child commands to the root command and sets flags appropriately.
@@ -31,23 +38,61 @@ func Execute() {
 
 func init() {
 
+	rootCmd.PersistentFlags().StringVarP(&outputFormat, "output", "o", outputText, "Output format: text, json or csv. json and csv write the results to stdout and diagnostics to stderr, and are supported by every command but serve, host, arena, replay and render")
+	rootCmd.PersistentFlags().StringVar(&renderStyle, "render", renderAuto, "Card rendering of rs, rm, prompt and eval: auto, plain, unicode or box. auto uses unicode with colors on a terminal and plain otherwise, and NO_COLOR turns colors off")
+
 	rootCmd.AddCommand(randomRsCmd)
 
 	randomMultiHandsCmd := randomMtCmd()
 	rootCmd.AddCommand(randomMultiHandsCmd)
 
 	rootCmd.AddCommand(promptCmd)
+
+	rootCmd.AddCommand(simulateCmd())
+
+	rootCmd.AddCommand(tournamentCmd())
+
+	rootCmd.AddCommand(icmCmd())
+
+	rootCmd.AddCommand(importCmd())
+
+	rootCmd.AddCommand(replayCmd())
+
+	rootCmd.AddCommand(statsCmd())
+
+	rootCmd.AddCommand(evalCmd())
+
+	rootCmd.AddCommand(serveCmd())
+
+	rootCmd.AddCommand(hostCmd())
+
+	rootCmd.AddCommand(arenaCmd())
+
+	rootCmd.AddCommand(renderCmd())
+
+	rootCmd.AddCommand(verifyCmd())
+
+	rootCmd.AddCommand(studCmd())
+
+	rootCmd.AddCommand(drawCmd())
+
+	rootCmd.AddCommand(adviseCmd())
 }
 
 // randomRsCmd represents the command for generating a random single hand and evaluating it.
 var randomRsCmd = &cobra.Command{
-	Use:   "rs",
-	Short: "Random-Single-Hand: Generate random a hand and evaluate",
+	Use:         "rs",
+	Short:       "Random-Single-Hand: Generate random a hand and evaluate",
+	Annotations: outputAnnotations(),
 
 	Run: func(cmd *cobra.Command, args []string) {
 		// get a random card
 		hand := poker.RandomCards(1)
-		log.Printf("Cards: %s\n", hand.Cards)
+		if outputFormat != outputText {
+			writeOutput(cmd, poker.EvaluateHands(poker.Hands{hand}))
+			return
+		}
+		log.Printf("Cards: %s\n", renderCards(cardRenderer(log.Writer()), hand.Cards))
 
 		// check valid ranks
 		if !hand.HasValidRanks() {
@@ -73,8 +118,9 @@ func randomMtCmd() *cobra.Command {
 	var input int
 
 	c := &cobra.Command{
-		Use:   "rm",
-		Short: "Random-Multi-Hands : Generate random multi hands and evaluate",
+		Use:         "rm",
+		Short:       "Random-Multi-Hands : Generate random multi hands and evaluate",
+		Annotations: outputAnnotations(),
 
 		Run: func(cmd *cobra.Command, args []string) {
 
@@ -86,12 +132,12 @@ func randomMtCmd() *cobra.Command {
 			hands := poker.RandomCardsToHands(input)
 
 			results := poker.EvaluateHands(hands)
-
-			log.Printf("Congrats! Win Hand ID:%d, Rank: %s, RankOrder: %d, Cards: %+v\n", results[0].HandID, results[0].Rank, results[0].RankOrder, results[0].Card)
-
-			for i, result := range results {
-				log.Printf("Result Rank [%d]. ID:%d, Rank: %s, RankOrder: %d, Cards: %+v\n", i+1, result.HandID, result.Rank, result.RankOrder, result.Card)
+			if outputFormat != outputText {
+				writeOutput(cmd, results)
+				return
 			}
+
+			logResults(results)
 		},
 	}
 
@@ -103,22 +149,34 @@ func randomMtCmd() *cobra.Command {
 // It takes a string input as a flag and generates the specified number of hands.
 // It then evaluates the hands and logs the results.
 var promptCmd = &cobra.Command{
-	Use:   "prompt",
-	Short: "Prompt: Create new hands and create new cards by each hand through prompt(your input)",
+	Use:         "prompt",
+	Short:       "Prompt: Create new hands and create new cards by each hand through prompt(your input)",
+	Annotations: outputAnnotations(),
 
 	Run: func(cmd *cobra.Command, args []string) {
 		hands := createNewHands()
 
 		results := poker.EvaluateHands(hands)
-
-		log.Printf("Congrats! Win Hand ID:%d, Rank: %s, RankOrder: %d, Cards: %+v\n", results[0].HandID, results[0].Rank, results[0].RankOrder, results[0].Card)
-
-		for i, result := range results {
-			log.Printf("Result Rank [%d]. ID:%d, Rank: %s, RankOrder: %d, Cards: %+v\n", i+1, result.HandID, result.Rank, result.RankOrder, result.Card)
+		if outputFormat != outputText {
+			writeOutput(cmd, results)
+			return
 		}
+
+		logResults(results)
 	},
 }
 
+// logResults logs the winner and the ranking of evaluated hands, with the cards rendered following the --render flag.
+func logResults(results []poker.HandResult) {
+	r := cardRenderer(log.Writer())
+
+	log.Printf("Congrats! Win Hand ID:%d, Rank: %s, RankOrder: %d, Cards: %s\n", results[0].HandID, results[0].Rank, results[0].RankOrder, renderCards(r, results[0].Card))
+
+	for i, result := range results {
+		log.Printf("Result Rank [%d]. ID:%d, Rank: %s, RankOrder: %d, Cards: %s\n", i+1, result.HandID, result.Rank, result.RankOrder, renderCards(r, result.Card))
+	}
+}
+
 type promptContent struct {
 	errorMsg string
 	label    string
@@ -146,6 +204,7 @@ func promptGetInput(pc promptContent) string {
 		Label:     pc.label,
 		Templates: templates,
 		Validate:  validate,
+		Stdout:    promptStdout(),
 	}
 
 	result, err := prompt.Run()
@@ -0,0 +1,111 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/YoungsoonLee/poker/pokerpb"
+	"github.com/YoungsoonLee/poker/rpc"
+	"github.com/YoungsoonLee/poker/server"
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc"
+)
+
+// serveShutdownTimeout is how long the server waits for requests in flight when it is stopped.
+const serveShutdownTimeout = 5 * time.Second
+
+// serveCmd returns a Cobra command for serving the evaluator, equity calculator and deck as a JSON API over HTTP.
+// With a gRPC address the gRPC service is served as well. The servers stop gracefully on an interrupt.
+func serveCmd() *cobra.Command {
+	var addr, grpcAddr string
+	var origins []string
+	var workers int
+
+	c := &cobra.Command{
+		Use:   "serve",
+		Short: "Serve: Serve hand evaluation, ranking, comparison, equity, dealing and rendering as a JSON API over HTTP",
+		Long: "Serve: Serve hand evaluation, ranking, comparison, equity, dealing and rendering as a JSON API over HTTP.\n" +
+			"Endpoints are POST /v1/evaluate, /v1/rank, /v1/compare, /v1/equity, /v1/deal and /v1/render, which answers with an image.\n" +
+			"With --grpc-addr the gRPC service of pokerpb/poker.proto is served too.",
+
+		RunE: func(cmd *cobra.Command, args []string) error {
+			srv := &http.Server{
+				Addr:              addr,
+				Handler:           server.New(server.Config{AllowOrigins: origins}),
+				ReadHeaderTimeout: 10 * time.Second,
+			}
+
+			// both listeners are opened before serving, so a bad address fails without leaving a server running
+			lis, err := net.Listen("tcp", addr)
+			if err != nil {
+				return err
+			}
+
+			var grpcLis net.Listener
+			if grpcAddr != "" {
+				grpcLis, err = net.Listen("tcp", grpcAddr)
+				if err != nil {
+					lis.Close()
+					return err
+				}
+			}
+
+			ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+			defer stop()
+
+			errs := make(chan error, 2)
+			go func() {
+				log.Printf("Listening on http://%s\n", lis.Addr())
+				errs <- srv.Serve(lis)
+			}()
+
+			var grpcServer *grpc.Server
+			if grpcLis != nil {
+				grpcServer = grpc.NewServer()
+				pokerpb.RegisterPokerServiceServer(grpcServer, rpc.NewServer(workers))
+				go func() {
+					log.Printf("Listening for gRPC on %s\n", grpcLis.Addr())
+					errs <- grpcServer.Serve(grpcLis)
+				}()
+			}
+
+			// a server that fails stops the other one too
+			var serveErr error
+			select {
+			case serveErr = <-errs:
+			case <-ctx.Done():
+				log.Printf("Shutting down\n")
+			}
+
+			if grpcServer != nil {
+				grpcServer.GracefulStop()
+			}
+
+			shutdown, cancel := context.WithTimeout(context.Background(), serveShutdownTimeout)
+			defer cancel()
+			if err := srv.Shutdown(shutdown); err != nil && serveErr == nil {
+				return err
+			}
+			if serveErr != nil {
+				return serveErr
+			}
+			if err := <-errs; err != nil && !errors.Is(err, http.ErrServerClosed) {
+				return err
+			}
+
+			return nil
+		},
+	}
+
+	c.Flags().StringVar(&addr, "addr", "localhost:8080", "Address to listen on")
+	c.Flags().StringVar(&grpcAddr, "grpc-addr", "", "Address to also serve the gRPC service on, ex) localhost:9090")
+	c.Flags().IntVar(&workers, "workers", 0, "Number of parallel workers of the gRPC RankStream (default number of CPUs)")
+	c.Flags().StringSliceVar(&origins, "cors-origin", nil, "Origins browsers may call the API from, ex) http://localhost:3000, or * for any origin")
+	return c
+}
@@ -0,0 +1,66 @@
+package cmd
+
+import (
+	"io"
+	"net"
+	"testing"
+)
+
+// freeAddr returns a local address that nothing listens on.
+func freeAddr(t *testing.T) string {
+	t.Helper()
+
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Listen() error = %v", err)
+	}
+	addr := lis.Addr().String()
+	lis.Close()
+
+	return addr
+}
+
+func TestServeCmd_ListenError(t *testing.T) {
+	captureLog(t)
+
+	taken, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Listen() error = %v", err)
+	}
+	defer taken.Close()
+
+	tests := []struct {
+		name     string
+		addr     func() string
+		grpcAddr func() string
+	}{
+		{name: "http address in use", addr: taken.Addr().String, grpcAddr: func() string { return freeAddr(t) }},
+		{name: "grpc address in use", addr: func() string { return freeAddr(t) }, grpcAddr: taken.Addr().String},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			addr, grpcAddr := tt.addr(), tt.grpcAddr()
+
+			c := serveCmd()
+			c.SetOut(io.Discard)
+			c.SetErr(io.Discard)
+			c.SetArgs([]string{"--addr", addr, "--grpc-addr", grpcAddr})
+			if err := c.Execute(); err == nil {
+				t.Fatalf("Execute() error = nil, want an error")
+			}
+
+			// neither server may still be listening after the command failed
+			for _, a := range []string{addr, grpcAddr} {
+				if a == taken.Addr().String() {
+					continue
+				}
+				lis, err := net.Listen("tcp", a)
+				if err != nil {
+					t.Fatalf("%s is still in use: %v", a, err)
+				}
+				lis.Close()
+			}
+		})
+	}
+}
@@ -0,0 +1,183 @@
+package cmd
+
+import (
+	"context"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/YoungsoonLee/poker/history"
+	"github.com/YoungsoonLee/poker/sim"
+	"github.com/YoungsoonLee/poker/table"
+	"github.com/YoungsoonLee/poker/tournament"
+	"github.com/spf13/cobra"
+)
+
+// simulateCmd returns a Cobra command for running bots against each other without any prompts.
+// It plays cash game hands or sit-and-go tournaments in parallel and logs the performance of every bot.
+// The chip graph can be written as CSV with the --csv flag, and every hand as a JSON line with the --history flag.
+func simulateCmd() *cobra.Command {
+	var cfg sim.Config
+	var mode, csvPath, structurePath, historyPath string
+	var handSeconds int
+
+	c := &cobra.Command{
+		Use:   "simulate",
+		Short: "Simulate: Run bots against each other and report win rates, bb/100 and chip graphs",
+		Long: "Simulate: Run bots against each other and report win rates, bb/100 and chip graphs.\n" +
+			"Available bots: " + strings.Join(table.StrategyNames(), ", "),
+		Annotations: outputAnnotations(),
+
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg.Mode = sim.Mode(mode)
+			cfg.HandDuration = time.Duration(handSeconds) * time.Second
+
+			if structurePath != "" {
+				structure, err := tournament.LoadStructure(structurePath)
+				if err != nil {
+					return err
+				}
+				cfg.Structure = &structure
+			}
+
+			if historyPath != "" {
+				f, err := os.Create(historyPath)
+				if err != nil {
+					return err
+				}
+				defer f.Close()
+				cfg.History = history.NewWriter(f)
+			}
+
+			report, err := sim.Run(context.Background(), cfg)
+			if err != nil {
+				return err
+			}
+
+			switch {
+			case outputFormat != outputText:
+				if err := writeReport(cmd.OutOrStdout(), newSimulateOutput(report)); err != nil {
+					return err
+				}
+			case report.Mode == sim.Cash:
+				log.Printf("Simulated %d hands\n", report.Hands)
+				for _, p := range report.Players {
+					log.Printf("Bot: %s, Win Rate: %.2f%%, Net: %d, bb/100: %.2f (95%% CI ±%.2f)\n", p.Name, p.WinRate*100, p.Net, p.BB100, p.CI95)
+				}
+			case report.Mode == sim.SitAndGo:
+				log.Printf("Simulated %d tournaments, %d hands\n", report.Tournaments, report.Hands)
+				for _, p := range report.Players {
+					log.Printf("Bot: %s, Wins: %d, Win Rate: %.2f%%, Avg Finish: %.2f, Prizes: %d, ROI: %.2f%%, Knockouts: %d\n", p.Name, p.Wins, p.WinRate*100, p.AvgFinish, p.Prizes, p.ROI*100, p.Knockouts)
+				}
+			}
+
+			if historyPath != "" {
+				log.Printf("Hand history written to %s\n", historyPath)
+			}
+
+			if csvPath == "" {
+				return nil
+			}
+
+			f, err := os.Create(csvPath)
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+
+			if err := report.WriteGraphCSV(f); err != nil {
+				return err
+			}
+			log.Printf("Chip graph written to %s\n", csvPath)
+
+			return nil
+		},
+	}
+
+	c.Flags().StringSliceVar(&cfg.Bots, "bots", []string{"tag", "calling"}, "Bots to seat, one per seat")
+	c.Flags().StringVar(&mode, "mode", string(sim.Cash), "Game to simulate: cash or sng")
+	c.Flags().IntVar(&cfg.Hands, "hands", 10000, "Number of hands to play in cash mode")
+	c.Flags().IntVar(&cfg.Tournaments, "tournaments", 100, "Number of tournaments to play in sng mode")
+	c.Flags().IntVar(&cfg.Workers, "workers", 0, "Number of parallel workers (default number of CPUs)")
+	c.Flags().IntVar(&cfg.StartingStack, "stack", 1000, "Starting stack of every bot")
+	c.Flags().IntVar(&cfg.SmallBlind, "sb", 5, "Small blind")
+	c.Flags().IntVar(&cfg.BigBlind, "bb", 10, "Big blind")
+	c.Flags().IntVar(&cfg.LevelHands, "level-hands", 50, "Hands per blind level in sng mode, blinds double every level")
+	c.Flags().StringVar(&structurePath, "structure", "", "YAML or JSON tournament structure file for sng mode")
+	c.Flags().IntVar(&handSeconds, "hand-seconds", 60, "Simulated seconds every hand takes for time based levels")
+	c.Flags().IntVar(&cfg.TableSize, "table-size", 0, "Maximum number of bots per table in sng mode (default all bots at one table)")
+	c.Flags().Int64Var(&cfg.Seed, "seed", 0, "Random seed for reproducible runs (default current time)")
+	c.Flags().StringVar(&csvPath, "csv", "", "Write the chip graph as CSV to this file")
+	c.Flags().StringVar(&historyPath, "history", "", "Write every hand as line-delimited JSON to this file")
+	return c
+}
+
+// simulateOutput is a simulation report in the json and csv output formats, with one csv record per bot.
+type simulateOutput struct {
+	Mode        string                 `json:"mode"`
+	Hands       int                    `json:"hands"`
+	Tournaments int                    `json:"tournaments"`
+	Players     []simulatePlayerOutput `json:"players"`
+}
+
+// simulatePlayerOutput is the performance of a bot in a simulation, with the fields of sim.PlayerStats.
+// AvgFinish, Prizes, ROI and Knockouts are only set in sit-and-go mode, and Net, BB100 and CI95 in cash mode.
+type simulatePlayerOutput struct {
+	Seat      int     `json:"seat"`
+	Name      string  `json:"name"`
+	Bot       string  `json:"bot"`
+	Hands     int     `json:"hands"`
+	Net       int     `json:"net"`
+	BB100     float64 `json:"bb_100"`
+	CI95      float64 `json:"ci95"`
+	WinRate   float64 `json:"win_rate"`
+	Wins      int     `json:"wins"`
+	AvgFinish float64 `json:"avg_finish"`
+	Prizes    int     `json:"prizes"`
+	ROI       float64 `json:"roi"`
+	Knockouts int     `json:"knockouts"`
+}
+
+// newSimulateOutput converts a simulation report to its json and csv form.
+func newSimulateOutput(r *sim.Report) simulateOutput {
+	out := simulateOutput{Mode: string(r.Mode), Hands: r.Hands, Tournaments: r.Tournaments, Players: []simulatePlayerOutput{}}
+	for _, p := range r.Players {
+		out.Players = append(out.Players, simulatePlayerOutput{
+			Seat:      p.Seat,
+			Name:      p.Name,
+			Bot:       p.Bot,
+			Hands:     p.Hands,
+			Net:       p.Net,
+			BB100:     p.BB100,
+			CI95:      p.CI95,
+			WinRate:   p.WinRate,
+			Wins:      p.Wins,
+			AvgFinish: p.AvgFinish,
+			Prizes:    p.Prizes,
+			ROI:       p.ROI,
+			Knockouts: p.Knockouts,
+		})
+	}
+
+	return out
+}
+
+// csvHeader implements report.
+func (o simulateOutput) csvHeader() []string {
+	return []string{"mode", "seat", "name", "bot", "hands", "net", "bb_100", "ci95", "win_rate", "wins", "avg_finish", "prizes", "roi", "knockouts"}
+}
+
+// csvRecords implements report with one record per bot.
+func (o simulateOutput) csvRecords() [][]string {
+	records := make([][]string, len(o.Players))
+	for i, p := range o.Players {
+		records[i] = []string{
+			o.Mode, strconv.Itoa(p.Seat), p.Name, p.Bot, strconv.Itoa(p.Hands), strconv.Itoa(p.Net), formatFloat(p.BB100), formatFloat(p.CI95),
+			formatFloat(p.WinRate), strconv.Itoa(p.Wins), formatFloat(p.AvgFinish), strconv.Itoa(p.Prizes), formatFloat(p.ROI), strconv.Itoa(p.Knockouts),
+		}
+	}
+
+	return records
+}
@@ -0,0 +1,243 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/YoungsoonLee/poker/stats"
+	"github.com/spf13/cobra"
+)
+
+// statsDateLayout is the layout of the --from and --to flags.
+const statsDateLayout = "2006-01-02"
+
+// statsCmd returns a Cobra command for adding hand histories to a local statistics database and printing player statistics.
+// The given JSON or PokerStars hand history files are added first, skipping hands already in the database.
+// The statistics can be filtered by date, stakes and player.
+func statsCmd() *cobra.Command {
+	var dbPath, from, to, stakes string
+	var players []string
+	var detail bool
+
+	c := &cobra.Command{
+		Use:   "stats [files]",
+		Short: "Stats: Aggregate hand histories into player statistics like VPIP, PFR, 3-bet, WTSD, W$SD and aggression",
+		Long: "Stats: Aggregate hand histories into player statistics like VPIP, PFR, 3-bet, WTSD, W$SD and aggression.\n" +
+			"Hand history files given as arguments are added to the database before the statistics are printed.",
+		Annotations: outputAnnotations(),
+
+		RunE: func(cmd *cobra.Command, args []string) error {
+			filter, err := statsFilter(from, to, stakes, players)
+			if err != nil {
+				return err
+			}
+
+			db, err := stats.Open(dbPath)
+			if err != nil {
+				return err
+			}
+			defer db.Close()
+
+			for _, path := range args {
+				hands, err := readHands(path)
+				if err != nil {
+					return err
+				}
+
+				added, err := db.Add(hands...)
+				if err != nil {
+					return err
+				}
+				log.Printf("%s: added %d of %d hands\n", path, added, len(hands))
+			}
+
+			records, err := db.Records(filter)
+			if err != nil {
+				return err
+			}
+
+			players := stats.Aggregate(records)
+			if outputFormat != outputText {
+				return writeReport(cmd.OutOrStdout(), newStatsOutput(players, detail))
+			}
+
+			printStats(cmd.OutOrStdout(), players, detail)
+
+			return nil
+		},
+	}
+
+	c.Flags().StringVar(&dbPath, "db", "poker-stats.db", "Statistics database file")
+	c.Flags().StringVar(&from, "from", "", "Only hands played on or after this date, ex) 2023-05-01")
+	c.Flags().StringVar(&to, "to", "", "Only hands played before this date, ex) 2023-06-01")
+	c.Flags().StringVar(&stakes, "stakes", "", "Only hands with these blinds, ex) 5/10, or only the big blind, ex) 10")
+	c.Flags().StringSliceVar(&players, "player", nil, "Only these players")
+	c.Flags().BoolVar(&detail, "detail", false, "Also print the winnings by position and the showdowns by hand category")
+	return c
+}
+
+// statsFilter builds the filter of the stats command from its flags.
+func statsFilter(from, to, stakes string, players []string) (stats.Filter, error) {
+	filter := stats.Filter{Players: players}
+
+	var err error
+	if from != "" {
+		if filter.From, err = time.Parse(statsDateLayout, from); err != nil {
+			return filter, fmt.Errorf("invalid --from: %s. date should be like 2023-05-01", from)
+		}
+	}
+	if to != "" {
+		if filter.To, err = time.Parse(statsDateLayout, to); err != nil {
+			return filter, fmt.Errorf("invalid --to: %s. date should be like 2023-06-01", to)
+		}
+	}
+
+	if stakes != "" {
+		sb, bb, ok := strings.Cut(stakes, "/")
+		if !ok {
+			sb, bb = "", stakes
+		}
+		if filter.BigBlind, err = strconv.Atoi(bb); err != nil || filter.BigBlind < 1 {
+			return filter, fmt.Errorf("invalid --stakes: %s. stakes should be like 5/10", stakes)
+		}
+		if sb != "" {
+			if filter.SmallBlind, err = strconv.Atoi(sb); err != nil || filter.SmallBlind < 1 {
+				return filter, fmt.Errorf("invalid --stakes: %s. stakes should be like 5/10", stakes)
+			}
+		}
+	}
+
+	return filter, nil
+}
+
+// printStats prints a table with a row per player, and with detail a table of positions and showdowns per player.
+func printStats(out io.Writer, players []stats.PlayerStats, detail bool) {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', tabwriter.AlignRight)
+
+	fmt.Fprintln(w, "Player\tHands\tVPIP\tPFR\t3-Bet\tWTSD\tW$SD\tAF\tNet\tbb/100\t")
+	for _, p := range players {
+		fmt.Fprintf(w, "%s\t%d\t%.1f%%\t%.1f%%\t%.1f%%\t%.1f%%\t%.1f%%\t%.2f\t%d\t%.2f\t\n",
+			p.Player, p.Hands, p.VPIP*100, p.PFR*100, p.ThreeBet*100, p.WTSD*100, p.WSD*100, p.AF, p.Net, p.BB100)
+	}
+	w.Flush()
+
+	if !detail {
+		return
+	}
+
+	for _, p := range players {
+		fmt.Fprintf(out, "\n%s\n", p.Player)
+
+		w := tabwriter.NewWriter(out, 0, 0, 2, ' ', tabwriter.AlignRight)
+		fmt.Fprintln(w, "Position\tHands\tNet\tbb\t")
+		for _, pos := range p.Positions {
+			fmt.Fprintf(w, "%s\t%d\t%d\t%.1f\t\n", pos.Position, pos.Hands, pos.Net, pos.BigBlinds)
+		}
+		w.Flush()
+
+		if len(p.Showdowns) == 0 {
+			continue
+		}
+
+		fmt.Fprintln(out)
+		w = tabwriter.NewWriter(out, 0, 0, 2, ' ', tabwriter.AlignRight)
+		fmt.Fprintln(w, "Showdown\tHands\tWon\t")
+		for _, b := range p.Showdowns {
+			fmt.Fprintf(w, "%s\t%d\t%d\t\n", b.Category, b.Showdowns, b.Won)
+		}
+		w.Flush()
+	}
+}
+
+// statsOutput is the statistics of every player in the json and csv output formats.
+// The winnings by position and the showdowns by hand category are only in the json, with --detail,
+// and the csv has one record per player with the columns of the text table.
+type statsOutput struct {
+	Players []statsPlayerOutput `json:"players"`
+}
+
+// statsPlayerOutput is the statistics of a player, with the fields of stats.PlayerStats.
+type statsPlayerOutput struct {
+	Player    string                `json:"player"`
+	Hands     int                   `json:"hands"`
+	VPIP      float64               `json:"vpip"`
+	PFR       float64               `json:"pfr"`
+	ThreeBet  float64               `json:"three_bet"`
+	WTSD      float64               `json:"wtsd"`
+	WSD       float64               `json:"wsd"`
+	AF        float64               `json:"af"`
+	Net       int                   `json:"net"`
+	BigBlinds float64               `json:"big_blinds"`
+	BB100     float64               `json:"bb_100"`
+	Positions []statsPositionOutput `json:"positions,omitempty"`
+	Showdowns []statsShowdownOutput `json:"showdowns,omitempty"`
+}
+
+// statsPositionOutput is the winnings of a player from one position.
+type statsPositionOutput struct {
+	Position  string  `json:"position"`
+	Hands     int     `json:"hands"`
+	Net       int     `json:"net"`
+	BigBlinds float64 `json:"big_blinds"`
+}
+
+// statsShowdownOutput is how often a player went to showdown with a category of hand, and how often they won.
+type statsShowdownOutput struct {
+	Category  string `json:"category"`
+	Showdowns int    `json:"showdowns"`
+	Won       int    `json:"won"`
+}
+
+// newStatsOutput converts the statistics of the players to their json and csv form, with the details if detail is set.
+func newStatsOutput(players []stats.PlayerStats, detail bool) statsOutput {
+	out := statsOutput{Players: []statsPlayerOutput{}}
+	for _, p := range players {
+		o := statsPlayerOutput{
+			Player:    p.Player,
+			Hands:     p.Hands,
+			VPIP:      p.VPIP,
+			PFR:       p.PFR,
+			ThreeBet:  p.ThreeBet,
+			WTSD:      p.WTSD,
+			WSD:       p.WSD,
+			AF:        p.AF,
+			Net:       p.Net,
+			BigBlinds: p.BigBlinds,
+			BB100:     p.BB100,
+		}
+		if detail {
+			for _, pos := range p.Positions {
+				o.Positions = append(o.Positions, statsPositionOutput{Position: pos.Position, Hands: pos.Hands, Net: pos.Net, BigBlinds: pos.BigBlinds})
+			}
+			for _, b := range p.Showdowns {
+				o.Showdowns = append(o.Showdowns, statsShowdownOutput{Category: b.Category, Showdowns: b.Showdowns, Won: b.Won})
+			}
+		}
+		out.Players = append(out.Players, o)
+	}
+
+	return out
+}
+
+// csvHeader implements report.
+func (o statsOutput) csvHeader() []string {
+	return []string{"player", "hands", "vpip", "pfr", "three_bet", "wtsd", "wsd", "af", "net", "big_blinds", "bb_100"}
+}
+
+// csvRecords implements report with one record per player.
+func (o statsOutput) csvRecords() [][]string {
+	records := make([][]string, len(o.Players))
+	for i, p := range o.Players {
+		records[i] = []string{
+			p.Player, strconv.Itoa(p.Hands), formatFloat(p.VPIP), formatFloat(p.PFR), formatFloat(p.ThreeBet), formatFloat(p.WTSD),
+			formatFloat(p.WSD), formatFloat(p.AF), strconv.Itoa(p.Net), formatFloat(p.BigBlinds), formatFloat(p.BB100),
+		}
+	}
+
+	return records
+}
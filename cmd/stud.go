@@ -0,0 +1,155 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"math/rand"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/YoungsoonLee/poker/history"
+	"github.com/YoungsoonLee/poker/stud"
+	"github.com/spf13/cobra"
+)
+
+// studCmd returns a Cobra command for playing Fixed-Limit Seven-Card Stud or Razz between bots.
+// Stacks are reset before every hand, and the net chips and big bets per 100 hands of every bot are logged.
+func studCmd() *cobra.Command {
+	var gameName string
+	var bots []string
+	var hands, stack int
+	var stakes stud.Stakes
+	var seed int64
+	var show bool
+	var historyPath string
+
+	c := &cobra.Command{
+		Use:   "stud",
+		Short: "Stud: Play Seven-Card Stud or Razz between bots",
+		Long: "Stud: Play Fixed-Limit Seven-Card Stud or Razz between bots and report the net chips of every bot.\n" +
+			"Available bots: " + strings.Join(stud.StrategyNames(), ", "),
+		Annotations: outputAnnotations(),
+
+		RunE: func(cmd *cobra.Command, args []string) error {
+			game, err := stud.ParseGame(gameName)
+			if err != nil {
+				return err
+			}
+
+			if seed == 0 {
+				seed = time.Now().UnixNano()
+			}
+			rng := rand.New(rand.NewSource(seed))
+
+			seats := make([]*stud.Seat, len(bots))
+			for i, name := range bots {
+				strategy, err := stud.NewStrategy(name, rng)
+				if err != nil {
+					return err
+				}
+				seats[i] = &stud.Seat{Name: name, Stack: stack, Strategy: strategy}
+			}
+
+			t, err := stud.New(game, seats, stakes, rng)
+			if err != nil {
+				return err
+			}
+
+			var recorder *history.Writer
+			if historyPath != "" {
+				f, err := os.Create(historyPath)
+				if err != nil {
+					return err
+				}
+				defer f.Close()
+				recorder = history.NewWriter(f)
+			}
+
+			net := make([]int, len(seats))
+			for i := 0; i < hands; i++ {
+				for _, s := range seats {
+					s.Stack = stack
+				}
+
+				result, err := t.PlayHand()
+				if err != nil {
+					return err
+				}
+				for _, p := range result.Players {
+					net[p.Seat] += p.Net()
+				}
+
+				if show {
+					logStudHand(result)
+				}
+				if recorder != nil {
+					if err := recorder.WriteStudResult(fmt.Sprintf("%s-%d", game, result.HandNo), result); err != nil {
+						return err
+					}
+				}
+			}
+
+			if historyPath != "" {
+				log.Printf("Hand history written to %s\n", historyPath)
+			}
+
+			if outputFormat != outputText {
+				out := playOutput{Game: game.String(), Hands: hands, Seats: []playSeatOutput{}}
+				for i, s := range seats {
+					out.Seats = append(out.Seats, playSeatOutput{Seat: i, Name: s.Name, Stack: s.Stack, Net: net[i], BB100: float64(net[i]) * 100 / float64(stakes.BigBet) / float64(max(hands, 1))})
+				}
+				return writeReport(cmd.OutOrStdout(), out)
+			}
+
+			log.Printf("Played %d hands of %s\n", hands, game)
+			for i, s := range seats {
+				log.Printf("Seat %d. Bot: %s, Net: %d, bb/100: %.2f\n", i, s.Name, net[i], float64(net[i])*100/float64(stakes.BigBet)/float64(hands))
+			}
+
+			return nil
+		},
+	}
+
+	c.Flags().StringVar(&gameName, "game", "stud", "Game to play: stud or razz")
+	c.Flags().StringSliceVar(&bots, "bots", []string{"calling", "random"}, "Bots to seat, one per seat, at most 8")
+	c.Flags().IntVar(&hands, "hands", 1000, "Number of hands to play")
+	c.Flags().IntVar(&stack, "stack", 1000, "Stack of every bot at the start of every hand")
+	c.Flags().IntVar(&stakes.Ante, "ante", 1, "Ante")
+	c.Flags().IntVar(&stakes.BringIn, "bring-in", 2, "Bring-in")
+	c.Flags().IntVar(&stakes.SmallBet, "small-bet", 5, "Small bet on third and fourth street")
+	c.Flags().IntVar(&stakes.BigBet, "big-bet", 10, "Big bet from fifth street on")
+	c.Flags().Int64Var(&seed, "seed", 0, "Random seed for reproducible runs (default current time)")
+	c.Flags().BoolVar(&show, "show", false, "Log the cards and the showdown of every hand")
+	c.Flags().StringVar(&historyPath, "history", "", "Write every hand as line-delimited JSON to this file")
+	return c
+}
+
+// logStudHand logs the cards of every player in a stud hand, and the hands shown at showdown.
+func logStudHand(result *stud.Result) {
+	renderer := cardRenderer(log.Writer())
+
+	log.Printf("Hand %d. Bring-in: seat %d\n", result.HandNo, result.BringInSeat)
+	for _, p := range result.Players {
+		status := ""
+		if p.Folded {
+			status = ", Folded"
+		}
+		log.Printf("Seat %d. Down: %s, Up: %s%s, Net: %d\n", p.Seat, renderCards(renderer, p.Down), renderCards(renderer, p.Up), status, p.Net())
+	}
+	if len(result.Community) > 0 {
+		log.Printf("Community: %s\n", renderCards(renderer, result.Community))
+	}
+
+	for _, s := range result.Showdown {
+		winner := ""
+		for _, pot := range result.Pots {
+			if slices.Contains(pot.Winners, s.Seat) {
+				winner = ", Winner"
+				break
+			}
+		}
+		log.Printf("Showdown. Seat %d, Rank: %s, Cards: %s%s\n", s.Seat, s.Rank, renderCards(renderer, s.Hand.Cards), winner)
+	}
+}
@@ -0,0 +1,165 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math/rand"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/YoungsoonLee/poker/history"
+	"github.com/YoungsoonLee/poker/table"
+	"github.com/YoungsoonLee/poker/tournament"
+	"github.com/spf13/cobra"
+)
+
+// tournamentCmd returns a Cobra command for playing a tournament between bots.
+// The blind schedule, bounty and payouts are loaded from a YAML or JSON structure file given with --structure.
+// It logs the level changes, the eliminations and the final places with their prizes.
+// Every hand can be recorded as a JSON line with the --history flag.
+func tournamentCmd() *cobra.Command {
+	var bots []string
+	var structurePath, historyPath string
+	var tableSize int
+	var handSeconds int
+	var seed int64
+
+	c := &cobra.Command{
+		Use:   "tournament",
+		Short: "Tournament: Play a sit-and-go or multi-table tournament between bots with a blind structure and payouts",
+		Long: "Tournament: Play a sit-and-go or multi-table tournament between bots with a blind structure and payouts.\n" +
+			"Available bots: " + strings.Join(table.StrategyNames(), ", "),
+		Annotations: outputAnnotations(),
+
+		RunE: func(cmd *cobra.Command, args []string) error {
+			structure := tournament.DefaultStructure(1500, 10, 20, 10)
+			if structurePath != "" {
+				s, err := tournament.LoadStructure(structurePath)
+				if err != nil {
+					return err
+				}
+				structure = s
+			}
+
+			if seed == 0 {
+				seed = time.Now().UnixNano()
+			}
+			rng := rand.New(rand.NewSource(seed))
+
+			players := make([]tournament.Player, len(bots))
+			for i, bot := range bots {
+				strategy, err := table.NewStrategy(bot, rng)
+				if err != nil {
+					return err
+				}
+				players[i] = tournament.Player{Name: fmt.Sprintf("%s-%d", bot, i+1), Strategy: strategy}
+			}
+
+			var recorder *history.Writer
+			var historyErr error
+			if historyPath != "" {
+				f, err := os.Create(historyPath)
+				if err != nil {
+					return err
+				}
+				defer f.Close()
+				recorder = history.NewWriter(f)
+			}
+
+			log.Printf("Tournament: %s, Entrants: %d, Prize Pool: %d, Bounty: %d\n", structure.Name, len(players), structure.PrizePool(len(players)), structure.Bounty)
+
+			result, err := tournament.Run(context.Background(), tournament.Config{
+				Structure:    structure,
+				Players:      players,
+				TableSize:    tableSize,
+				HandDuration: time.Duration(handSeconds) * time.Second,
+				Rng:          rng,
+				OnLevel: func(level int, l tournament.Level) {
+					log.Printf("Level %d: Blinds %d/%d, Ante: %d\n", level+1, l.SmallBlind, l.BigBlind, l.Ante)
+				},
+				OnHand: func(tableID int, hand *table.Result) {
+					if recorder != nil && historyErr == nil {
+						historyErr = recorder.WriteResult(fmt.Sprintf("table-%d-%d", tableID+1, hand.HandNo), hand)
+					}
+
+					for _, p := range hand.Players {
+						if p.EndStack == 0 {
+							log.Printf("Table %d, Hand %d: %s is eliminated\n", tableID+1, hand.HandNo, p.Name)
+						}
+					}
+				},
+			})
+			if err != nil {
+				return err
+			}
+			if historyErr != nil {
+				return historyErr
+			}
+
+			if outputFormat != outputText {
+				return writeReport(cmd.OutOrStdout(), newTournamentOutput(structure.Name, result))
+			}
+
+			log.Printf("Congrats! Winner: %s, Hands: %d\n", result.Places[0].Name, result.Hands)
+			for _, p := range result.Places {
+				log.Printf("Place [%d]. %s, Prize: %d, Knockouts: %d, Bounties: %d\n", p.Position, p.Name, p.Prize, p.Knockouts, p.Bounties)
+			}
+
+			return nil
+		},
+	}
+
+	c.Flags().StringSliceVar(&bots, "bots", []string{"tag", "calling", "random", "maniac", "tag", "calling"}, "Bots to enter, one per entrant")
+	c.Flags().StringVar(&structurePath, "structure", "", "YAML or JSON structure file (default blinds double every 10 hands, winner takes all)")
+	c.Flags().IntVar(&tableSize, "table-size", 9, "Maximum number of seats per table")
+	c.Flags().IntVar(&handSeconds, "hand-seconds", 60, "Seconds every hand takes for time based levels, 0 uses the wall clock")
+	c.Flags().Int64Var(&seed, "seed", 0, "Random seed for reproducible runs (default current time)")
+	c.Flags().StringVar(&historyPath, "history", "", "Write every hand as line-delimited JSON to this file")
+	return c
+}
+
+// tournamentOutput is the result of a tournament in the json and csv output formats, with one csv record per place.
+type tournamentOutput struct {
+	Name      string        `json:"name"`
+	Entrants  int           `json:"entrants"`
+	PrizePool int           `json:"prize_pool"`
+	Hands     int           `json:"hands"`
+	Places    []placeOutput `json:"places"`
+}
+
+// placeOutput is the finishing place of a player, starting with the winner.
+type placeOutput struct {
+	Position  int    `json:"position"`
+	Name      string `json:"name"`
+	Prize     int    `json:"prize"`
+	Knockouts int    `json:"knockouts"`
+	Bounties  int    `json:"bounties"`
+}
+
+// newTournamentOutput converts the result of a tournament with the given structure name to its json and csv form.
+func newTournamentOutput(name string, r *tournament.Result) tournamentOutput {
+	out := tournamentOutput{Name: name, Entrants: r.Entrants, PrizePool: r.PrizePool, Hands: r.Hands, Places: []placeOutput{}}
+	for _, p := range r.Places {
+		out.Places = append(out.Places, placeOutput{Position: p.Position, Name: p.Name, Prize: p.Prize, Knockouts: p.Knockouts, Bounties: p.Bounties})
+	}
+
+	return out
+}
+
+// csvHeader implements report.
+func (o tournamentOutput) csvHeader() []string {
+	return []string{"position", "name", "prize", "knockouts", "bounties"}
+}
+
+// csvRecords implements report with one record per place.
+func (o tournamentOutput) csvRecords() [][]string {
+	records := make([][]string, len(o.Places))
+	for i, p := range o.Places {
+		records[i] = []string{strconv.Itoa(p.Position), p.Name, strconv.Itoa(p.Prize), strconv.Itoa(p.Knockouts), strconv.Itoa(p.Bounties)}
+	}
+
+	return records
+}
@@ -0,0 +1,118 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"github.com/YoungsoonLee/poker/poker"
+	"github.com/spf13/cobra"
+)
+
+// verifyCmd returns a Cobra command for checking the evaluator against every five card hand.
+// It prints the frequency and probability of every category, and fails if any count differs from combinatorics.
+func verifyCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "verify",
+		Short: "Verify: Evaluate all 2,598,960 five card hands and check the frequencies of every category",
+		Long: "Verify: Evaluate all 2,598,960 five card hands and check the frequencies of every category.\n" +
+			"The hands and distinct values of every category are compared with the known combinatorial frequencies, and there should be exactly 7,462 distinct values.",
+		Annotations: outputAnnotations(),
+
+		RunE: func(cmd *cobra.Command, args []string) error {
+			start := time.Now()
+			v := poker.VerifyFiveCardHands()
+
+			if outputFormat != outputText {
+				if err := writeReport(cmd.OutOrStdout(), newVerifyOutput(v)); err != nil {
+					return err
+				}
+			} else {
+				printFrequencies(cmd.OutOrStdout(), v)
+			}
+
+			if err := v.Err(); err != nil {
+				return err
+			}
+			log.Printf("Verified %d hands and %d equivalence classes in %s\n", v.Hands, v.Classes, time.Since(start).Round(time.Millisecond))
+
+			return nil
+		},
+	}
+}
+
+// printFrequencies prints a table with the hands, probability, odds and equivalence classes of every category.
+func printFrequencies(out io.Writer, v poker.Verification) {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', tabwriter.AlignRight)
+
+	fmt.Fprintln(w, "Rank\tHands\tProbability\tOdds\tClasses\tExpected\t")
+	for _, f := range v.Frequencies {
+		expected := "ok"
+		if f.Hands != f.WantHands || f.Classes != f.WantClasses {
+			expected = fmt.Sprintf("%d hands, %d classes", f.WantHands, f.WantClasses)
+		}
+		fmt.Fprintf(w, "%s\t%d\t%.6f%%\t%.2f : 1\t%d\t%s\t\n",
+			f.Rank, f.Hands, f.Probability()*100, float64(v.Hands-f.Hands)/float64(max(f.Hands, 1)), f.Classes, expected)
+	}
+	fmt.Fprintf(w, "Total\t%d\t%.6f%%\t\t%d\t\t\n", v.Hands, 100.0, v.Classes)
+	w.Flush()
+}
+
+// verifyOutput is the verification of every five card hand in the json and csv output formats,
+// with one csv record per category. The want counts are the known combinatorial frequencies.
+type verifyOutput struct {
+	Hands       int               `json:"hands"`
+	Classes     int               `json:"classes"`
+	Mismatches  int               `json:"mismatches"`
+	Frequencies []frequencyOutput `json:"frequencies"`
+}
+
+// frequencyOutput is the number of hands and equivalence classes of a category.
+type frequencyOutput struct {
+	RankOrder   int     `json:"rank_order"`
+	Rank        string  `json:"rank"`
+	Hands       int     `json:"hands"`
+	Probability float64 `json:"probability"`
+	Classes     int     `json:"classes"`
+	WantHands   int     `json:"want_hands"`
+	WantClasses int     `json:"want_classes"`
+}
+
+// newVerifyOutput converts a verification to its json and csv form.
+func newVerifyOutput(v poker.Verification) verifyOutput {
+	out := verifyOutput{Hands: v.Hands, Classes: v.Classes, Mismatches: v.Mismatches, Frequencies: []frequencyOutput{}}
+	for _, f := range v.Frequencies {
+		out.Frequencies = append(out.Frequencies, frequencyOutput{
+			RankOrder:   f.RankOrder,
+			Rank:        f.Rank,
+			Hands:       f.Hands,
+			Probability: f.Probability(),
+			Classes:     f.Classes,
+			WantHands:   f.WantHands,
+			WantClasses: f.WantClasses,
+		})
+	}
+
+	return out
+}
+
+// csvHeader implements report.
+func (o verifyOutput) csvHeader() []string {
+	return []string{"rank_order", "rank", "hands", "probability", "classes", "want_hands", "want_classes"}
+}
+
+// csvRecords implements report with one record per category.
+func (o verifyOutput) csvRecords() [][]string {
+	records := make([][]string, len(o.Frequencies))
+	for i, f := range o.Frequencies {
+		records[i] = []string{
+			strconv.Itoa(f.RankOrder), f.Rank, strconv.Itoa(f.Hands), formatFloat(f.Probability), strconv.Itoa(f.Classes),
+			strconv.Itoa(f.WantHands), strconv.Itoa(f.WantClasses),
+		}
+	}
+
+	return records
+}
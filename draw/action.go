@@ -0,0 +1,43 @@
+// Package draw provides a Fixed-Limit Five-Card Draw table engine that deals hands between strategies.
+package draw
+
+import (
+	"fmt"
+
+	"github.com/YoungsoonLee/poker/table"
+)
+
+// Round represents a betting round of a draw hand.
+type Round int
+
+const (
+	BeforeDraw Round = iota
+	AfterDraw
+	Showdown
+)
+
+// String returns the name of the round.
+func (r Round) String() string {
+	switch r {
+	case BeforeDraw:
+		return "before the draw"
+	case AfterDraw:
+		return "after the draw"
+	case Showdown:
+		return "showdown"
+	default:
+		return fmt.Sprintf("round(%d)", int(r))
+	}
+}
+
+// ActionLog records an action taken during a hand.
+// Amount is the number of chips the player put into the pot with the action,
+// and Total is the player's total bet in the round after the action.
+type ActionLog struct {
+	Seat   int
+	Round  Round
+	Type   table.ActionType
+	Amount int
+	Total  int
+	AllIn  bool
+}
@@ -0,0 +1,439 @@
+package draw
+
+import (
+	"fmt"
+	"math/rand"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/YoungsoonLee/poker/internal/limit"
+	"github.com/YoungsoonLee/poker/poker"
+	"github.com/YoungsoonLee/poker/table"
+	"github.com/YoungsoonLee/poker/types"
+)
+
+// handSize is the number of cards dealt to each player.
+const handSize = 5
+
+// maxSeats is the number of players a 52 card deck can deal five cards to and still leave enough cards to draw,
+// reshuffling the discards when the stub runs out.
+const maxSeats = 8
+
+// Seat represents a player sitting at the table.
+// A seat with an empty stack sits out until it gets chips again.
+type Seat = limit.Seat[Strategy]
+
+// Stakes are the forced bets of a table.
+// The big blind is also the fixed size of a bet before the draw, and bets after the draw are twice as big.
+type Stakes struct {
+	Ante       int
+	SmallBlind int
+	BigBlind   int
+}
+
+// validate checks that the stakes can be played.
+func (s Stakes) validate() error {
+	if s.Ante < 0 || s.SmallBlind < 0 || s.BigBlind < 1 || s.SmallBlind > s.BigBlind {
+		return fmt.Errorf("invalid stakes: %d/%d ante %d", s.SmallBlind, s.BigBlind, s.Ante)
+	}
+
+	return nil
+}
+
+// Table deals hands of Fixed-Limit Five-Card Draw between the seats.
+// Button is the index of the seat on the dealer button. It moves to the next seat with chips after every hand.
+type Table struct {
+	Seats  []*Seat
+	Button int
+	Stakes Stakes
+
+	rng    *rand.Rand
+	handNo int
+}
+
+// New creates a new Table with the given seats and stakes. A table has at most 8 seats.
+// If rng is nil, a generator seeded with the current time is used for shuffling.
+func New(seats []*Seat, stakes Stakes, rng *rand.Rand) (*Table, error) {
+	if len(seats) < 2 || len(seats) > maxSeats {
+		return nil, fmt.Errorf("a draw table needs 2 to %d seats, got %d", maxSeats, len(seats))
+	}
+
+	if err := stakes.validate(); err != nil {
+		return nil, err
+	}
+
+	for i, s := range seats {
+		if s.Strategy == nil {
+			return nil, fmt.Errorf("seat %d (%s) has no strategy", i, s.Name)
+		}
+	}
+
+	if rng == nil {
+		rng = rand.New(rand.NewSource(time.Now().UnixNano()))
+	}
+
+	return &Table{Seats: seats, Stakes: stakes, rng: rng}, nil
+}
+
+// PlayerResult is the outcome of a hand for one seat.
+// Dealt are the five cards dealt to the player, Discarded the cards thrown away in the draw,
+// and Cards the five cards the player ended the hand with.
+type PlayerResult struct {
+	Seat       int
+	Name       string
+	StartStack int
+	EndStack   int
+	Dealt      []types.Card
+	Discarded  []types.Card
+	Cards      []types.Card
+	Folded     bool
+}
+
+// Net returns the number of chips the player won (positive) or lost (negative) in the hand.
+func (p PlayerResult) Net() int {
+	return p.EndStack - p.StartStack
+}
+
+// ShowdownResult is the hand shown by a seat at showdown, evaluated with Hand.Evaluate.
+type ShowdownResult struct {
+	Seat      int
+	Hand      poker.Hand
+	Rank      string
+	RankOrder int
+	Score     int
+}
+
+// Result records everything that happened in a hand.
+// Players only contains the seats that were dealt in.
+type Result struct {
+	HandNo   int
+	Button   int
+	Stakes   Stakes
+	Players  []PlayerResult
+	Actions  []ActionLog
+	Showdown []ShowdownResult
+	Pots     []table.Pot
+}
+
+// Player returns the result of the given seat, and false if the seat was not dealt in.
+func (r *Result) Player(seat int) (PlayerResult, bool) {
+	for _, p := range r.Players {
+		if p.Seat == seat {
+			return p, true
+		}
+	}
+
+	return PlayerResult{}, false
+}
+
+// ActiveSeats returns the number of seats that have chips.
+func (t *Table) ActiveSeats() int {
+	n := 0
+	for _, s := range t.Seats {
+		if s.Stack > 0 {
+			n++
+		}
+	}
+
+	return n
+}
+
+// HandNo returns the number of hands played at the table.
+func (t *Table) HandNo() int {
+	return t.handNo
+}
+
+// PlayHand deals and plays one hand, and updates the stacks of the seats.
+// It returns table.ErrNotEnoughPlayers if fewer than two seats have chips.
+func (t *Table) PlayHand() (*Result, error) {
+	if t.ActiveSeats() < 2 {
+		return nil, table.ErrNotEnoughPlayers
+	}
+
+	if err := t.Stakes.validate(); err != nil {
+		return nil, err
+	}
+
+	t.Button = t.nextActiveSeat(t.Button - 1)
+	t.handNo++
+
+	h := newHand(t)
+	h.play()
+
+	t.Button = t.nextActiveSeat(t.Button)
+
+	return h.result, nil
+}
+
+// nextActiveSeat returns the index of the first seat with chips after the given seat.
+func (t *Table) nextActiveSeat(seat int) int {
+	n := len(t.Seats)
+	for i := 1; i <= n; i++ {
+		next := ((seat+i)%n + n) % n
+		if t.Seats[next].Stack > 0 {
+			return next
+		}
+	}
+
+	return seat
+}
+
+// player is the state of a seat during a hand: its betting, its cards and its draw.
+// drawn is the number of cards the player drew, or -1 before the draw.
+type player struct {
+	*limit.Player[Strategy]
+	dealt     []types.Card
+	cards     []types.Card
+	discarded []types.Card
+	drawn     int
+}
+
+// hand is the state of a hand being played.
+// The players are in seat order, in the same order as the players of the betting, and button is the index of the button in players.
+// muck holds the discards that can be reshuffled into the stub when it runs out.
+type hand struct {
+	t       *Table
+	deck    *poker.Deck
+	muck    []types.Card
+	betting *limit.Betting[Strategy]
+	players []*player
+	button  int
+	round   Round
+	result  *Result
+}
+
+func newHand(t *Table) *hand {
+	h := &hand{
+		t:    t,
+		deck: poker.NewDeck(t.rng),
+		result: &Result{
+			HandNo: t.handNo,
+			Button: t.Button,
+			Stakes: t.Stakes,
+		},
+	}
+	h.betting = &limit.Betting[Strategy]{
+		BetSize: t.Stakes.BigBlind,
+		Decide:  func(i int) table.Action { return h.players[i].Strategy.Act(h.view(i)) },
+		Record:  h.record,
+	}
+
+	for i, s := range t.Seats {
+		if s.Stack <= 0 {
+			continue
+		}
+		if i == t.Button {
+			h.button = len(h.players)
+		}
+		p := &player{Player: &limit.Player[Strategy]{Seat: s, SeatIndex: i}, drawn: -1}
+		h.players = append(h.players, p)
+		h.betting.Players = append(h.betting.Players, p.Player)
+		h.result.Players = append(h.result.Players, PlayerResult{Seat: i, Name: s.Name, StartStack: s.Stack})
+	}
+
+	return h
+}
+
+// next returns the index of the player after i in seat order.
+func (h *hand) next(i int) int {
+	return h.betting.Next(i)
+}
+
+func (h *hand) play() {
+	h.deck.Shuffle()
+
+	// deal one card at a time starting left of the button
+	for c := 0; c < handSize; c++ {
+		for i := h.next(h.button); ; i = h.next(i) {
+			card, _ := h.deck.Deal(1)
+			h.players[i].cards = append(h.players[i].cards, card...)
+			if i == h.button {
+				break
+			}
+		}
+	}
+	for _, p := range h.players {
+		p.dealt = append([]types.Card(nil), p.cards...)
+	}
+
+	b := h.betting
+	h.postBlinds()
+	b.Round(h.next(h.bigBlindIndex()))
+
+	if b.InHand() > 1 {
+		h.draw()
+
+		h.round = AfterDraw
+		b.NextRound(h.betSize())
+		if b.AbleToAct() > 1 {
+			b.Round(h.next(h.button))
+		}
+	}
+
+	b.NextRound(0)
+	h.round = Showdown
+	h.awardPots()
+	h.finish()
+}
+
+// smallBlindIndex returns the index of the player posting the small blind.
+// Heads-up the button posts the small blind.
+func (h *hand) smallBlindIndex() int {
+	if len(h.players) == 2 {
+		return h.button
+	}
+
+	return h.next(h.button)
+}
+
+// bigBlindIndex returns the index of the player posting the big blind.
+func (h *hand) bigBlindIndex() int {
+	return h.next(h.smallBlindIndex())
+}
+
+// postBlinds posts the antes and the blinds. The big blind is the first bet before the draw.
+func (h *hand) postBlinds() {
+	if h.t.Stakes.Ante > 0 {
+		for i := h.next(h.button); ; i = h.next(i) {
+			h.betting.Put(i, table.PostAnte, h.t.Stakes.Ante)
+			if i == h.button {
+				break
+			}
+		}
+	}
+
+	h.betting.Put(h.smallBlindIndex(), table.PostSmallBlind, h.t.Stakes.SmallBlind)
+	h.betting.Put(h.bigBlindIndex(), table.PostBigBlind, h.t.Stakes.BigBlind)
+
+	h.betting.CurrentBet = h.t.Stakes.BigBlind
+	h.betting.Bets = 1
+}
+
+// draw asks every player still in the hand, starting left of the button, for their discards,
+// and replaces them with cards from the stub. When the stub runs out, the earlier discards are reshuffled into it.
+func (h *hand) draw() {
+	for i := h.next(h.button); ; i = h.next(i) {
+		p := h.players[i]
+		if !p.Folded {
+			discards := validDiscards(p.Strategy.Discard(h.view(i)), len(p.cards))
+
+			if h.deck.Len() < len(discards) {
+				h.deck.Cards = append(h.deck.Cards, h.muck...)
+				h.muck = nil
+				h.deck.Shuffle()
+			}
+
+			drawn, _ := h.deck.Deal(len(discards))
+			for k, index := range discards {
+				p.discarded = append(p.discarded, p.cards[index])
+				p.cards[index] = drawn[k]
+			}
+			p.drawn = len(discards)
+			h.muck = append(h.muck, p.discarded...)
+		}
+		if i == h.button {
+			break
+		}
+	}
+}
+
+// validDiscards returns the valid indexes of the discards in ascending order, without repeats.
+func validDiscards(discards []int, n int) []int {
+	var valid []int
+	for _, index := range discards {
+		if index >= 0 && index < n && !slices.Contains(valid, index) {
+			valid = append(valid, index)
+		}
+	}
+	sort.Ints(valid)
+
+	return valid
+}
+
+// betSize returns the fixed size of a bet in the current round.
+func (h *hand) betSize() int {
+	if h.round == BeforeDraw {
+		return h.t.Stakes.BigBlind
+	}
+
+	return 2 * h.t.Stakes.BigBlind
+}
+
+// record logs an action of the betting in the current round.
+func (h *hand) record(p *limit.Player[Strategy], actionType table.ActionType, amount int) {
+	h.result.Actions = append(h.result.Actions, ActionLog{
+		Seat:   p.SeatIndex,
+		Round:  h.round,
+		Type:   actionType,
+		Amount: amount,
+		Total:  p.Bet,
+		AllIn:  p.AllIn,
+	})
+}
+
+// view returns what the player at index i can see.
+func (h *hand) view(i int) View {
+	p := h.players[i]
+
+	var opponents []Opponent
+	for j, other := range h.players {
+		if j != i {
+			opponents = append(opponents, Opponent{Seat: other.SeatIndex, Stack: other.Stack, Drawn: other.drawn, Folded: other.Folded})
+		}
+	}
+
+	return View{
+		Seat:      p.SeatIndex,
+		Round:     h.round,
+		Cards:     append([]types.Card(nil), p.cards...),
+		Opponents: opponents,
+		Pot:       h.betting.Pot(),
+		Bet:       p.Bet,
+		ToCall:    h.betting.CurrentBet - p.Bet,
+		BetSize:   h.betting.BetSize,
+		CanRaise:  h.betting.CanRaise(),
+		Stack:     p.Stack,
+		Players:   h.betting.InHand(),
+		Button:    i == h.button,
+	}
+}
+
+// awardPots decides the winners of each pot by the scores of the hands shown with Hand.Evaluate, and pays them.
+// Odd chips go to the winners closest to the left of the button.
+func (h *hand) awardPots() {
+	scores := make(map[int]int)
+	order := make([]int, 0, len(h.players))
+	for i := h.next(h.button); ; i = h.next(i) {
+		p := h.players[i]
+		order = append(order, p.SeatIndex)
+		if !p.Folded && h.betting.InHand() > 1 {
+			hand := poker.Hand{HandID: p.SeatIndex, Cards: p.cards}
+			rank, rankOrder := hand.Evaluate()
+			scores[p.SeatIndex] = hand.Score()
+			h.result.Showdown = append(h.result.Showdown, ShowdownResult{
+				Seat:      p.SeatIndex,
+				Hand:      hand,
+				Rank:      rank,
+				RankOrder: rankOrder,
+				Score:     scores[p.SeatIndex],
+			})
+		}
+		if i == h.button {
+			break
+		}
+	}
+
+	h.result.Pots = h.betting.Award(scores, order)
+}
+
+// finish fills in the final state of the players in the result.
+func (h *hand) finish() {
+	for k, p := range h.players {
+		h.result.Players[k].EndStack = p.Stack
+		h.result.Players[k].Dealt = p.dealt
+		h.result.Players[k].Discarded = p.discarded
+		h.result.Players[k].Cards = p.cards
+		h.result.Players[k].Folded = p.Folded
+	}
+}
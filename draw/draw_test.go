@@ -0,0 +1,248 @@
+package draw
+
+import (
+	"errors"
+	"math/rand"
+	"reflect"
+	"slices"
+	"testing"
+
+	"github.com/YoungsoonLee/poker/internal/limit"
+	"github.com/YoungsoonLee/poker/internal/pokertest"
+	"github.com/YoungsoonLee/poker/table"
+	"github.com/YoungsoonLee/poker/types"
+)
+
+// testStakes are the stakes of the test tables: an ante of 1 and 5/10 blinds, so 10/20 limits.
+var testStakes = Stakes{Ante: 1, SmallBlind: 5, BigBlind: 10}
+
+// newTestTable creates a table with one seat per stack, all using the given strategy.
+func newTestTable(t *testing.T, strategy Strategy, stacks ...int) *Table {
+	t.Helper()
+
+	tb, err := New(pokertest.Seats(strategy, stacks...), testStakes, rand.New(rand.NewSource(1)))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	return tb
+}
+
+// drawAll is a calling station that throws away every card.
+type drawAll struct{ CallingStation }
+
+func (drawAll) Discard(v View) []int {
+	return []int{0, 1, 2, 3, 4}
+}
+
+// folder folds to every bet.
+type folder struct{ CallingStation }
+
+func (folder) Act(v View) table.Action {
+	return table.Action{Type: table.Fold}
+}
+
+func TestNew(t *testing.T) {
+	seats := func(n int) []*Seat {
+		stacks := make([]int, n)
+		for i := range stacks {
+			stacks[i] = 100
+		}
+		return pokertest.Seats[Strategy](CallingStation{}, stacks...)
+	}
+
+	tests := []struct {
+		name    string
+		seats   []*Seat
+		stakes  Stakes
+		wantErr bool
+	}{
+		{name: "valid table", seats: seats(2), stakes: testStakes},
+		{name: "8 seats", seats: seats(8), stakes: testStakes},
+		{name: "one seat", seats: seats(1), stakes: testStakes, wantErr: true},
+		{name: "9 seats", seats: seats(9), stakes: testStakes, wantErr: true},
+		{name: "small blind bigger than the big blind", seats: seats(2), stakes: Stakes{SmallBlind: 20, BigBlind: 10}, wantErr: true},
+		{name: "no big blind", seats: seats(2), stakes: Stakes{Ante: 1}, wantErr: true},
+		{name: "seat without strategy", seats: []*Seat{{Name: "A", Stack: 100, Strategy: CallingStation{}}, {Name: "B", Stack: 100}}, stakes: testStakes, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := New(tt.seats, tt.stakes, nil)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("New() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestTable_PlayHand_FoldToBigBlind(t *testing.T) {
+	tb := newTestTable(t, folder{}, 100, 100, 100)
+
+	result, err := tb.PlayHand()
+	if err != nil {
+		t.Fatalf("Table.PlayHand() error = %v", err)
+	}
+
+	// the button is seat 0, so seat 1 posts the small blind and seat 2 wins the blind and the antes
+	want := map[int]int{0: -1, 1: -6, 2: 7}
+	for _, p := range result.Players {
+		if p.Net() != want[p.Seat] {
+			t.Errorf("seat %d net = %d, want %d", p.Seat, p.Net(), want[p.Seat])
+		}
+		if p.Discarded != nil {
+			t.Errorf("seat %d discarded %v before a draw", p.Seat, p.Discarded)
+		}
+	}
+	if len(result.Showdown) != 0 {
+		t.Errorf("Showdown = %v, want none", result.Showdown)
+	}
+	if tb.Button != 1 {
+		t.Errorf("Button = %d, want 1", tb.Button)
+	}
+}
+
+func TestTable_PlayHand_Showdown(t *testing.T) {
+	tb := newTestTable(t, CallingStation{}, 1000, 1000, 1000)
+
+	result, err := tb.PlayHand()
+	if err != nil {
+		t.Fatalf("Table.PlayHand() error = %v", err)
+	}
+
+	seen := make(map[types.Card]bool)
+	for _, p := range result.Players {
+		if len(p.Dealt) != 5 || len(p.Cards) != 5 {
+			t.Fatalf("seat %d has %d dealt and %d final cards, want 5 and 5", p.Seat, len(p.Dealt), len(p.Cards))
+		}
+		if want := SimpleDiscards(p.Dealt); len(p.Discarded) != len(want) {
+			t.Errorf("seat %d discarded %v, want %d cards", p.Seat, p.Discarded, len(want))
+		}
+		for _, c := range append(append([]types.Card(nil), p.Cards...), p.Discarded...) {
+			if seen[c] {
+				t.Errorf("%v was dealt twice", c)
+			}
+			seen[c] = true
+		}
+	}
+
+	// calling stations put in the ante and call the big blind, and the best score wins the pot
+	if len(result.Showdown) != 3 {
+		t.Fatalf("Showdown has %d hands, want 3", len(result.Showdown))
+	}
+	best := result.Showdown[0]
+	for _, s := range result.Showdown {
+		if s.Score < best.Score {
+			best = s
+		}
+	}
+	if winners := result.Pots[0].Winners; !slices.Contains(winners, best.Seat) {
+		t.Errorf("Winners = %v, want seat %d with %s", winners, best.Seat, best.Rank)
+	}
+	if want := 3 * (testStakes.Ante + testStakes.BigBlind); result.Pots[0].Amount != want {
+		t.Errorf("pot = %d, want %d", result.Pots[0].Amount, want)
+	}
+}
+
+func TestTable_PlayHand_Capped(t *testing.T) {
+	tb := newTestTable(t, Maniac{}, 1000, 1000)
+
+	result, err := tb.PlayHand()
+	if err != nil {
+		t.Fatalf("Table.PlayHand() error = %v", err)
+	}
+
+	// the big blind is the first bet before the draw, so both rounds end at four bets
+	perRound := map[Round]int{}
+	for _, a := range result.Actions {
+		if a.Type == table.Bet || a.Type == table.Raise {
+			perRound[a.Round]++
+		}
+	}
+	if want := map[Round]int{BeforeDraw: limit.MaxBets - 1, AfterDraw: limit.MaxBets}; !reflect.DeepEqual(perRound, want) {
+		t.Errorf("bets and raises per round = %v, want %v", perRound, want)
+	}
+
+	want := testStakes.Ante + limit.MaxBets*testStakes.BigBlind + limit.MaxBets*2*testStakes.BigBlind
+	if result.Pots[0].Amount != 2*want {
+		t.Errorf("pot = %d, want %d", result.Pots[0].Amount, 2*want)
+	}
+}
+
+func TestTable_PlayHand_ReshuffleDiscards(t *testing.T) {
+	tb := newTestTable(t, drawAll{}, 100, 100, 100, 100, 100, 100, 100, 100)
+
+	result, err := tb.PlayHand()
+	if err != nil {
+		t.Fatalf("Table.PlayHand() error = %v", err)
+	}
+
+	// 8 players use 40 cards, so the last players draw from the reshuffled discards
+	for _, p := range result.Players {
+		if len(p.Discarded) != 5 {
+			t.Fatalf("seat %d discarded %d cards, want 5", p.Seat, len(p.Discarded))
+		}
+	}
+	final := make(map[types.Card]int)
+	for _, p := range result.Players {
+		for _, c := range p.Cards {
+			final[c]++
+			if final[c] > 1 {
+				t.Errorf("%v is held by two players", c)
+			}
+		}
+	}
+}
+
+func TestTable_PlayHand_ChipsConserved(t *testing.T) {
+	rng := rand.New(rand.NewSource(2))
+	seats := []*Seat{
+		{Name: "A", Stack: 200, Strategy: &Random{rng: rng}},
+		{Name: "B", Stack: 50, Strategy: Maniac{}},
+		{Name: "C", Stack: 300, Strategy: CallingStation{}},
+		{Name: "D", Stack: 120, Strategy: &Random{rng: rng}},
+	}
+	tb, err := New(seats, testStakes, rng)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	for i := 0; i < 200; i++ {
+		result, err := tb.PlayHand()
+		if errors.Is(err, table.ErrNotEnoughPlayers) {
+			break
+		}
+		if err != nil {
+			t.Fatalf("Table.PlayHand() error = %v", err)
+		}
+
+		total := 0
+		for _, s := range seats {
+			if s.Stack < 0 {
+				t.Fatalf("hand %d: seat %s has %d chips", result.HandNo, s.Name, s.Stack)
+			}
+			total += s.Stack
+		}
+		if total != 670 {
+			t.Fatalf("hand %d: total chips = %d, want 670", result.HandNo, total)
+		}
+	}
+}
+
+func TestValidDiscards(t *testing.T) {
+	tests := []struct {
+		name     string
+		discards []int
+		want     []int
+	}{
+		{name: "stand pat"},
+		{name: "sorted", discards: []int{4, 0, 2}, want: []int{0, 2, 4}},
+		{name: "repeats and out of range", discards: []int{1, 1, -1, 5, 3}, want: []int{1, 3}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := validDiscards(tt.discards, 5); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("validDiscards() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
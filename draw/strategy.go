@@ -0,0 +1,182 @@
+package draw
+
+import (
+	"math/rand"
+	"sort"
+
+	"github.com/YoungsoonLee/poker/internal/limit"
+	"github.com/YoungsoonLee/poker/internal/registry"
+	"github.com/YoungsoonLee/poker/poker"
+	"github.com/YoungsoonLee/poker/table"
+	"github.com/YoungsoonLee/poker/types"
+)
+
+// View is what a player can see when it is their turn to act or draw.
+// Bet is the player's bet in the round, ToCall is the number of chips needed to call,
+// and BetSize is the fixed size of a bet or raise in the round. CanRaise is false once the betting is capped.
+type View struct {
+	Seat      int
+	Round     Round
+	Cards     []types.Card
+	Opponents []Opponent
+	Pot       int
+	Bet       int
+	ToCall    int
+	BetSize   int
+	CanRaise  bool
+	Stack     int
+	Players   int
+	Button    bool
+}
+
+// Opponent is what a player can see of another player dealt into the hand.
+// Drawn is the number of cards the opponent drew, or -1 before they draw.
+type Opponent struct {
+	Seat   int
+	Stack  int
+	Drawn  int
+	Folded bool
+}
+
+// Strategy decides the actions and the discards of a player.
+// The table corrects invalid actions, so a strategy may e.g. raise when the betting is capped to call.
+// A Bet or Raise always puts in one fixed-size bet, so the Amount of the action is ignored.
+// Discard returns the indexes of the cards in v.Cards to throw away, or none to stand pat.
+// Invalid and repeated indexes are ignored.
+type Strategy interface {
+	Act(v View) table.Action
+	Discard(v View) []int
+}
+
+// strategies maps the name of a built-in strategy to its constructor.
+var strategies = registry.Registry[Strategy]{
+	"calling": func(*rand.Rand) Strategy { return CallingStation{} },
+	"maniac":  func(*rand.Rand) Strategy { return Maniac{} },
+	"random":  func(rng *rand.Rand) Strategy { return &Random{rng: rng} },
+}
+
+// StrategyNames returns the sorted names of the built-in strategies.
+func StrategyNames() []string {
+	return strategies.Names()
+}
+
+// NewStrategy creates a built-in strategy by name.
+// The random number generator is used by strategies that make random decisions.
+// If rng is nil, a generator seeded with the current time is used.
+func NewStrategy(name string, rng *rand.Rand) (Strategy, error) {
+	return strategies.New(name, rng)
+}
+
+// CallingStation checks or calls every bet, never raises and draws with SimpleDiscards.
+type CallingStation struct{}
+
+// Act implements Strategy.
+func (CallingStation) Act(v View) table.Action {
+	return table.Action{Type: table.Call}
+}
+
+// Discard implements Strategy.
+func (CallingStation) Discard(v View) []int {
+	return SimpleDiscards(v.Cards)
+}
+
+// Maniac bets or raises on every decision and draws with SimpleDiscards.
+type Maniac struct{}
+
+// Act implements Strategy.
+func (Maniac) Act(v View) table.Action {
+	return table.Action{Type: table.Raise}
+}
+
+// Discard implements Strategy.
+func (Maniac) Discard(v View) []int {
+	return SimpleDiscards(v.Cards)
+}
+
+// Random chooses between folding, calling and raising at random, and draws with SimpleDiscards.
+type Random struct {
+	rng *rand.Rand
+}
+
+// Act implements Strategy.
+func (r *Random) Act(v View) table.Action {
+	return limit.RandomAction(r.rng)
+}
+
+// Discard implements Strategy.
+func (r *Random) Discard(v View) []int {
+	return SimpleDiscards(v.Cards)
+}
+
+// SimpleDiscards returns the indexes of the cards to throw away from five cards by a few common rules:
+// stand pat with a straight or better, keep pairs, three and four of a kind, draw one to four cards of a flush
+// or of a straight, and otherwise keep the highest card only.
+func SimpleDiscards(cards []types.Card) []int {
+	if len(cards) != 5 {
+		return nil
+	}
+
+	if _, rankOrder := (poker.Hand{Cards: cards}).Evaluate(); rankOrder <= 6 {
+		return nil
+	}
+
+	ranks := make(map[string]int)
+	suits := make(map[string]int)
+	for _, c := range cards {
+		ranks[c.Rank]++
+		suits[c.Suit]++
+	}
+
+	// keep every card that pairs another one
+	if len(ranks) < len(cards) {
+		return discardWhere(cards, func(c types.Card) bool { return ranks[c.Rank] == 1 })
+	}
+
+	for suit, n := range suits {
+		if n == len(cards)-1 {
+			return discardWhere(cards, func(c types.Card) bool { return c.Suit != suit })
+		}
+	}
+
+	// four cards in a row are an open-ended straight draw, so the card outside them is thrown away
+	values := make([]int, len(cards))
+	for i, c := range cards {
+		values[i] = types.RankMap[c.Rank]
+	}
+	sorted := append([]int(nil), values...)
+	sort.Ints(sorted)
+	for _, window := range [][]int{sorted[:4], sorted[1:]} {
+		if window[3]-window[0] == 3 {
+			odd := sorted[4]
+			if window[0] == sorted[1] {
+				odd = sorted[0]
+			}
+			for i, v := range values {
+				if v == odd {
+					return []int{i}
+				}
+			}
+		}
+	}
+
+	high := 0
+	for i, v := range values {
+		if v > values[high] {
+			high = i
+		}
+	}
+
+	return discardWhere(cards, func(c types.Card) bool { return c != cards[high] })
+}
+
+// discardWhere returns the indexes of the cards for which discard returns true.
+func discardWhere(cards []types.Card, discard func(types.Card) bool) []int {
+	var indexes []int
+	for i, c := range cards {
+		if discard(c) {
+			indexes = append(indexes, i)
+		}
+	}
+
+	return indexes
+}
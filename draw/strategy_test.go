@@ -0,0 +1,46 @@
+package draw
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/YoungsoonLee/poker/internal/pokertest"
+)
+
+func TestNewStrategy(t *testing.T) {
+	for _, name := range StrategyNames() {
+		if _, err := NewStrategy(name, nil); err != nil {
+			t.Errorf("NewStrategy(%s) error = %v", name, err)
+		}
+	}
+
+	if _, err := NewStrategy("tag", nil); err == nil {
+		t.Errorf("NewStrategy(tag) error = nil, want error")
+	}
+}
+
+func TestSimpleDiscards(t *testing.T) {
+	tests := []struct {
+		name  string
+		cards string
+		want  []int
+	}{
+		{name: "stand pat with a straight", cards: "9S TD JH QC KS"},
+		{name: "stand pat with a flush", cards: "2H 7H 9H JH KH"},
+		{name: "keep a pair", cards: "QS 3D QH 8C 5S", want: []int{1, 3, 4}},
+		{name: "keep two pair", cards: "QS 3D QH 3C 5S", want: []int{4}},
+		{name: "keep trips", cards: "7S 7D AH 7C 2S", want: []int{2, 4}},
+		{name: "draw to a flush", cards: "2H 7H 9S JH KH", want: []int{2}},
+		{name: "draw to an open-ended straight", cards: "5S 6D 7H 8C KS", want: []int{4}},
+		{name: "draw to a straight below the odd card", cards: "2S 9D TH JC QS", want: []int{0}},
+		{name: "keep the highest card", cards: "2S 9D 4H JC 6S", want: []int{0, 1, 2, 4}},
+		{name: "not five cards", cards: "2S 9D 4H"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SimpleDiscards(pokertest.Cards(t, tt.cards)); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("SimpleDiscards() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
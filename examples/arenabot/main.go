@@ -0,0 +1,107 @@
+// Command arenabot is a sample bot for the arena protocol of the arena package.
+// It connects to a dealer, says hello and answers every turn in the text format:
+// it raises strong hands, calls with medium hands and otherwise checks or folds.
+//
+//	go run ./examples/arenabot --addr=localhost:9000 --name=sample
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"log"
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/YoungsoonLee/poker/poker"
+	"github.com/YoungsoonLee/poker/table"
+	"github.com/YoungsoonLee/poker/types"
+)
+
+func main() {
+	addr := flag.String("addr", "localhost:9000", "Address of the dealer")
+	name := flag.String("name", "sample", "Name of the bot")
+	flag.Parse()
+
+	conn, err := net.Dial("tcp", *addr)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer conn.Close()
+
+	fmt.Fprintf(conn, "hello %s\n", *name)
+
+	sc := bufio.NewScanner(conn)
+	for sc.Scan() {
+		typ, values := parseLine(sc.Text())
+
+		switch typ {
+		case "welcome", "end", "error":
+			log.Println(sc.Text())
+		case "result":
+			log.Printf("Hand %s: net %s\n", values["hand"], values["net"])
+		case "turn":
+			fmt.Fprintln(conn, decide(values))
+		}
+	}
+	if err := sc.Err(); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// parseLine splits a line like "turn pot=30 board=AS,KD,QH" into its type and values.
+func parseLine(line string) (string, map[string]string) {
+	fields := strings.Fields(line)
+	if len(fields) == 0 {
+		return "", nil
+	}
+
+	values := make(map[string]string)
+	for _, f := range fields[1:] {
+		if k, v, ok := strings.Cut(f, "="); ok {
+			values[k] = v
+		}
+	}
+
+	return fields[0], values
+}
+
+// decide answers a turn with the strength of the hole cards before the flop, and of the best hand after it.
+func decide(turn map[string]string) string {
+	hole, _ := types.ParseCards(turn["hole"])
+	board, _ := types.ParseCards(turn["board"])
+	toCall, _ := strconv.Atoi(turn["to_call"])
+	minRaise, _ := strconv.Atoi(turn["min_raise"])
+
+	// strength is 2 for raising, 1 for calling and 0 for checking or folding
+	strength := 0
+	if len(board) == 0 {
+		switch chen := table.ChenScore(hole); {
+		case chen >= 10:
+			strength = 2
+		case chen >= 7:
+			strength = 1
+		}
+	} else if hand, err := poker.BestHand(0, append(hole, board...)); err == nil {
+		switch _, rankOrder := hand.Evaluate(); {
+		case rankOrder <= 8:
+			strength = 2
+		case rankOrder == 9:
+			strength = 1
+		}
+	}
+
+	switch {
+	case strength == 2 && toCall == 0:
+		return fmt.Sprintf("bet %d", minRaise)
+	case strength == 2:
+		return fmt.Sprintf("raise %d", minRaise)
+	case strength == 1 && toCall > 0:
+		return "call"
+	case toCall == 0:
+		return "check"
+	default:
+		return "fold"
+	}
+}
@@ -0,0 +1,371 @@
+// Package history records the hands played by the table and stud engines as line-delimited JSON.
+//
+// Every line is one Hand. The Version field holds the schema version the hand was written with,
+// so readers can detect hands written by a newer version of the schema.
+package history
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/YoungsoonLee/poker/poker"
+	"github.com/YoungsoonLee/poker/table"
+	"github.com/YoungsoonLee/poker/types"
+)
+
+// SchemaVersion is the version of the hand history schema written by this package.
+const SchemaVersion = 1
+
+// GameHoldem is the game of hands played by the table engine, No-Limit Texas Hold'em.
+const GameHoldem = "nlhe"
+
+// maxLineSize is the longest line the Reader accepts.
+const maxLineSize = 1 << 20
+
+// Hand is the record of a single hand.
+// ID is set by the caller to tell hands of different tables or games apart, and Time is when the hand was played, if known.
+// Site, Table and Currency are only set for hands imported from online sites, where amounts are in cents.
+// Warnings lists the inconsistencies found when the hand was imported.
+// Stud hands have no button or blinds, and use BringIn, SmallBet and BigBet for their stakes instead.
+// Cards are written in the two character form of types.Card.String, e.g. "AS".
+type Hand struct {
+	Version    int        `json:"version"`
+	Game       string     `json:"game"`
+	ID         string     `json:"id,omitempty"`
+	Site       string     `json:"site,omitempty"`
+	Table      string     `json:"table,omitempty"`
+	Currency   string     `json:"currency,omitempty"`
+	Time       *time.Time `json:"time,omitempty"`
+	HandNo     int        `json:"hand_no"`
+	Button     int        `json:"button"`
+	SmallBlind int        `json:"small_blind"`
+	BigBlind   int        `json:"big_blind"`
+	Ante       int        `json:"ante"`
+	BringIn    int        `json:"bring_in,omitempty"`
+	SmallBet   int        `json:"small_bet,omitempty"`
+	BigBet     int        `json:"big_bet,omitempty"`
+	Seats      []Seat     `json:"seats"`
+	Board      []string   `json:"board"`
+	Actions    []Action   `json:"actions"`
+	Showdown   []Showdown `json:"showdown,omitempty"`
+	Pots       []Pot      `json:"pots"`
+	Warnings   []string   `json:"warnings,omitempty"`
+}
+
+// Seat is a player dealt into the hand, with the stack before and after the hand.
+// In stud hands Hole holds the face down cards of the player and Up the face up cards.
+type Seat struct {
+	Seat       int      `json:"seat"`
+	Name       string   `json:"name"`
+	StartStack int      `json:"start_stack"`
+	EndStack   int      `json:"end_stack"`
+	Hole       []string `json:"hole"`
+	Up         []string `json:"up,omitempty"`
+	Folded     bool     `json:"folded"`
+}
+
+// Action is an action taken during the hand.
+// Street and Type are the names returned by table.Street.String and table.ActionType.String.
+type Action struct {
+	Seat   int    `json:"seat"`
+	Street string `json:"street"`
+	Type   string `json:"type"`
+	Amount int    `json:"amount"`
+	Total  int    `json:"total"`
+	AllIn  bool   `json:"all_in,omitempty"`
+}
+
+// Showdown is the best five card hand shown by a seat.
+type Showdown struct {
+	Seat      int      `json:"seat"`
+	Cards     []string `json:"cards"`
+	Rank      string   `json:"rank"`
+	RankOrder int      `json:"rank_order"`
+}
+
+// Pot is a main or side pot, the seats eligible to win it and the seats that won it.
+type Pot struct {
+	Amount   int   `json:"amount"`
+	Eligible []int `json:"eligible"`
+	Winners  []int `json:"winners"`
+}
+
+// FromResult converts the result of a hand played by the table engine into a Hand of the current schema.
+func FromResult(r *table.Result) Hand {
+	h := Hand{
+		Version:    SchemaVersion,
+		Game:       GameHoldem,
+		HandNo:     r.HandNo,
+		Button:     r.Button,
+		SmallBlind: r.SmallBlind,
+		BigBlind:   r.BigBlind,
+		Ante:       r.Ante,
+		Board:      cardStrings(r.Board),
+		Actions:    make([]Action, 0, len(r.Actions)),
+		Pots:       make([]Pot, 0, len(r.Pots)),
+	}
+
+	for _, p := range r.Players {
+		h.Seats = append(h.Seats, Seat{
+			Seat:       p.Seat,
+			Name:       p.Name,
+			StartStack: p.StartStack,
+			EndStack:   p.EndStack,
+			Hole:       cardStrings(p.Hole),
+			Folded:     p.Folded,
+		})
+	}
+
+	for _, a := range r.Actions {
+		h.Actions = append(h.Actions, Action{
+			Seat:   a.Seat,
+			Street: a.Street.String(),
+			Type:   a.Type.String(),
+			Amount: a.Amount,
+			Total:  a.Total,
+			AllIn:  a.AllIn,
+		})
+	}
+
+	for _, s := range r.Showdown {
+		h.Showdown = append(h.Showdown, Showdown{
+			Seat:      s.Seat,
+			Cards:     cardStrings(s.Hand.Cards),
+			Rank:      s.Rank,
+			RankOrder: s.RankOrder,
+		})
+	}
+
+	for _, p := range r.Pots {
+		h.Pots = append(h.Pots, Pot{
+			Amount:   p.Amount,
+			Eligible: append([]int{}, p.Eligible...),
+			Winners:  append([]int{}, p.Winners...),
+		})
+	}
+
+	return h
+}
+
+// Result converts the hand back into the result of the table engine.
+// The showdown hands are evaluated again, so their scores are filled in.
+// Stud hands are converted with StudResult instead.
+func (h Hand) Result() (*table.Result, error) {
+	if h.Game == GameStud || h.Game == GameRazz {
+		return nil, fmt.Errorf("hand #%s is a %s hand, not a hold'em hand", h.ID, h.Game)
+	}
+
+	r := &table.Result{
+		HandNo:     h.HandNo,
+		Button:     h.Button,
+		SmallBlind: h.SmallBlind,
+		BigBlind:   h.BigBlind,
+		Ante:       h.Ante,
+	}
+
+	var err error
+	if r.Board, err = parseCards(h.Board); err != nil {
+		return nil, fmt.Errorf("board: %w", err)
+	}
+
+	for _, s := range h.Seats {
+		hole, err := parseCards(s.Hole)
+		if err != nil {
+			return nil, fmt.Errorf("seat %d: %w", s.Seat, err)
+		}
+		r.Players = append(r.Players, table.PlayerResult{
+			Seat:       s.Seat,
+			Name:       s.Name,
+			StartStack: s.StartStack,
+			EndStack:   s.EndStack,
+			Hole:       hole,
+			Folded:     s.Folded,
+		})
+	}
+
+	for i, a := range h.Actions {
+		street, ok := parseStreet(a.Street)
+		if !ok {
+			return nil, fmt.Errorf("action %d: unknown street: %q", i+1, a.Street)
+		}
+		actionType, ok := parseActionType(a.Type)
+		if !ok {
+			return nil, fmt.Errorf("action %d: unknown action type: %q", i+1, a.Type)
+		}
+		r.Actions = append(r.Actions, table.ActionLog{
+			Seat:   a.Seat,
+			Street: street,
+			Type:   actionType,
+			Amount: a.Amount,
+			Total:  a.Total,
+			AllIn:  a.AllIn,
+		})
+	}
+
+	for _, s := range h.Showdown {
+		cards, err := parseCards(s.Cards)
+		if err != nil {
+			return nil, fmt.Errorf("showdown of seat %d: %w", s.Seat, err)
+		}
+		hand := poker.Hand{HandID: s.Seat, Cards: cards}
+		r.Showdown = append(r.Showdown, table.ShowdownResult{
+			Seat:      s.Seat,
+			Hand:      hand,
+			Rank:      s.Rank,
+			RankOrder: s.RankOrder,
+			Score:     hand.Score(),
+		})
+	}
+
+	for _, p := range h.Pots {
+		r.Pots = append(r.Pots, table.Pot{
+			Amount:   p.Amount,
+			Eligible: append([]int(nil), p.Eligible...),
+			Winners:  append([]int(nil), p.Winners...),
+		})
+	}
+
+	return r, nil
+}
+
+// Writer writes hands as line-delimited JSON.
+// It is safe for concurrent use, so the tables of a simulation can share a Writer.
+type Writer struct {
+	mu  sync.Mutex
+	enc *json.Encoder
+}
+
+// NewWriter creates a Writer writing to w.
+func NewWriter(w io.Writer) *Writer {
+	return &Writer{enc: json.NewEncoder(w)}
+}
+
+// Write writes the hand as a single line.
+// A hand without a version is written with the current SchemaVersion.
+func (w *Writer) Write(h Hand) error {
+	if h.Version == 0 {
+		h.Version = SchemaVersion
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	return w.enc.Encode(h)
+}
+
+// WriteResult converts the result of a hand with FromResult and writes it with the given id.
+func (w *Writer) WriteResult(id string, r *table.Result) error {
+	h := FromResult(r)
+	h.ID = id
+
+	return w.Write(h)
+}
+
+// Reader reads hands written by a Writer.
+type Reader struct {
+	scanner *bufio.Scanner
+	line    int
+}
+
+// NewReader creates a Reader reading from r.
+func NewReader(r io.Reader) *Reader {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxLineSize)
+
+	return &Reader{scanner: scanner}
+}
+
+// Read returns the next hand, skipping empty lines.
+// It returns io.EOF when there are no more hands, and an error with the line number
+// if a line is not a valid hand or was written with a newer schema version.
+func (r *Reader) Read() (Hand, error) {
+	for r.scanner.Scan() {
+		r.line++
+		if len(r.scanner.Bytes()) == 0 {
+			continue
+		}
+
+		var h Hand
+		if err := json.Unmarshal(r.scanner.Bytes(), &h); err != nil {
+			return Hand{}, fmt.Errorf("line %d: %w", r.line, err)
+		}
+		if h.Version < 1 || h.Version > SchemaVersion {
+			return Hand{}, fmt.Errorf("line %d: unsupported schema version: %d", r.line, h.Version)
+		}
+
+		return h, nil
+	}
+
+	if err := r.scanner.Err(); err != nil {
+		return Hand{}, fmt.Errorf("line %d: %w", r.line+1, err)
+	}
+
+	return Hand{}, io.EOF
+}
+
+// ReadAll reads every hand from r.
+func ReadAll(r io.Reader) ([]Hand, error) {
+	reader := NewReader(r)
+
+	var hands []Hand
+	for {
+		h, err := reader.Read()
+		if err == io.EOF {
+			return hands, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		hands = append(hands, h)
+	}
+}
+
+// cardStrings returns the two character form of the cards.
+func cardStrings(cards []types.Card) []string {
+	out := make([]string, 0, len(cards))
+	for _, c := range cards {
+		out = append(out, c.String())
+	}
+
+	return out
+}
+
+// parseCards parses cards in the two character form.
+func parseCards(cards []string) ([]types.Card, error) {
+	out := make([]types.Card, 0, len(cards))
+	for _, s := range cards {
+		c, err := types.ParseCard(s)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, c)
+	}
+
+	return out, nil
+}
+
+// parseStreet returns the street with the given name.
+func parseStreet(name string) (table.Street, bool) {
+	for s := table.Preflop; s <= table.Showdown; s++ {
+		if s.String() == name {
+			return s, true
+		}
+	}
+
+	return 0, false
+}
+
+// parseActionType returns the action type with the given name.
+func parseActionType(name string) (table.ActionType, bool) {
+	for a := table.Fold; a <= table.PostBringIn; a++ {
+		if a.String() == name {
+			return a, true
+		}
+	}
+
+	return 0, false
+}
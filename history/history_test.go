@@ -0,0 +1,205 @@
+package history
+
+import (
+	"bytes"
+	"io"
+	"math/rand"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/YoungsoonLee/poker/table"
+)
+
+// playHands plays n hands between the built-in bots and returns their results.
+func playHands(t *testing.T, n int) []*table.Result {
+	t.Helper()
+
+	rng := rand.New(rand.NewSource(7))
+	var seats []*table.Seat
+	for _, bot := range []string{"tag", "calling", "maniac"} {
+		strategy, err := table.NewStrategy(bot, rng)
+		if err != nil {
+			t.Fatalf("NewStrategy() error = %v", err)
+		}
+		seats = append(seats, &table.Seat{Name: bot, Stack: 1000, Strategy: strategy})
+	}
+
+	tb, err := table.New(seats, 5, 10, rng)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	tb.Ante = 1
+
+	var results []*table.Result
+	for i := 0; i < n; i++ {
+		for _, s := range tb.Seats {
+			s.Stack = 1000
+		}
+		result, err := tb.PlayHand()
+		if err != nil {
+			t.Fatalf("PlayHand() error = %v", err)
+		}
+		results = append(results, result)
+	}
+
+	return results
+}
+
+func TestWriter_RoundTrip(t *testing.T) {
+	results := playHands(t, 50)
+
+	var buf bytes.Buffer
+	w := NewWriter(&buf)
+	for _, r := range results {
+		if err := w.WriteResult("test", r); err != nil {
+			t.Fatalf("WriteResult() error = %v", err)
+		}
+	}
+
+	if lines := strings.Count(buf.String(), "\n"); lines != len(results) {
+		t.Fatalf("Write() wrote %v lines, want %v", lines, len(results))
+	}
+
+	hands, err := ReadAll(&buf)
+	if err != nil {
+		t.Fatalf("ReadAll() error = %v", err)
+	}
+	if len(hands) != len(results) {
+		t.Fatalf("ReadAll() = %v hands, want %v", len(hands), len(results))
+	}
+
+	showdowns := 0
+	for i, h := range hands {
+		if h.Version != SchemaVersion || h.Game != GameHoldem || h.ID != "test" {
+			t.Errorf("hand %d: version = %v, game = %v, id = %v", i, h.Version, h.Game, h.ID)
+		}
+
+		got, err := h.Result()
+		if err != nil {
+			t.Fatalf("Result() error = %v", err)
+		}
+		if !reflect.DeepEqual(got, results[i]) {
+			t.Errorf("hand %d: Result() = %+v, want %+v", i, got, results[i])
+		}
+		showdowns += len(h.Showdown)
+	}
+
+	if showdowns == 0 {
+		t.Errorf("no hand went to showdown, the test does not cover showdowns")
+	}
+}
+
+func TestFromResult_Cards(t *testing.T) {
+	r := playHands(t, 1)[0]
+	h := FromResult(r)
+
+	for i, p := range r.Players {
+		for j, c := range p.Hole {
+			if h.Seats[i].Hole[j] != c.String() {
+				t.Errorf("FromResult() hole card = %v, want %v", h.Seats[i].Hole[j], c.String())
+			}
+		}
+	}
+
+	if h.Board == nil || h.Actions == nil || h.Pots == nil {
+		t.Errorf("FromResult() board, actions and pots should never be null")
+	}
+}
+
+func TestReader_Read(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    int
+		wantErr string
+	}{
+		{
+			name:  "empty lines are skipped",
+			input: "\n{\"version\":1,\"game\":\"nlhe\",\"hand_no\":1}\n\n{\"version\":1,\"game\":\"nlhe\",\"hand_no\":2}\n",
+			want:  2,
+		},
+		{
+			name:    "invalid json",
+			input:   "{\"version\":1,\"game\":\"nlhe\"}\n{\"version\":1,",
+			wantErr: "line 2",
+		},
+		{
+			name:    "newer schema version",
+			input:   "{\"version\":2,\"game\":\"nlhe\"}\n",
+			wantErr: "line 1: unsupported schema version: 2",
+		},
+		{
+			name:    "missing schema version",
+			input:   "{\"game\":\"nlhe\"}\n",
+			wantErr: "unsupported schema version: 0",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hands, err := ReadAll(strings.NewReader(tt.input))
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("ReadAll() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ReadAll() error = %v", err)
+			}
+			if len(hands) != tt.want {
+				t.Errorf("ReadAll() = %v hands, want %v", len(hands), tt.want)
+			}
+		})
+	}
+
+	if _, err := NewReader(strings.NewReader("")).Read(); err != io.EOF {
+		t.Errorf("Read() error = %v, want io.EOF", err)
+	}
+}
+
+func TestHand_Result_Errors(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(h *Hand)
+	}{
+		{
+			name:   "invalid board card",
+			modify: func(h *Hand) { h.Board = []string{"XX"} },
+		},
+		{
+			name:   "invalid hole card",
+			modify: func(h *Hand) { h.Seats[0].Hole = []string{"AS", "1H"} },
+		},
+		{
+			name:   "unknown street",
+			modify: func(h *Hand) { h.Actions[0].Street = "fifth" },
+		},
+		{
+			name:   "unknown action type",
+			modify: func(h *Hand) { h.Actions[0].Type = "shove" },
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := FromResult(playHands(t, 1)[0])
+			tt.modify(&h)
+			if _, err := h.Result(); err == nil {
+				t.Errorf("Result() error = nil, want error")
+			}
+		})
+	}
+}
+
+func TestParseActionType(t *testing.T) {
+	// every named action type reads back, up to the first unnamed one after the last constant
+	for a := table.Fold; !strings.HasPrefix(a.String(), "action("); a++ {
+		if got, ok := parseActionType(a.String()); !ok || got != a {
+			t.Errorf("parseActionType(%q) = %v, %v, want %v, true", a.String(), got, ok, a)
+		}
+	}
+
+	if _, ok := parseActionType("straddle"); ok {
+		t.Errorf("parseActionType(straddle) ok = true, want false")
+	}
+}
@@ -0,0 +1,773 @@
+package history
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/YoungsoonLee/poker/poker"
+	"github.com/YoungsoonLee/poker/table"
+	"github.com/YoungsoonLee/poker/types"
+)
+
+// SitePokerStars is the site of hands imported from PokerStars text hand histories.
+const SitePokerStars = "PokerStars"
+
+var (
+	psHeader    = regexp.MustCompile(`^PokerStars (?:Zoom )?(?:Hand|Game) #(\d+):`)
+	psBlinds    = regexp.MustCompile(`\(([$€£]?[\d.,]+)/([$€£]?[\d.,]+)(?: ([A-Z]{3}))?\)`)
+	psTime      = regexp.MustCompile(`\d{4}/\d{2}/\d{2} \d{1,2}:\d{2}:\d{2}`)
+	psTable     = regexp.MustCompile(`^Table '([^']*)'.* Seat #(\d+) is the button`)
+	psSeat      = regexp.MustCompile(`^Seat (\d+): (.+) \(([$€£]?[\d.,]+) in chips[^)]*\)(.*)$`)
+	psStreet    = regexp.MustCompile(`^\*\*\* (FLOP|TURN|RIVER) \*\*\*.*\[([^\]]+)\]$`)
+	psDealt     = regexp.MustCompile(`^Dealt to (.+?) \[([^\]]+)\]$`)
+	psReturned  = regexp.MustCompile(`^Uncalled bet \(([$€£]?[\d.,]+)\) returned to (.+)$`)
+	psCollected = regexp.MustCompile(`^(.+) collected ([$€£]?[\d.,]+) from (pot|main pot|side pot(?:-\d+)?)$`)
+	psShows     = regexp.MustCompile(`^shows \[([^\]]+)\](?: \((.+)\))?`)
+	psShowed    = regexp.MustCompile(`^Seat (\d+): .* (?:showed|mucked) \[([^\]]+)\]`)
+	psBoard     = regexp.MustCompile(`^Board \[([^\]]+)\]`)
+	psTotalPot  = regexp.MustCompile(`^Total pot ([$€£]?[\d.,]+).*\| Rake ([$€£]?[\d.,]+)`)
+)
+
+// boardCardCount is the number of community cards dealt by the river.
+const boardCardCount = 5
+
+// psCurrencies maps the currency symbols of PokerStars to currency codes.
+var psCurrencies = map[string]string{"$": "USD", "€": "EUR", "£": "GBP"}
+
+// psIgnored are the player lines that do not change the hand.
+var psIgnored = []string{
+	"mucks hand", "doesn't show hand", "sits out", "is sitting out", "has timed out",
+	"is disconnected", "is connected", "has returned", "leaves the table", "joins the table",
+}
+
+// ParseError is a hand that could not be parsed.
+// Line is the line the problem was found on, Start the first line of the hand and ID the hand number, if known.
+type ParseError struct {
+	Line  int
+	Start int
+	ID    string
+	Err   error
+}
+
+func (e *ParseError) Error() string {
+	if e.ID != "" {
+		return fmt.Sprintf("line %d: hand #%s: %v", e.Line, e.ID, e.Err)
+	}
+
+	return fmt.Sprintf("line %d: %v", e.Line, e.Err)
+}
+
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// PokerStarsReader reads No-Limit Hold'em hands from PokerStars text hand histories and converts them into Hands.
+// Cash game amounts are converted to cents.
+// The showdowns are evaluated again, and when the text disagrees with the evaluator,
+// e.g. about the shown hand or the winner, the problem is added to the Warnings of the hand.
+type PokerStarsReader struct {
+	scanner *bufio.Scanner
+	line    int
+	text    string
+	pending bool
+}
+
+// NewPokerStarsReader creates a PokerStarsReader reading from r.
+func NewPokerStarsReader(r io.Reader) *PokerStarsReader {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxLineSize)
+
+	return &PokerStarsReader{scanner: scanner}
+}
+
+// Read returns the next hand. It returns io.EOF when there are no more hands.
+// If a hand cannot be parsed, a *ParseError with the line number is returned
+// and the next call continues with the following hand.
+func (r *PokerStarsReader) Read() (Hand, error) {
+	for {
+		text, ok := r.scan()
+		if !ok {
+			if err := r.scanner.Err(); err != nil {
+				return Hand{}, &ParseError{Line: r.line + 1, Err: err}
+			}
+			return Hand{}, io.EOF
+		}
+
+		if strings.TrimSpace(text) == "" {
+			continue
+		}
+
+		if !psHeader.MatchString(text) {
+			line := r.line
+			r.skipHand()
+			return Hand{}, &ParseError{Line: line, Start: line, Err: errors.New("line is not part of a PokerStars hand")}
+		}
+
+		lines := []psLine{{number: r.line, text: text}}
+		for {
+			text, ok := r.scan()
+			if !ok {
+				break
+			}
+			if psHeader.MatchString(text) {
+				r.pending = true
+				break
+			}
+			lines = append(lines, psLine{number: r.line, text: text})
+		}
+
+		if err := r.scanner.Err(); err != nil {
+			return Hand{}, &ParseError{Line: r.line + 1, Start: lines[0].number, Err: err}
+		}
+
+		return parsePokerStarsHand(lines)
+	}
+}
+
+// scan returns the next line, or the last line again if it was pushed back.
+func (r *PokerStarsReader) scan() (string, bool) {
+	if r.pending {
+		r.pending = false
+		return r.text, true
+	}
+
+	if !r.scanner.Scan() {
+		return "", false
+	}
+
+	r.line++
+	r.text = strings.TrimRight(r.scanner.Text(), "\r")
+	if r.line == 1 {
+		r.text = strings.TrimPrefix(r.text, "\ufeff")
+	}
+
+	return r.text, true
+}
+
+// skipHand skips the lines until the start of the next hand.
+func (r *PokerStarsReader) skipHand() {
+	for {
+		text, ok := r.scan()
+		if !ok {
+			return
+		}
+		if psHeader.MatchString(text) {
+			r.pending = true
+			return
+		}
+	}
+}
+
+// psLine is a line of a hand and its line number.
+type psLine struct {
+	number int
+	text   string
+}
+
+// psShow is a hand shown by a player and the description PokerStars gave it.
+type psShow struct {
+	line        int
+	description string
+}
+
+// psParser is the state of a hand being parsed.
+type psParser struct {
+	h         Hand
+	start     int
+	scale     float64
+	names     []string
+	seats     map[string]int
+	folded    map[int]bool
+	current   table.Street
+	bets      map[int]int
+	invested  []int
+	returned  []int
+	collected []int
+	pots      map[string]int
+	shows     map[int]psShow
+	dealing   bool
+	summary   bool
+	totalPot  int
+	rake      int
+	hasTotal  bool
+}
+
+// parsePokerStarsHand parses the lines of a single hand, starting with the header line.
+func parsePokerStarsHand(lines []psLine) (Hand, error) {
+	p := &psParser{
+		start:  lines[0].number,
+		scale:  1,
+		seats:  make(map[string]int),
+		folded: make(map[int]bool),
+		bets:   make(map[int]int),
+		pots:   make(map[string]int),
+		shows:  make(map[int]psShow),
+		h: Hand{
+			Version: SchemaVersion,
+			Game:    GameHoldem,
+			Site:    SitePokerStars,
+			Board:   []string{},
+			Actions: []Action{},
+			Pots:    []Pot{},
+		},
+	}
+
+	if err := p.header(lines[0].text); err != nil {
+		return Hand{}, p.errorf(lines[0].number, "%w", err)
+	}
+
+	for _, l := range lines[1:] {
+		text := strings.TrimSpace(l.text)
+		if text == "" {
+			continue
+		}
+
+		var err error
+		if p.summary {
+			err = p.summaryLine(l.number, text)
+		} else {
+			err = p.line(l.number, text)
+		}
+		if err != nil {
+			return Hand{}, p.errorf(l.number, "%w", err)
+		}
+	}
+
+	if err := p.finish(); err != nil {
+		return Hand{}, p.errorf(p.start, "%w", err)
+	}
+
+	return p.h, nil
+}
+
+// errorf returns a *ParseError for the hand.
+func (p *psParser) errorf(line int, format string, args ...interface{}) *ParseError {
+	return &ParseError{Line: line, Start: p.start, ID: p.h.ID, Err: fmt.Errorf(format, args...)}
+}
+
+// warnf adds a warning to the hand.
+func (p *psParser) warnf(line int, format string, args ...interface{}) {
+	p.h.Warnings = append(p.h.Warnings, fmt.Sprintf("line %d: ", line)+fmt.Sprintf(format, args...))
+}
+
+// header parses the first line of a hand with the hand number, the game, the blinds and the time.
+func (p *psParser) header(text string) error {
+	m := psHeader.FindStringSubmatch(text)
+	p.h.ID = m[1]
+
+	handNo, err := strconv.Atoi(m[1])
+	if err != nil {
+		return fmt.Errorf("invalid hand number: %s", m[1])
+	}
+	p.h.HandNo = handNo
+
+	if !strings.Contains(text, "Hold'em No Limit") {
+		return errors.New("unsupported game, only No Limit Hold'em can be imported")
+	}
+
+	blinds := psBlinds.FindAllStringSubmatch(text, -1)
+	if len(blinds) == 0 {
+		return errors.New("blinds not found")
+	}
+	b := blinds[len(blinds)-1]
+
+	if symbol, ok := psCurrencies[string([]rune(b[1])[0])]; ok {
+		p.scale = 100
+		p.h.Currency = symbol
+	}
+	if b[3] != "" {
+		p.h.Currency = b[3]
+	}
+
+	if p.h.SmallBlind, err = p.amount(b[1]); err != nil {
+		return err
+	}
+	if p.h.BigBlind, err = p.amount(b[2]); err != nil {
+		return err
+	}
+
+	if s := psTime.FindString(text); s != "" {
+		t, err := time.Parse("2006/01/02 15:04:05", s)
+		if err != nil {
+			return fmt.Errorf("invalid time: %s", s)
+		}
+		p.h.Time = &t
+	}
+
+	return nil
+}
+
+// amount parses an amount like "$1,000.50" or "1500".
+func (p *psParser) amount(s string) (int, error) {
+	s = strings.ReplaceAll(strings.TrimLeft(s, "$€£"), ",", "")
+
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil || f < 0 {
+		return 0, fmt.Errorf("invalid amount: %s", s)
+	}
+
+	return int(math.Round(f * p.scale)), nil
+}
+
+// line parses a line between the header and the summary.
+func (p *psParser) line(number int, text string) error {
+	if m := psTable.FindStringSubmatch(text); m != nil && p.h.Table == "" {
+		p.h.Table = m[1]
+		button, _ := strconv.Atoi(m[2])
+		p.h.Button = button - 1
+		return nil
+	}
+
+	if m := psSeat.FindStringSubmatch(text); m != nil && !p.dealing {
+		return p.seat(m)
+	}
+
+	if strings.HasPrefix(text, "***") {
+		return p.street(text)
+	}
+
+	if i, rest, ok := p.player(text); ok {
+		return p.action(number, i, rest)
+	}
+
+	if m := psDealt.FindStringSubmatch(text); m != nil {
+		i, ok := p.seats[m[1]]
+		if !ok {
+			return fmt.Errorf("cards dealt to unknown player: %s", m[1])
+		}
+		return p.hole(i, m[2])
+	}
+
+	if m := psReturned.FindStringSubmatch(text); m != nil {
+		i, ok := p.seats[m[2]]
+		if !ok {
+			return fmt.Errorf("bet returned to unknown player: %s", m[2])
+		}
+		amount, err := p.amount(m[1])
+		if err != nil {
+			return err
+		}
+		p.returned[i] += amount
+		return nil
+	}
+
+	if m := psCollected.FindStringSubmatch(text); m != nil {
+		i, ok := p.seats[m[1]]
+		if !ok {
+			return fmt.Errorf("pot collected by unknown player: %s", m[1])
+		}
+		amount, err := p.amount(m[2])
+		if err != nil {
+			return err
+		}
+		p.collect(i, amount, m[3])
+		return nil
+	}
+
+	// chat, players joining or leaving and other lines that do not change the hand
+	return nil
+}
+
+// seat adds a player dealt into the hand. Players sitting out are skipped.
+func (p *psParser) seat(m []string) error {
+	if strings.Contains(m[4], "sitting out") || strings.Contains(m[4], "out of hand") {
+		return nil
+	}
+
+	number, _ := strconv.Atoi(m[1])
+	stack, err := p.amount(m[3])
+	if err != nil {
+		return err
+	}
+
+	if _, ok := p.seats[m[2]]; ok {
+		return fmt.Errorf("player %s is seated twice", m[2])
+	}
+
+	p.seats[m[2]] = len(p.h.Seats)
+	p.names = append(p.names, m[2])
+	// the longest names are matched first, so a name that starts with another name is found
+	sort.Slice(p.names, func(i, j int) bool {
+		return len(p.names[i]) > len(p.names[j])
+	})
+
+	p.h.Seats = append(p.h.Seats, Seat{Seat: number - 1, Name: m[2], StartStack: stack, Hole: []string{}})
+	p.invested = append(p.invested, 0)
+	p.returned = append(p.returned, 0)
+	p.collected = append(p.collected, 0)
+
+	return nil
+}
+
+// street parses a line like "*** FLOP *** [2c 3d 4h]" that starts a new street.
+func (p *psParser) street(text string) error {
+	p.dealing = true
+
+	switch {
+	case text == "*** HOLE CARDS ***":
+		p.current = table.Preflop
+		return nil
+	case text == "*** SHOW DOWN ***":
+		return nil
+	case text == "*** SUMMARY ***":
+		p.summary = true
+		return nil
+	}
+
+	m := psStreet.FindStringSubmatch(text)
+	if m == nil {
+		return fmt.Errorf("unsupported line: %s", text)
+	}
+
+	cards, err := types.ParseCards(m[2])
+	if err != nil {
+		return err
+	}
+
+	want := map[string]int{"FLOP": 3, "TURN": 1, "RIVER": 1}[m[1]]
+	if len(cards) != want {
+		return fmt.Errorf("%s should deal %d cards, got %d", strings.ToLower(m[1]), want, len(cards))
+	}
+
+	p.current++
+	p.bets = make(map[int]int)
+	p.h.Board = append(p.h.Board, cardStrings(cards)...)
+
+	return nil
+}
+
+// player returns the player a line like "Alice: calls $1" starts with, and the rest of the line.
+func (p *psParser) player(text string) (int, string, bool) {
+	for _, name := range p.names {
+		if rest, ok := strings.CutPrefix(text, name+": "); ok {
+			return p.seats[name], rest, true
+		}
+	}
+
+	return 0, "", false
+}
+
+// action parses what a player did, e.g. "raises $2 to $3 and is all-in".
+func (p *psParser) action(number, i int, rest string) error {
+	rest, allIn := strings.CutSuffix(rest, " and is all-in")
+
+	if m := psShows.FindStringSubmatch(rest); m != nil {
+		p.shows[i] = psShow{line: number, description: m[2]}
+		return p.hole(i, m[1])
+	}
+
+	for _, ignored := range psIgnored {
+		if strings.HasPrefix(rest, ignored) {
+			return nil
+		}
+	}
+
+	a := Action{Seat: p.h.Seats[i].Seat, Street: p.current.String(), AllIn: allIn}
+	fields := strings.Fields(rest)
+
+	var err error
+	switch {
+	case strings.HasPrefix(rest, "folds"):
+		a.Type = table.Fold.String()
+		p.folded[i] = true
+	case rest == "checks":
+		a.Type = table.Check.String()
+	case len(fields) == 2 && fields[0] == "calls":
+		a.Type = table.Call.String()
+		a.Amount, err = p.amount(fields[1])
+	case len(fields) == 2 && fields[0] == "bets":
+		a.Type = table.Bet.String()
+		a.Amount, err = p.amount(fields[1])
+	case len(fields) == 4 && fields[0] == "raises" && fields[2] == "to":
+		a.Type = table.Raise.String()
+		var to int
+		to, err = p.amount(fields[3])
+		a.Amount = to - p.bets[i]
+	case strings.HasPrefix(rest, "posts the ante "):
+		a.Type = table.PostAnte.String()
+		a.Amount, err = p.amount(fields[len(fields)-1])
+		p.h.Ante = max(p.h.Ante, a.Amount)
+	case strings.HasPrefix(rest, "posts small blind "):
+		a.Type = table.PostSmallBlind.String()
+		a.Amount, err = p.amount(fields[len(fields)-1])
+	case strings.HasPrefix(rest, "posts big blind "):
+		a.Type = table.PostBigBlind.String()
+		a.Amount, err = p.amount(fields[len(fields)-1])
+	case strings.HasPrefix(rest, "posts small & big blinds "):
+		// the small blind part is dead money, only the big blind counts as a bet
+		a.Type = table.PostBigBlind.String()
+		a.Amount, err = p.amount(fields[len(fields)-1])
+		p.bets[i] -= a.Amount - p.h.BigBlind
+	default:
+		return fmt.Errorf("unknown action: %s", rest)
+	}
+	if err != nil {
+		return err
+	}
+
+	if a.Amount < 0 {
+		return fmt.Errorf("invalid action: %s", rest)
+	}
+
+	if a.Type != table.PostAnte.String() {
+		p.bets[i] += a.Amount
+		a.Total = p.bets[i]
+	}
+	p.invested[i] += a.Amount
+	p.h.Actions = append(p.h.Actions, a)
+
+	return nil
+}
+
+// hole sets the hole cards of a player.
+func (p *psParser) hole(i int, s string) error {
+	cards, err := types.ParseCards(s)
+	if err != nil {
+		return err
+	}
+	if len(cards) != 2 {
+		return fmt.Errorf("%s should have 2 hole cards, got %d", p.h.Seats[i].Name, len(cards))
+	}
+
+	p.h.Seats[i].Hole = cardStrings(cards)
+
+	return nil
+}
+
+// collect pays a pot to a player. Pots with the same name, e.g. a split pot, are merged.
+func (p *psParser) collect(i, amount int, name string) {
+	p.collected[i] += amount
+
+	k, ok := p.pots[name]
+	if !ok {
+		k = len(p.h.Pots)
+		p.pots[name] = k
+		p.h.Pots = append(p.h.Pots, Pot{Eligible: []int{}, Winners: []int{}})
+	}
+
+	pot := &p.h.Pots[k]
+	pot.Amount += amount
+	if !slices.Contains(pot.Winners, p.h.Seats[i].Seat) {
+		pot.Winners = append(pot.Winners, p.h.Seats[i].Seat)
+	}
+}
+
+// summaryLine parses a line of the summary, which has the total pot, the board and the cards shown or mucked.
+func (p *psParser) summaryLine(number int, text string) error {
+	if m := psTotalPot.FindStringSubmatch(text); m != nil {
+		var err error
+		if p.totalPot, err = p.amount(m[1]); err != nil {
+			return err
+		}
+		if p.rake, err = p.amount(m[2]); err != nil {
+			return err
+		}
+		p.hasTotal = true
+		return nil
+	}
+
+	if m := psBoard.FindStringSubmatch(text); m != nil {
+		cards, err := types.ParseCards(m[1])
+		if err != nil {
+			return err
+		}
+		if board := cardStrings(cards); strings.Join(board, " ") != strings.Join(p.h.Board, " ") {
+			p.warnf(number, "summary board %v differs from the dealt board %v", board, p.h.Board)
+		}
+		return nil
+	}
+
+	if m := psShowed.FindStringSubmatch(text); m != nil {
+		number, _ := strconv.Atoi(m[1])
+		for i, s := range p.h.Seats {
+			if s.Seat == number-1 {
+				return p.hole(i, m[2])
+			}
+		}
+		return fmt.Errorf("cards shown by unknown seat: %d", number)
+	}
+
+	return nil
+}
+
+// finish computes the end stacks, evaluates the showdown and checks the hand for inconsistencies.
+func (p *psParser) finish() error {
+	if p.h.Table == "" {
+		return errors.New("table line not found")
+	}
+	if len(p.h.Seats) < 2 {
+		return fmt.Errorf("a hand needs at least 2 players, got %d", len(p.h.Seats))
+	}
+
+	var live []int
+	for i := range p.h.Seats {
+		s := &p.h.Seats[i]
+		s.Folded = p.folded[i]
+		s.EndStack = s.StartStack - p.invested[i] + p.returned[i] + p.collected[i]
+		if s.EndStack < 0 {
+			p.warnf(p.start, "%s put in more chips than their stack", s.Name)
+		}
+		if !s.Folded {
+			live = append(live, s.Seat)
+		}
+	}
+
+	for k := range p.h.Pots {
+		p.h.Pots[k].Eligible = append(p.h.Pots[k].Eligible, live...)
+	}
+
+	p.checkCards()
+	p.showdown()
+
+	if p.hasTotal {
+		invested, collected := 0, 0
+		for i := range p.h.Seats {
+			invested += p.invested[i] - p.returned[i]
+			collected += p.collected[i]
+		}
+		if invested != p.totalPot {
+			p.warnf(p.start, "players put in %d chips but the total pot is %d", invested, p.totalPot)
+		}
+		if collected+p.rake != p.totalPot {
+			p.warnf(p.start, "players collected %d chips and the rake is %d but the total pot is %d", collected, p.rake, p.totalPot)
+		}
+	}
+
+	return nil
+}
+
+// checkCards warns about cards that appear more than once.
+func (p *psParser) checkCards() {
+	seen := make(map[string]bool)
+	cards := append([]string(nil), p.h.Board...)
+	for _, s := range p.h.Seats {
+		cards = append(cards, s.Hole...)
+	}
+
+	for _, c := range cards {
+		if seen[c] {
+			p.warnf(p.start, "card %s is dealt more than once", c)
+		}
+		seen[c] = true
+	}
+}
+
+// showdown evaluates the hands of the players who did not fold,
+// and warns if a shown hand or the winner of the main pot differs from the evaluator.
+func (p *psParser) showdown() {
+	live := 0
+	for _, s := range p.h.Seats {
+		if !s.Folded {
+			live++
+		}
+	}
+	if live < 2 || len(p.h.Board) != boardCardCount {
+		return
+	}
+
+	board, _ := parseCards(p.h.Board)
+	scores := make(map[int]int)
+
+	for i, s := range p.h.Seats {
+		if s.Folded || len(s.Hole) != 2 {
+			continue
+		}
+
+		hole, _ := parseCards(s.Hole)
+		best, _ := poker.BestHand(s.Seat, append(hole, board...))
+		rank, rankOrder := best.Evaluate()
+		scores[s.Seat] = best.Score()
+
+		p.h.Showdown = append(p.h.Showdown, Showdown{
+			Seat:      s.Seat,
+			Cards:     cardStrings(best.Cards),
+			Rank:      rank,
+			RankOrder: rankOrder,
+		})
+
+		if show, ok := p.shows[i]; ok && show.description != "" {
+			if claimed := psRankOrder(show.description); claimed != 0 && claimed != rankOrder {
+				p.warnf(show.line, "%s shows %s but the hand is %s", s.Name, show.description, rank)
+			}
+		}
+	}
+
+	// the main pot can only be checked when every player who did not fold showed their cards
+	if len(scores) != live || len(p.h.Pots) == 0 {
+		return
+	}
+
+	best := 0
+	for _, score := range scores {
+		if best == 0 || score < best {
+			best = score
+		}
+	}
+
+	var want []int
+	for _, s := range p.h.Seats {
+		if score, ok := scores[s.Seat]; ok && score == best {
+			want = append(want, s.Seat)
+		}
+	}
+
+	main := p.h.Pots[0]
+	if k, ok := p.pots["main pot"]; ok {
+		main = p.h.Pots[k]
+	}
+
+	got := append([]int(nil), main.Winners...)
+	sort.Ints(got)
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		p.warnf(p.start, "main pot was won by seats %v but the best hand is held by seats %v", seatNumbers(got), seatNumbers(want))
+	}
+}
+
+// psRankOrder returns the rank order of a hand described by PokerStars, e.g. "a pair of Kings", or 0 if it is unknown.
+func psRankOrder(description string) int {
+	d := strings.ToLower(description)
+
+	switch {
+	case strings.HasPrefix(d, "a royal flush"):
+		return 1
+	case strings.HasPrefix(d, "a straight flush"):
+		return 2
+	case strings.HasPrefix(d, "four of a kind"):
+		return 3
+	case strings.HasPrefix(d, "a full house"):
+		return 4
+	case strings.HasPrefix(d, "a flush"):
+		return 5
+	case strings.HasPrefix(d, "a straight"):
+		return 6
+	case strings.HasPrefix(d, "three of a kind"):
+		return 7
+	case strings.HasPrefix(d, "two pair"):
+		return 8
+	case strings.HasPrefix(d, "a pair"):
+		return 9
+	case strings.HasPrefix(d, "high card"):
+		return 10
+	default:
+		return 0
+	}
+}
+
+// seatNumbers converts seat indexes to the seat numbers PokerStars uses, which start at 1.
+func seatNumbers(seats []int) []int {
+	numbers := make([]int, len(seats))
+	for i, s := range seats {
+		numbers[i] = s + 1
+	}
+
+	return numbers
+}
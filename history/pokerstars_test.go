@@ -0,0 +1,199 @@
+package history
+
+import (
+	"errors"
+	"io"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// readPokerStars reads every hand of the text, and the errors of the hands that could not be parsed.
+func readPokerStars(t *testing.T, r io.Reader) ([]Hand, []*ParseError) {
+	t.Helper()
+
+	reader := NewPokerStarsReader(r)
+
+	var hands []Hand
+	var errs []*ParseError
+	for {
+		h, err := reader.Read()
+		if err == io.EOF {
+			return hands, errs
+		}
+
+		var parseErr *ParseError
+		if errors.As(err, &parseErr) {
+			errs = append(errs, parseErr)
+			continue
+		}
+		if err != nil {
+			t.Fatalf("Read() error = %v", err)
+		}
+		hands = append(hands, h)
+	}
+}
+
+func TestPokerStarsReader_Read(t *testing.T) {
+	f, err := os.Open("testdata/pokerstars.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	hands, errs := readPokerStars(t, f)
+
+	var ids []string
+	for _, h := range hands {
+		ids = append(ids, h.ID)
+	}
+	if want := []string{"200000001", "200000002", "200000004"}; !reflect.DeepEqual(ids, want) {
+		t.Fatalf("Read() hands = %v, want %v", ids, want)
+	}
+
+	var lines []int
+	for _, e := range errs {
+		lines = append(lines, e.Line)
+	}
+	if want := []int{77, 116}; !reflect.DeepEqual(lines, want) {
+		t.Errorf("Read() error lines = %v, want %v (%v)", lines, want, errs)
+	}
+
+	t.Run("cash game", func(t *testing.T) {
+		h := hands[0]
+		if h.Site != SitePokerStars || h.Table != "Alpha II" || h.Currency != "USD" || h.Time == nil {
+			t.Errorf("site = %v, table = %v, currency = %v, time = %v", h.Site, h.Table, h.Currency, h.Time)
+		}
+		if h.SmallBlind != 1 || h.BigBlind != 2 || h.Button != 0 {
+			t.Errorf("blinds = %v/%v, button = %v, want 1/2 and 0", h.SmallBlind, h.BigBlind, h.Button)
+		}
+		if len(h.Seats) != 3 {
+			t.Fatalf("seats = %v, want 3 without the player sitting out", len(h.Seats))
+		}
+
+		var stacks []int
+		for _, s := range h.Seats {
+			stacks = append(stacks, s.EndStack)
+		}
+		if want := []int{126, 149, 371}; !reflect.DeepEqual(stacks, want) {
+			t.Errorf("end stacks = %v, want %v", stacks, want)
+		}
+
+		if !reflect.DeepEqual(h.Seats[0].Hole, []string{"AH", "KD"}) || !h.Seats[0].Folded {
+			t.Errorf("Alice = %+v, want AH KD and folded", h.Seats[0])
+		}
+		if want := []string{"AC", "7D", "2S", "9H", "3C"}; !reflect.DeepEqual(h.Board, want) {
+			t.Errorf("board = %v, want %v", h.Board, want)
+		}
+
+		raise := h.Actions[10]
+		if raise.Street != "turn" || raise.Type != "raise" || raise.Amount != 60 || raise.Total != 60 {
+			t.Errorf("turn raise = %+v, want 60 to 60", raise)
+		}
+		if len(h.Showdown) != 0 || len(h.Warnings) != 0 {
+			t.Errorf("showdown = %v, warnings = %v, want none", h.Showdown, h.Warnings)
+		}
+	})
+
+	t.Run("tournament with showdown", func(t *testing.T) {
+		h := hands[1]
+		if h.Currency != "" || h.SmallBlind != 15 || h.BigBlind != 30 || h.Ante != 5 {
+			t.Errorf("currency = %q, blinds = %v/%v ante %v", h.Currency, h.SmallBlind, h.BigBlind, h.Ante)
+		}
+		if !reflect.DeepEqual(h.Seats[1].Hole, []string{"AC", "KC"}) || h.Seats[1].EndStack != 1620 {
+			t.Errorf("Bob = %+v, want AC KC and 1620 chips", h.Seats[1])
+		}
+		if len(h.Showdown) != 2 || h.Showdown[1].Rank != "One Pair" {
+			t.Errorf("showdown = %+v, want 2 hands", h.Showdown)
+		}
+		if want := []Pot{{Amount: 1620, Eligible: []int{0, 1}, Winners: []int{1}}}; !reflect.DeepEqual(h.Pots, want) {
+			t.Errorf("pots = %+v, want %+v", h.Pots, want)
+		}
+		if len(h.Warnings) != 0 {
+			t.Errorf("warnings = %v, want none", h.Warnings)
+		}
+		if _, err := h.Result(); err != nil {
+			t.Errorf("Result() error = %v", err)
+		}
+	})
+
+	t.Run("inconsistent showdown", func(t *testing.T) {
+		h := hands[2]
+		if len(h.Warnings) != 2 {
+			t.Fatalf("warnings = %v, want the wrong description and the wrong winner", h.Warnings)
+		}
+		if !strings.Contains(h.Warnings[0], "Three of a Kind") || !strings.Contains(h.Warnings[1], "main pot") {
+			t.Errorf("warnings = %v", h.Warnings)
+		}
+	})
+}
+
+func TestPokerStarsReader_Read_Errors(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		wantLine int
+		wantErr  string
+	}{
+		{
+			name:     "line outside a hand",
+			input:    "\ngarbage\nPokerStars Hand #1:  Hold'em No Limit ($0.01/$0.02 USD) - 2023/05/01 12:00:00 ET\n",
+			wantLine: 2,
+			wantErr:  "not part of a PokerStars hand",
+		},
+		{
+			name:     "missing table",
+			input:    "PokerStars Hand #1:  Hold'em No Limit ($0.01/$0.02 USD) - 2023/05/01 12:00:00 ET\nSeat 1: A ($1 in chips)\n",
+			wantLine: 1,
+			wantErr:  "table line not found",
+		},
+		{
+			name:     "unknown action",
+			input:    "PokerStars Hand #1:  Hold'em No Limit (10/20) - 2023/05/01 12:00:00 ET\nTable 'T' 2-max Seat #1 is the button\nSeat 1: A (100 in chips)\nSeat 2: B (100 in chips)\nA: straddles 40\n",
+			wantLine: 5,
+			wantErr:  "unknown action",
+		},
+		{
+			name:     "run it twice",
+			input:    "PokerStars Hand #1:  Hold'em No Limit (10/20) - 2023/05/01 12:00:00 ET\nTable 'T' 2-max Seat #1 is the button\nSeat 1: A (100 in chips)\nSeat 2: B (100 in chips)\n*** FIRST FLOP *** [2c 3c 4c]\n",
+			wantLine: 5,
+			wantErr:  "unsupported line",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, errs := readPokerStars(t, strings.NewReader(tt.input))
+			if len(errs) == 0 {
+				t.Fatalf("Read() error = nil, want %q", tt.wantErr)
+			}
+			if errs[0].Line != tt.wantLine || !strings.Contains(errs[0].Error(), tt.wantErr) {
+				t.Errorf("Read() error = %v, want line %d: %q", errs[0], tt.wantLine, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestPsRankOrder(t *testing.T) {
+	tests := []struct {
+		description string
+		want        int
+	}{
+		{description: "a Royal Flush", want: 1},
+		{description: "a straight flush, Five to Nine", want: 2},
+		{description: "a straight, Two to Six", want: 6},
+		{description: "a flush, Ace high", want: 5},
+		{description: "a full house, Kings full of Aces", want: 4},
+		{description: "two pair, Aces and Kings", want: 8},
+		{description: "a pair of Kings", want: 9},
+		{description: "high card Ace", want: 10},
+		{description: "something else", want: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.description, func(t *testing.T) {
+			if got := psRankOrder(tt.description); got != tt.want {
+				t.Errorf("psRankOrder() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
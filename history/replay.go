@@ -0,0 +1,242 @@
+package history
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/YoungsoonLee/poker/poker"
+	"github.com/YoungsoonLee/poker/table"
+)
+
+// Step is the state of a hand at one point of a replay.
+// The first step is the deal, then there is one step for every action and for every street dealt,
+// and the last step shows the pots being awarded. Pot holds every chip put in, including the bets of the current street.
+type Step struct {
+	Description string
+	Street      string
+	Board       []string
+	Pot         int
+	Players     []PlayerState
+}
+
+// PlayerState is the state of a player at a step of a replay.
+// Bet is the player's bet on the current street.
+// Best, Rank and RankOrder are the best five card hand of the player and its category from Hand.Evaluate,
+// and are only set once the flop is dealt and the hole cards are known.
+type PlayerState struct {
+	Seat      int
+	Name      string
+	Stack     int
+	Bet       int
+	Hole      []string
+	Folded    bool
+	AllIn     bool
+	Best      []string
+	Rank      string
+	RankOrder int
+}
+
+// boardCards is the number of board cards dealt by each street.
+var boardCards = map[string]int{
+	table.Preflop.String(): 0,
+	table.Flop.String():    3,
+	table.Turn.String():    4,
+	table.River.String():   5,
+}
+
+// Replay returns the steps of the hand in the order they happened. Only hold'em hands can be replayed.
+func Replay(h Hand) ([]Step, error) {
+	if h.Game == GameStud || h.Game == GameRazz {
+		return nil, fmt.Errorf("hand #%s is a %s hand, only hold'em hands can be replayed", h.ID, h.Game)
+	}
+	if len(h.Seats) == 0 {
+		return nil, fmt.Errorf("hand #%s has no seats", h.ID)
+	}
+
+	players := make([]PlayerState, len(h.Seats))
+	index := make(map[int]int)
+	for i, s := range h.Seats {
+		players[i] = PlayerState{Seat: s.Seat, Name: s.Name, Stack: s.StartStack, Hole: s.Hole}
+		index[s.Seat] = i
+	}
+
+	r := &replay{h: h, players: players, street: table.Preflop.String()}
+	r.add("Hole cards are dealt")
+
+	for k, a := range h.Actions {
+		i, ok := index[a.Seat]
+		if !ok {
+			return nil, fmt.Errorf("action %d: unknown seat: %d", k+1, a.Seat)
+		}
+		if _, ok := boardCards[a.Street]; !ok {
+			return nil, fmt.Errorf("action %d: unknown street: %q", k+1, a.Street)
+		}
+
+		if a.Street != r.street {
+			if err := r.deal(a.Street); err != nil {
+				return nil, err
+			}
+		}
+
+		p := &r.players[i]
+		p.Stack -= a.Amount
+		p.AllIn = p.AllIn || a.AllIn || p.Stack == 0 && a.Amount > 0
+		if a.Type != table.PostAnte.String() {
+			p.Bet = a.Total
+		}
+		if a.Type == table.Fold.String() {
+			p.Folded = true
+		}
+		r.pot += a.Amount
+
+		r.add(h.describe(p.Name, a))
+	}
+
+	// the remaining streets are dealt when the players are all-in
+	if len(h.Board) > boardCards[r.street] {
+		for _, street := range []table.Street{table.Flop, table.Turn, table.River} {
+			if boardCards[street.String()] > boardCards[r.street] && boardCards[street.String()] <= len(h.Board) {
+				if err := r.deal(street.String()); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+
+	if len(h.Showdown) > 0 {
+		r.street = table.Showdown.String()
+	}
+	for i, s := range h.Seats {
+		r.players[i].Stack = s.EndStack
+		r.players[i].Bet = 0
+	}
+	r.pot = 0
+
+	var won []string
+	for _, pot := range h.Pots {
+		var names []string
+		for _, w := range pot.Winners {
+			if i, ok := index[w]; ok {
+				names = append(names, h.Seats[i].Name)
+			}
+		}
+		won = append(won, fmt.Sprintf("%s wins %s", strings.Join(names, " and "), h.Amount(pot.Amount)))
+	}
+	r.add(strings.Join(won, ", "))
+
+	return r.steps, nil
+}
+
+// FindStreet returns the index of the first step on the given street, or -1 if the hand did not reach it.
+func FindStreet(steps []Step, street string) int {
+	for i, s := range steps {
+		if s.Street == street {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// Amount formats a number of chips of the hand. Amounts of hands played for money are in cents.
+func (h Hand) Amount(chips int) string {
+	if h.Currency == "" {
+		return fmt.Sprint(chips)
+	}
+
+	return fmt.Sprintf("%.2f %s", float64(chips)/100, h.Currency)
+}
+
+// describe returns a sentence describing the action, e.g. "Alice raises to 60".
+func (h Hand) describe(name string, a Action) string {
+	var s string
+	switch a.Type {
+	case table.Fold.String():
+		s = name + " folds"
+	case table.Check.String():
+		s = name + " checks"
+	case table.Call.String():
+		s = fmt.Sprintf("%s calls %s", name, h.Amount(a.Amount))
+	case table.Bet.String():
+		s = fmt.Sprintf("%s bets %s", name, h.Amount(a.Amount))
+	case table.Raise.String():
+		s = fmt.Sprintf("%s raises to %s", name, h.Amount(a.Total))
+	default:
+		s = fmt.Sprintf("%s posts %s %s", name, a.Type, h.Amount(a.Amount))
+	}
+
+	if a.AllIn {
+		s += " and is all-in"
+	}
+
+	return s
+}
+
+// replay is the state of a hand being replayed.
+type replay struct {
+	h       Hand
+	players []PlayerState
+	street  string
+	pot     int
+	steps   []Step
+}
+
+// deal starts a new street and adds a step showing the board.
+func (r *replay) deal(street string) error {
+	n := boardCards[street]
+	if n > len(r.h.Board) {
+		return fmt.Errorf("hand #%s: the board has %d cards, the %s needs %d", r.h.ID, len(r.h.Board), street, n)
+	}
+
+	r.street = street
+	for i := range r.players {
+		r.players[i].Bet = 0
+	}
+
+	r.add(fmt.Sprintf("%s %s", strings.ToUpper(street[:1])+street[1:], strings.Join(r.h.Board[:n], " ")))
+
+	return nil
+}
+
+// add adds a step with the current state and evaluates the best hand of every player.
+func (r *replay) add(description string) {
+	board := r.h.Board[:boardCards[r.street]]
+	if r.street == table.Showdown.String() {
+		board = r.h.Board
+	}
+
+	players := make([]PlayerState, len(r.players))
+	copy(players, r.players)
+	for i := range players {
+		players[i].Best, players[i].Rank, players[i].RankOrder = bestHand(players[i].Hole, board)
+	}
+
+	r.steps = append(r.steps, Step{
+		Description: description,
+		Street:      r.street,
+		Board:       append([]string{}, board...),
+		Pot:         r.pot,
+		Players:     players,
+	})
+}
+
+// bestHand returns the best five card hand made from the hole cards and the board, and its category.
+// It returns nothing if the hole cards are unknown or fewer than five cards are available.
+func bestHand(hole, board []string) ([]string, string, int) {
+	if len(hole) != 2 || len(hole)+len(board) < 5 {
+		return nil, "", 0
+	}
+
+	cards, err := parseCards(append(append([]string(nil), hole...), board...))
+	if err != nil {
+		return nil, "", 0
+	}
+
+	best, err := poker.BestHand(0, cards)
+	if err != nil {
+		return nil, "", 0
+	}
+	rank, rankOrder := best.Evaluate()
+
+	return cardStrings(best.Cards), rank, rankOrder
+}
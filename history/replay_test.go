@@ -0,0 +1,128 @@
+package history
+
+import (
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestReplay(t *testing.T) {
+	f, err := os.Open("testdata/pokerstars.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	hands, _ := readPokerStars(t, f)
+	steps, err := Replay(hands[1])
+	if err != nil {
+		t.Fatalf("Replay() error = %v", err)
+	}
+
+	// deal, 5 posts, raise, fold, call, 3 streets and the pots
+	if len(steps) != 13 {
+		for _, s := range steps {
+			t.Log(s.Street, s.Description)
+		}
+		t.Fatalf("Replay() = %v steps, want 13", len(steps))
+	}
+
+	tests := []struct {
+		name        string
+		step        int
+		description string
+		street      string
+		board       int
+		pot         int
+		stacks      []int
+	}{
+		{name: "deal", step: 0, description: "Hole cards are dealt", street: "preflop", stacks: []int{1500, 800, 2000}},
+		{name: "all-in", step: 6, description: "Bob raises to 795 and is all-in", street: "preflop", pot: 855, stacks: []int{1465, 0, 1980}},
+		{name: "call", step: 8, description: "Alice calls 765", street: "preflop", pot: 1620, stacks: []int{700, 0, 1980}},
+		{name: "flop", step: 9, description: "Flop 2C 7H KD", street: "flop", board: 3, pot: 1620, stacks: []int{700, 0, 1980}},
+		{name: "river", step: 11, description: "River 2C 7H KD 4S TD", street: "river", board: 5, pot: 1620, stacks: []int{700, 0, 1980}},
+		{name: "pots", step: 12, description: "Bob wins 1620", street: "showdown", board: 5, stacks: []int{700, 1620, 1980}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := steps[tt.step]
+			if s.Description != tt.description || s.Street != tt.street || len(s.Board) != tt.board || s.Pot != tt.pot {
+				t.Errorf("step = %q %v board %v pot %v, want %q %v board %v pot %v", s.Description, s.Street, len(s.Board), s.Pot, tt.description, tt.street, tt.board, tt.pot)
+			}
+
+			var stacks []int
+			for _, p := range s.Players {
+				stacks = append(stacks, p.Stack)
+			}
+			if !reflect.DeepEqual(stacks, tt.stacks) {
+				t.Errorf("stacks = %v, want %v", stacks, tt.stacks)
+			}
+		})
+	}
+
+	if got := steps[9].Players[0].Rank; got != "One Pair" {
+		t.Errorf("Alice on the flop = %v, want One Pair", got)
+	}
+	if got := steps[8].Players[0].Rank; got != "" {
+		t.Errorf("Alice preflop = %v, want no hand", got)
+	}
+	if !steps[12].Players[2].Folded || steps[12].Players[2].Rank != "" {
+		t.Errorf("Carol should have folded with unknown cards: %+v", steps[12].Players[2])
+	}
+
+	if got := FindStreet(steps, "turn"); got != 10 {
+		t.Errorf("FindStreet(turn) = %v, want 10", got)
+	}
+	if got := FindStreet(steps, "fifth"); got != -1 {
+		t.Errorf("FindStreet(fifth) = %v, want -1", got)
+	}
+}
+
+func TestReplay_Recorded(t *testing.T) {
+	for _, r := range playHands(t, 30) {
+		h := FromResult(r)
+		steps, err := Replay(h)
+		if err != nil {
+			t.Fatalf("Replay() error = %v", err)
+		}
+
+		last := steps[len(steps)-1]
+		for i, p := range last.Players {
+			if p.Stack != h.Seats[i].EndStack {
+				t.Errorf("hand %d: final stack of %s = %v, want %v", h.HandNo, p.Name, p.Stack, h.Seats[i].EndStack)
+			}
+		}
+
+		pot := 0
+		for _, pt := range h.Pots {
+			pot += pt.Amount
+		}
+		if before := steps[len(steps)-2]; before.Pot != pot {
+			t.Errorf("hand %d: pot before the award = %v, want %v", h.HandNo, before.Pot, pot)
+		}
+
+		if !strings.Contains(last.Description, "wins") {
+			t.Errorf("hand %d: last step = %q", h.HandNo, last.Description)
+		}
+	}
+}
+
+func TestHand_Amount(t *testing.T) {
+	tests := []struct {
+		name     string
+		currency string
+		chips    int
+		want     string
+	}{
+		{name: "chips", chips: 1500, want: "1500"},
+		{name: "cents", currency: "USD", chips: 149, want: "1.49 USD"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := (Hand{Currency: tt.currency}).Amount(tt.chips); got != tt.want {
+				t.Errorf("Amount() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,189 @@
+package history
+
+import (
+	"fmt"
+
+	"github.com/YoungsoonLee/poker/poker"
+	"github.com/YoungsoonLee/poker/stud"
+	"github.com/YoungsoonLee/poker/table"
+)
+
+// Games of the hands played by the stud engine, named like stud.Game.String.
+const (
+	GameStud = "stud"
+	GameRazz = "razz"
+)
+
+// FromStudResult converts the result of a hand played by the stud engine into a Hand of the current schema.
+// The Game is GameStud or GameRazz, the hole cards of a seat are its down cards and Up its up cards,
+// and the board is the community card dealt when the deck runs out on seventh street.
+func FromStudResult(r *stud.Result) Hand {
+	h := Hand{
+		Version:  SchemaVersion,
+		Game:     r.Game.String(),
+		HandNo:   r.HandNo,
+		Ante:     r.Stakes.Ante,
+		BringIn:  r.Stakes.BringIn,
+		SmallBet: r.Stakes.SmallBet,
+		BigBet:   r.Stakes.BigBet,
+		Board:    cardStrings(r.Community),
+		Actions:  make([]Action, 0, len(r.Actions)),
+		Pots:     make([]Pot, 0, len(r.Pots)),
+	}
+
+	for _, p := range r.Players {
+		h.Seats = append(h.Seats, Seat{
+			Seat:       p.Seat,
+			Name:       p.Name,
+			StartStack: p.StartStack,
+			EndStack:   p.EndStack,
+			Hole:       cardStrings(p.Down),
+			Up:         cardStrings(p.Up),
+			Folded:     p.Folded,
+		})
+	}
+
+	for _, a := range r.Actions {
+		h.Actions = append(h.Actions, Action{
+			Seat:   a.Seat,
+			Street: a.Street.String(),
+			Type:   a.Type.String(),
+			Amount: a.Amount,
+			Total:  a.Total,
+			AllIn:  a.AllIn,
+		})
+	}
+
+	for _, s := range r.Showdown {
+		_, rankOrder := s.Hand.Evaluate()
+		if r.Game == stud.Razz {
+			rankOrder = 0
+		}
+		h.Showdown = append(h.Showdown, Showdown{
+			Seat:      s.Seat,
+			Cards:     cardStrings(s.Hand.Cards),
+			Rank:      s.Rank,
+			RankOrder: rankOrder,
+		})
+	}
+
+	for _, p := range r.Pots {
+		h.Pots = append(h.Pots, Pot{
+			Amount:   p.Amount,
+			Eligible: append([]int{}, p.Eligible...),
+			Winners:  append([]int{}, p.Winners...),
+		})
+	}
+
+	return h
+}
+
+// StudResult converts a stud or Razz hand back into the result of the stud engine.
+// The bring-in seat is read from the bring-in action, and the showdown hands are scored again for the game.
+func (h Hand) StudResult() (*stud.Result, error) {
+	game, err := stud.ParseGame(h.Game)
+	if err != nil {
+		return nil, err
+	}
+
+	r := &stud.Result{
+		HandNo: h.HandNo,
+		Game:   game,
+		Stakes: stud.Stakes{Ante: h.Ante, BringIn: h.BringIn, SmallBet: h.SmallBet, BigBet: h.BigBet},
+	}
+
+	if len(h.Board) > 0 {
+		if r.Community, err = parseCards(h.Board); err != nil {
+			return nil, fmt.Errorf("board: %w", err)
+		}
+	}
+
+	for _, s := range h.Seats {
+		down, err := parseCards(s.Hole)
+		if err != nil {
+			return nil, fmt.Errorf("seat %d: %w", s.Seat, err)
+		}
+		up, err := parseCards(s.Up)
+		if err != nil {
+			return nil, fmt.Errorf("seat %d: %w", s.Seat, err)
+		}
+		r.Players = append(r.Players, stud.PlayerResult{
+			Seat:       s.Seat,
+			Name:       s.Name,
+			StartStack: s.StartStack,
+			EndStack:   s.EndStack,
+			Down:       down,
+			Up:         up,
+			Folded:     s.Folded,
+		})
+	}
+
+	for i, a := range h.Actions {
+		street, ok := parseStudStreet(a.Street)
+		if !ok {
+			return nil, fmt.Errorf("action %d: unknown street: %q", i+1, a.Street)
+		}
+		actionType, ok := parseActionType(a.Type)
+		if !ok {
+			return nil, fmt.Errorf("action %d: unknown action type: %q", i+1, a.Type)
+		}
+		if actionType == table.PostBringIn {
+			r.BringInSeat = a.Seat
+		}
+		r.Actions = append(r.Actions, stud.ActionLog{
+			Seat:   a.Seat,
+			Street: street,
+			Type:   actionType,
+			Amount: a.Amount,
+			Total:  a.Total,
+			AllIn:  a.AllIn,
+		})
+	}
+
+	for _, s := range h.Showdown {
+		cards, err := parseCards(s.Cards)
+		if err != nil {
+			return nil, fmt.Errorf("showdown of seat %d: %w", s.Seat, err)
+		}
+		hand := poker.Hand{HandID: s.Seat, Cards: cards}
+		score := hand.Score()
+		if game == stud.Razz {
+			score = hand.LowScore()
+		}
+		r.Showdown = append(r.Showdown, stud.ShowdownResult{
+			Seat:  s.Seat,
+			Hand:  hand,
+			Rank:  s.Rank,
+			Score: score,
+		})
+	}
+
+	for _, p := range h.Pots {
+		r.Pots = append(r.Pots, table.Pot{
+			Amount:   p.Amount,
+			Eligible: append([]int(nil), p.Eligible...),
+			Winners:  append([]int(nil), p.Winners...),
+		})
+	}
+
+	return r, nil
+}
+
+// WriteStudResult converts the result of a stud hand with FromStudResult and writes it with the given id.
+func (w *Writer) WriteStudResult(id string, r *stud.Result) error {
+	h := FromStudResult(r)
+	h.ID = id
+
+	return w.Write(h)
+}
+
+// parseStudStreet returns the stud street with the given name.
+func parseStudStreet(name string) (stud.Street, bool) {
+	for s := stud.ThirdStreet; s <= stud.Showdown; s++ {
+		if s.String() == name {
+			return s, true
+		}
+	}
+
+	return 0, false
+}
@@ -0,0 +1,140 @@
+package history
+
+import (
+	"bytes"
+	"math/rand"
+	"reflect"
+	"testing"
+
+	"github.com/YoungsoonLee/poker/stud"
+	"github.com/YoungsoonLee/poker/table"
+)
+
+// playStudHands plays n hands of the game between the built-in stud bots and returns their results.
+func playStudHands(t *testing.T, game stud.Game, n int) []*stud.Result {
+	t.Helper()
+
+	rng := rand.New(rand.NewSource(7))
+	var seats []*stud.Seat
+	for _, bot := range []string{"calling", "random", "maniac"} {
+		strategy, err := stud.NewStrategy(bot, rng)
+		if err != nil {
+			t.Fatalf("NewStrategy() error = %v", err)
+		}
+		seats = append(seats, &stud.Seat{Name: bot, Stack: 1000, Strategy: strategy})
+	}
+
+	tb, err := stud.New(game, seats, stud.Stakes{Ante: 1, BringIn: 2, SmallBet: 5, BigBet: 10}, rng)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	var results []*stud.Result
+	for i := 0; i < n; i++ {
+		for _, s := range tb.Seats {
+			s.Stack = 1000
+		}
+		result, err := tb.PlayHand()
+		if err != nil {
+			t.Fatalf("PlayHand() error = %v", err)
+		}
+		results = append(results, result)
+	}
+
+	return results
+}
+
+func TestWriter_StudRoundTrip(t *testing.T) {
+	tests := []struct {
+		game     stud.Game
+		wantGame string
+	}{
+		{game: stud.Stud, wantGame: GameStud},
+		{game: stud.Razz, wantGame: GameRazz},
+	}
+	for _, tt := range tests {
+		t.Run(tt.wantGame, func(t *testing.T) {
+			results := playStudHands(t, tt.game, 30)
+
+			var buf bytes.Buffer
+			w := NewWriter(&buf)
+			for _, r := range results {
+				if err := w.WriteStudResult("test", r); err != nil {
+					t.Fatalf("WriteStudResult() error = %v", err)
+				}
+			}
+
+			hands, err := ReadAll(&buf)
+			if err != nil {
+				t.Fatalf("ReadAll() error = %v", err)
+			}
+			if len(hands) != len(results) {
+				t.Fatalf("ReadAll() = %v hands, want %v", len(hands), len(results))
+			}
+
+			showdowns := 0
+			for i, h := range hands {
+				if h.Game != tt.wantGame || h.BigBet != 10 {
+					t.Errorf("hand %d: game = %v, big bet = %v, want %v, 10", i, h.Game, h.BigBet, tt.wantGame)
+				}
+				// the bring-in follows the antes of every seat
+				if bringIn := h.Actions[len(h.Seats)]; bringIn.Type != table.PostBringIn.String() {
+					t.Errorf("hand %d: action after the antes = %v, want the bring-in", i, bringIn.Type)
+				}
+
+				got, err := h.StudResult()
+				if err != nil {
+					t.Fatalf("StudResult() error = %v", err)
+				}
+				if !reflect.DeepEqual(got, results[i]) {
+					t.Errorf("hand %d: StudResult() = %+v, want %+v", i, got, results[i])
+				}
+				showdowns += len(h.Showdown)
+			}
+
+			if showdowns == 0 {
+				t.Errorf("no hand went to showdown, the test does not cover showdowns")
+			}
+		})
+	}
+}
+
+func TestHand_StudResult_Errors(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(h *Hand)
+	}{
+		{
+			name:   "hold'em game",
+			modify: func(h *Hand) { h.Game = GameHoldem },
+		},
+		{
+			name:   "invalid up card",
+			modify: func(h *Hand) { h.Seats[0].Up = []string{"1H"} },
+		},
+		{
+			name:   "hold'em street",
+			modify: func(h *Hand) { h.Actions[0].Street = table.Flop.String() },
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := FromStudResult(playStudHands(t, stud.Stud, 1)[0])
+			tt.modify(&h)
+			if _, err := h.StudResult(); err == nil {
+				t.Errorf("StudResult() error = nil, want error")
+			}
+		})
+	}
+}
+
+func TestHand_StudHandsAreNotHoldem(t *testing.T) {
+	h := FromStudResult(playStudHands(t, stud.Razz, 1)[0])
+
+	if _, err := h.Result(); err == nil {
+		t.Errorf("Result() error = nil, want error")
+	}
+	if _, err := Replay(h); err == nil {
+		t.Errorf("Replay() error = nil, want error")
+	}
+}
@@ -0,0 +1,118 @@
+// Package icm calculates tournament equity with the Independent Chip Model,
+// and push or fold decisions that combine it with the poker equity engine.
+package icm
+
+import "fmt"
+
+// maxPlayers is the maximum number of players supported by Equity.
+const maxPlayers = 32
+
+// maxFinishes is the most sets of players finishing in the paid places that Equity follows,
+// which takes about a second. The sets grow with the number of players and of paid places,
+// e.g. 20 players with 10 paid places are about 430000 sets, and 26 players with 8 paid places almost a million.
+const maxFinishes = 500000
+
+// Equity returns the Independent Chip Model equity of each player, in the same unit as the payouts.
+// The chance of a player finishing first is their share of the chips, and the remaining places
+// are decided the same way between the remaining players (the Malmuth-Harville model).
+// Payouts start with the winner. Players without chips finish last and split the payouts of the last places.
+// It returns an error if there are too many players with chips for the number of paid places, see maxFinishes.
+func Equity(stacks []int, payouts []float64) ([]float64, error) {
+	if len(stacks) == 0 || len(stacks) > maxPlayers {
+		return nil, fmt.Errorf("invalid number of players: %d. ICM supports 1 to %d players", len(stacks), maxPlayers)
+	}
+
+	var alive []int
+	total := 0
+	for i, s := range stacks {
+		if s < 0 {
+			return nil, fmt.Errorf("player %d: invalid stack: %d", i+1, s)
+		}
+		if s > 0 {
+			alive = append(alive, i)
+			total += s
+		}
+	}
+
+	if len(alive) == 0 {
+		return nil, fmt.Errorf("no player has chips")
+	}
+
+	for i, p := range payouts {
+		if p < 0 {
+			return nil, fmt.Errorf("place %d: invalid payout: %v", i+1, p)
+		}
+	}
+
+	payout := func(place int) float64 {
+		if place < len(payouts) {
+			return payouts[place]
+		}
+		return 0
+	}
+
+	equities := make([]float64, len(stacks))
+
+	// probs maps the set of players who took the first places, as a bit mask of the alive players,
+	// to the probability that exactly those players took them
+	paid := len(payouts)
+	for paid > 0 && payouts[paid-1] == 0 {
+		paid--
+	}
+	places := min(len(alive), paid)
+	if finishes(len(alive), places) > maxFinishes {
+		return nil, fmt.Errorf("%d players with chips and %d paid places are too many for ICM. pay fewer places", len(alive), places)
+	}
+
+	probs := map[uint32]float64{0: 1}
+	for place := 0; place < places; place++ {
+		next := make(map[uint32]float64)
+		for used, prob := range probs {
+			left := total
+			for k, i := range alive {
+				if used&(1<<k) != 0 {
+					left -= stacks[i]
+				}
+			}
+
+			for k, i := range alive {
+				if used&(1<<k) != 0 {
+					continue
+				}
+				p := prob * float64(stacks[i]) / float64(left)
+				equities[i] += p * payout(place)
+				next[used|1<<k] += p
+			}
+		}
+		probs = next
+	}
+
+	// players without chips split the places after the alive players
+	busted := len(stacks) - len(alive)
+	if busted > 0 {
+		share := 0.0
+		for place := len(alive); place < len(stacks); place++ {
+			share += payout(place)
+		}
+		for i, s := range stacks {
+			if s == 0 {
+				equities[i] = share / float64(busted)
+			}
+		}
+	}
+
+	return equities, nil
+}
+
+// finishes returns the number of sets of players that can take the first places before the last one,
+// which are the states Equity follows, stopping early once it is over maxFinishes.
+func finishes(players, places int) int {
+	total, sets := 0, 1
+	for k := 0; k < places && total <= maxFinishes; k++ {
+		// sets is players choose k
+		total += sets
+		sets = sets * (players - k) / (k + 1)
+	}
+
+	return total
+}
@@ -0,0 +1,102 @@
+package icm
+
+import (
+	"math"
+	"testing"
+)
+
+func TestEquity(t *testing.T) {
+	tests := []struct {
+		name    string
+		stacks  []int
+		payouts []float64
+		want    []float64
+		wantErr bool
+	}{
+		{
+			name:    "three players",
+			stacks:  []int{5000, 3000, 2000},
+			payouts: []float64{50, 30, 20},
+			want:    []float64{38.392857, 32.75, 28.857143},
+		},
+		{
+			name:    "equal stacks split equally",
+			stacks:  []int{1000, 1000, 1000, 1000},
+			payouts: []float64{65, 35},
+			want:    []float64{25, 25, 25, 25},
+		},
+		{
+			name:    "winner takes all is chip proportional",
+			stacks:  []int{3000, 1000},
+			payouts: []float64{100},
+			want:    []float64{75, 25},
+		},
+		{
+			name:    "more places paid than players",
+			stacks:  []int{1000, 1000},
+			payouts: []float64{50, 30, 20},
+			want:    []float64{40, 40},
+		},
+		{
+			name:    "busted player gets the last place",
+			stacks:  []int{2000, 1000, 0},
+			payouts: []float64{50, 30, 20},
+			want:    []float64{50*2.0/3 + 30*1.0/3, 50*1.0/3 + 30*2.0/3, 20},
+		},
+		{
+			name:    "32 players with 4 paid places",
+			stacks:  equalStacks(32),
+			payouts: []float64{40, 30, 20, 10},
+			want:    []float64{100.0 / 32},
+		},
+		{
+			name:    "unpaid places are not followed",
+			stacks:  equalStacks(26),
+			payouts: []float64{50, 30, 20, 0, 0, 0, 0, 0},
+			want:    []float64{100.0 / 26},
+		},
+		{
+			name:    "too many players and paid places",
+			stacks:  equalStacks(26),
+			payouts: []float64{30, 20, 15, 10, 8, 7, 5, 5},
+			wantErr: true,
+		},
+		{
+			name:    "negative stack",
+			stacks:  []int{1000, -1},
+			payouts: []float64{100},
+			wantErr: true,
+		},
+		{
+			name:    "nobody has chips",
+			stacks:  []int{0, 0},
+			payouts: []float64{100},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Equity(tt.stacks, tt.payouts)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Equity() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			for i := range tt.want {
+				if math.Abs(got[i]-tt.want[i]) > 1e-5 {
+					t.Errorf("Equity() = %v, want %v", got, tt.want)
+					break
+				}
+			}
+		})
+	}
+}
+
+// equalStacks returns n stacks of 1000 chips.
+func equalStacks(n int) []int {
+	stacks := make([]int, n)
+	for i := range stacks {
+		stacks[i] = 1000
+	}
+
+	return stacks
+}
@@ -0,0 +1,186 @@
+package icm
+
+import (
+	"fmt"
+	"math/rand"
+
+	"github.com/YoungsoonLee/poker/poker"
+	"github.com/YoungsoonLee/poker/types"
+)
+
+// defaultIterations is the number of run-outs used for the equity when Spot.Iterations is not set.
+const defaultIterations = 20000
+
+// Spot is a push or fold decision where everybody folded to the hero, and only the caller is left to act.
+// Stacks are the stacks before the antes and blinds, and Posted the blinds posted by each player (nil for none).
+// CallRange is the range the caller calls an all-in with.
+type Spot struct {
+	Stacks     []int
+	Payouts    []float64
+	Hero       int
+	Caller     int
+	Posted     []int
+	Ante       int
+	Hole       []types.Card
+	CallRange  poker.Range
+	Iterations int
+}
+
+// Decision is the value of pushing all-in and of folding in a Spot.
+// PushEV and FoldEV are the ICM equities of the hero, and PushChipEV and FoldChipEV the expected stacks of the hero.
+type Decision struct {
+	PushEV           float64
+	FoldEV           float64
+	PushChipEV       float64
+	FoldChipEV       float64
+	CallProbability  float64
+	EquityWhenCalled float64
+	Push             bool
+}
+
+// PushFold decides whether the hero should push all-in or fold in the spot.
+// The chance that the caller calls is the share of the call range left after removing the hero's cards,
+// and the equity when called is calculated against that range. If rng is nil, a generator seeded with the current time is used.
+func PushFold(spot Spot, rng *rand.Rand) (Decision, error) {
+	if err := spot.validate(); err != nil {
+		return Decision{}, err
+	}
+
+	iterations := spot.Iterations
+	if iterations < 1 {
+		iterations = defaultIterations
+	}
+
+	combos := spot.CallRange.Without(spot.Hole...)
+	// every combo of the 50 cards the hero does not hold is equally likely
+	callProbability := float64(len(combos)) / float64(50*49/2)
+
+	var called poker.Equity
+	if len(combos) > 0 {
+		var err error
+		called, err = poker.RangeEquity(spot.Hole, combos, nil, iterations, rng)
+		if err != nil {
+			return Decision{}, err
+		}
+	}
+	lose := 1 - called.Win - called.Tie
+
+	o := spot.outcomes()
+	icmOf := func(stacks []int) (float64, error) {
+		equities, err := Equity(stacks, spot.Payouts)
+		if err != nil {
+			return 0, err
+		}
+		return equities[spot.Hero], nil
+	}
+
+	fold, err := icmOf(o.fold)
+	if err != nil {
+		return Decision{}, err
+	}
+	steal, err := icmOf(o.steal)
+	if err != nil {
+		return Decision{}, err
+	}
+	win, err := icmOf(o.win)
+	if err != nil {
+		return Decision{}, err
+	}
+	tie, err := icmOf(o.tie)
+	if err != nil {
+		return Decision{}, err
+	}
+	loss, err := icmOf(o.lose)
+	if err != nil {
+		return Decision{}, err
+	}
+
+	h := spot.Hero
+	d := Decision{
+		FoldEV:           fold,
+		PushEV:           (1-callProbability)*steal + callProbability*(called.Win*win+called.Tie*tie+lose*loss),
+		FoldChipEV:       float64(o.fold[h]),
+		PushChipEV:       (1-callProbability)*float64(o.steal[h]) + callProbability*(called.Win*float64(o.win[h])+called.Tie*float64(o.tie[h])+lose*float64(o.lose[h])),
+		CallProbability:  callProbability,
+		EquityWhenCalled: called.Equity,
+	}
+	d.Push = d.PushEV > d.FoldEV
+
+	return d, nil
+}
+
+func (s Spot) validate() error {
+	n := len(s.Stacks)
+	if n < 2 {
+		return fmt.Errorf("need at least 2 players, got %d", n)
+	}
+
+	if s.Hero < 0 || s.Hero >= n || s.Caller < 0 || s.Caller >= n || s.Hero == s.Caller {
+		return fmt.Errorf("invalid hero %d or caller %d for %d players", s.Hero+1, s.Caller+1, n)
+	}
+
+	if s.Stacks[s.Hero] <= 0 || s.Stacks[s.Caller] <= 0 {
+		return fmt.Errorf("hero and caller need chips")
+	}
+
+	if s.Posted != nil && len(s.Posted) != n {
+		return fmt.Errorf("posted blinds should have one amount per player, got %d for %d players", len(s.Posted), n)
+	}
+
+	if s.Ante < 0 {
+		return fmt.Errorf("invalid ante: %d", s.Ante)
+	}
+
+	if len(s.Hole) != 2 || s.Hole[0] == s.Hole[1] {
+		return fmt.Errorf("hero should have 2 different hole cards, got %v", s.Hole)
+	}
+
+	return nil
+}
+
+// outcomes are the stacks of every player after each way the hand can end.
+type outcomes struct {
+	fold  []int
+	steal []int
+	win   []int
+	tie   []int
+	lose  []int
+}
+
+// outcomes returns the stacks after the hero folds, after the caller folds to the push,
+// and after the hero wins, ties or loses the all-in.
+func (s Spot) outcomes() outcomes {
+	n := len(s.Stacks)
+	base := make([]int, n)
+	pot := 0
+	posted := make([]int, n)
+
+	for i, stack := range s.Stacks {
+		ante := min(s.Ante, stack)
+		if s.Posted != nil {
+			posted[i] = max(0, min(s.Posted[i], stack-ante))
+		}
+		base[i] = stack - ante - posted[i]
+		pot += ante + posted[i]
+	}
+
+	h, c := s.Hero, s.Caller
+	with := func(hero, caller int) []int {
+		stacks := append([]int(nil), base...)
+		stacks[h], stacks[c] = hero, caller
+		return stacks
+	}
+
+	// chips the hero and the caller can still put in, including their blinds
+	liveHero, liveCaller := base[h]+posted[h], base[c]+posted[c]
+	effective := min(liveHero, liveCaller)
+	calledPot := pot - posted[h] - posted[c] + 2*effective
+
+	return outcomes{
+		fold:  with(base[h], base[c]+pot),
+		steal: with(base[h]+pot, base[c]),
+		win:   with(liveHero-effective+calledPot, liveCaller-effective),
+		tie:   with(liveHero-effective+calledPot/2, liveCaller-effective+calledPot-calledPot/2),
+		lose:  with(liveHero-effective, liveCaller-effective+calledPot),
+	}
+}
@@ -0,0 +1,105 @@
+package icm
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/YoungsoonLee/poker/poker"
+	"github.com/YoungsoonLee/poker/types"
+)
+
+func TestPushFold(t *testing.T) {
+	tests := []struct {
+		name      string
+		hole      string
+		callRange string
+		stacks    []int
+		wantPush  bool
+	}{
+		{
+			name:      "aces always push",
+			hole:      "AsAh",
+			callRange: "22+, A2+, K9+",
+			stacks:    []int{1500, 3000, 3000, 2500},
+			wantPush:  true,
+		},
+		{
+			name:      "seven two folds against a wide calling range on the bubble",
+			hole:      "7s2h",
+			callRange: "any",
+			stacks:    []int{3000, 3000, 3000, 1000},
+			wantPush:  false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hole, _ := types.ParseCards(tt.hole)
+			callRange, err := poker.ParseRange(tt.callRange)
+			if err != nil {
+				t.Fatalf("ParseRange() error = %v", err)
+			}
+
+			got, err := PushFold(Spot{
+				Stacks:     tt.stacks,
+				Payouts:    []float64{50, 30, 20},
+				Hero:       0,
+				Caller:     1,
+				Posted:     []int{100, 200, 0, 0},
+				Ante:       25,
+				Hole:       hole,
+				CallRange:  callRange,
+				Iterations: 5000,
+			}, rand.New(rand.NewSource(1)))
+			if err != nil {
+				t.Fatalf("PushFold() error = %v", err)
+			}
+
+			if got.Push != tt.wantPush {
+				t.Errorf("PushFold() push = %v (push EV %v, fold EV %v), want %v", got.Push, got.PushEV, got.FoldEV, tt.wantPush)
+			}
+			if got.CallProbability <= 0 || got.CallProbability > 1 {
+				t.Errorf("PushFold() call probability = %v", got.CallProbability)
+			}
+		})
+	}
+}
+
+func TestSpot_outcomes(t *testing.T) {
+	s := Spot{
+		Stacks: []int{1000, 3000, 500},
+		Hero:   0,
+		Caller: 1,
+		Posted: []int{50, 100, 0},
+		Ante:   10,
+	}
+
+	o := s.outcomes()
+	for name, stacks := range map[string][]int{"fold": o.fold, "steal": o.steal, "win": o.win, "tie": o.tie, "lose": o.lose} {
+		total := 0
+		for _, stack := range stacks {
+			total += stack
+		}
+		if total != 4500 {
+			t.Errorf("outcomes() %s stacks = %v, total %v, want %v", name, stacks, total, 4500)
+		}
+	}
+
+	if o.lose[0] != 0 {
+		t.Errorf("outcomes() hero stack after losing = %v, want %v", o.lose[0], 0)
+	}
+	// the hero doubles up the 990 chips left after the ante and wins the 3 antes
+	if want := 990 + 990 + 3*10; o.win[0] != want {
+		t.Errorf("outcomes() hero stack after winning = %v, want %v", o.win[0], want)
+	}
+}
+
+func TestPushFold_InvalidSpot(t *testing.T) {
+	hole, _ := types.ParseCards("AsAh")
+
+	if _, err := PushFold(Spot{Stacks: []int{1000, 1000}, Hero: 0, Caller: 0, Hole: hole}, nil); err == nil {
+		t.Errorf("PushFold() with hero as caller error = nil, want error")
+	}
+	if _, err := PushFold(Spot{Stacks: []int{1000, 1000}, Hero: 0, Caller: 1, Hole: hole[:1]}, nil); err == nil {
+		t.Errorf("PushFold() with one hole card error = nil, want error")
+	}
+}
@@ -0,0 +1,245 @@
+// Package limit holds the fixed-limit betting shared by the stud and draw tables: the seats, the betting state
+// of the players in a hand, and betting rounds where every bet and raise is one fixed-size bet, up to a cap.
+package limit
+
+import (
+	"math/rand"
+	"slices"
+
+	"github.com/YoungsoonLee/poker/table"
+)
+
+// MaxBets is the number of bets and raises allowed in a betting round: a bet and three raises.
+const MaxBets = 4
+
+// Seat represents a player sitting at a table with a strategy of type S.
+// A seat with an empty stack sits out until it gets chips again.
+type Seat[S any] struct {
+	Name     string
+	Stack    int
+	Strategy S
+}
+
+// Player is the betting state of a seat during a hand.
+// SeatIndex is the index of the seat at the table, Bet the chips put in during the current round
+// and Committed all the chips put in during the hand.
+type Player[S any] struct {
+	*Seat[S]
+	SeatIndex int
+	Bet       int
+	Committed int
+	Folded    bool
+	AllIn     bool
+}
+
+// CanAct reports whether the player can still make decisions in the hand.
+func (p *Player[S]) CanAct() bool {
+	return !p.Folded && !p.AllIn
+}
+
+// Betting is the betting of a hand between players in seat order.
+// BetSize is the fixed size of a bet in the current round, and Bets the number of bets and raises made in it.
+// Decide asks the strategy of the player at an index for an action, and Record logs every action after it is applied.
+type Betting[S any] struct {
+	Players    []*Player[S]
+	CurrentBet int
+	Bets       int
+	BetSize    int
+
+	Decide func(i int) table.Action
+	Record func(p *Player[S], actionType table.ActionType, amount int)
+}
+
+// Next returns the index of the player after i in seat order.
+func (b *Betting[S]) Next(i int) int {
+	return (i + 1) % len(b.Players)
+}
+
+// InHand returns the number of players who have not folded.
+func (b *Betting[S]) InHand() int {
+	n := 0
+	for _, p := range b.Players {
+		if !p.Folded {
+			n++
+		}
+	}
+
+	return n
+}
+
+// AbleToAct returns the number of players who have not folded and are not all-in.
+func (b *Betting[S]) AbleToAct() int {
+	n := 0
+	for _, p := range b.Players {
+		if p.CanAct() {
+			n++
+		}
+	}
+
+	return n
+}
+
+// Pot returns the number of chips put in by every player during the hand.
+func (b *Betting[S]) Pot() int {
+	pot := 0
+	for _, p := range b.Players {
+		pot += p.Committed
+	}
+
+	return pot
+}
+
+// CanRaise reports whether the betting of the round is not capped yet.
+func (b *Betting[S]) CanRaise() bool {
+	return b.Bets < MaxBets
+}
+
+// Put moves up to amount chips from the stack of the player at index i into the pot and records the action.
+// Antes are dead money and do not count as a bet in the round.
+func (b *Betting[S]) Put(i int, actionType table.ActionType, amount int) {
+	p := b.Players[i]
+	amount = min(amount, p.Stack)
+
+	p.Stack -= amount
+	if actionType != table.PostAnte {
+		p.Bet += amount
+	}
+	p.Committed += amount
+	if p.Stack == 0 {
+		p.AllIn = true
+	}
+
+	b.Record(p, actionType, amount)
+}
+
+// Round asks players to act starting from first until every player has matched the current bet or folded.
+// Players in acted already acted in the round, like the bring-in, and only act again if someone bets or raises.
+func (b *Betting[S]) Round(first int, acted ...int) {
+	needsToAct := make(map[int]bool)
+	for i, p := range b.Players {
+		if p.CanAct() && !slices.Contains(acted, i) {
+			needsToAct[i] = true
+		}
+	}
+
+	for i := first; len(needsToAct) > 0; i = b.Next(i) {
+		if b.InHand() < 2 {
+			return
+		}
+
+		if !needsToAct[i] {
+			continue
+		}
+		delete(needsToAct, i)
+
+		p := b.Players[i]
+		// the last player able to act does not need to act unless they are facing a bet
+		if b.AbleToAct() == 1 && p.Bet >= b.CurrentBet {
+			continue
+		}
+
+		if b.act(i) {
+			for j, other := range b.Players {
+				if j != i && other.CanAct() {
+					needsToAct[j] = true
+				}
+			}
+		}
+	}
+}
+
+// act asks the player at index i for an action and applies it.
+// Invalid actions are corrected to the closest valid action.
+// It returns true if the player bet or raised.
+func (b *Betting[S]) act(i int) bool {
+	p := b.Players[i]
+	toCall := b.CurrentBet - p.Bet
+
+	action := b.Decide(i)
+
+	switch action.Type {
+	case table.Bet, table.Raise:
+		if !b.CanRaise() {
+			break
+		}
+
+		// the first bet of a round completes a smaller forced bet, like the bring-in, to a full bet
+		raiseTo := b.CurrentBet + b.BetSize
+		if b.Bets == 0 && b.CurrentBet < b.BetSize {
+			raiseTo = b.BetSize
+		}
+		raiseTo = min(raiseTo, p.Bet+p.Stack)
+		if raiseTo <= b.CurrentBet {
+			break
+		}
+
+		actionType := table.Raise
+		if b.CurrentBet == 0 {
+			actionType = table.Bet
+		}
+
+		b.Bets++
+		b.CurrentBet = raiseTo
+		b.Put(i, actionType, raiseTo-p.Bet)
+		return true
+	case table.Check, table.Call:
+		if toCall > 0 && action.Type == table.Check {
+			p.Folded = true
+			b.Put(i, table.Fold, 0)
+			return false
+		}
+	default:
+		if toCall > 0 {
+			p.Folded = true
+			b.Put(i, table.Fold, 0)
+			return false
+		}
+	}
+
+	if toCall == 0 {
+		b.Put(i, table.Check, 0)
+	} else {
+		b.Put(i, table.Call, toCall)
+	}
+
+	return false
+}
+
+// NextRound resets the bets of the round before the next round, whose bets have the given size.
+func (b *Betting[S]) NextRound(betSize int) {
+	for _, p := range b.Players {
+		p.Bet = 0
+	}
+	b.CurrentBet = 0
+	b.Bets = 0
+	b.BetSize = betSize
+}
+
+// Award builds the pots from the chips the players put in, pays their winners by the scores of the seats
+// still in the hand, and returns the pots. Odd chips go to the winners in the order of the seats.
+func (b *Betting[S]) Award(scores map[int]int, order []int) []table.Pot {
+	contributions := make([]table.Contribution, len(b.Players))
+	for i, p := range b.Players {
+		contributions[i] = table.Contribution{Seat: p.SeatIndex, Committed: p.Committed, Folded: p.Folded}
+	}
+	pots := table.BuildPots(contributions)
+
+	won := table.Award(pots, scores, order)
+	for _, p := range b.Players {
+		p.Stack += won[p.SeatIndex]
+	}
+
+	return pots
+}
+
+// RandomAction folds, calls or raises at random, like the Random strategies of the games.
+func RandomAction(rng *rand.Rand) table.Action {
+	switch n := rng.Intn(10); {
+	case n < 2:
+		return table.Action{Type: table.Fold}
+	case n < 7:
+		return table.Action{Type: table.Call}
+	default:
+		return table.Action{Type: table.Raise}
+	}
+}
@@ -0,0 +1,153 @@
+package limit
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/YoungsoonLee/poker/table"
+)
+
+// logged is an action recorded by the betting of a test.
+type logged struct {
+	Seat   int
+	Type   table.ActionType
+	Amount int
+}
+
+// newTestBetting returns a betting round between players with the given stacks, where the player at index i
+// always takes actions[i], and the log of the recorded actions.
+func newTestBetting(betSize int, stacks []int, actions []table.ActionType) (*Betting[string], *[]logged) {
+	var log []logged
+	b := &Betting[string]{
+		BetSize: betSize,
+		Decide:  func(i int) table.Action { return table.Action{Type: actions[i]} },
+		Record: func(p *Player[string], actionType table.ActionType, amount int) {
+			log = append(log, logged{Seat: p.SeatIndex, Type: actionType, Amount: amount})
+		},
+	}
+	for i, stack := range stacks {
+		b.Players = append(b.Players, &Player[string]{Seat: &Seat[string]{Stack: stack}, SeatIndex: i})
+	}
+
+	return b, &log
+}
+
+func TestBetting_Round(t *testing.T) {
+	tests := []struct {
+		name       string
+		stacks     []int
+		actions    []table.ActionType
+		forced     int
+		want       []logged
+		wantStacks []int
+	}{
+		{
+			name:       "checks around",
+			stacks:     []int{100, 100},
+			actions:    []table.ActionType{table.Check, table.Check},
+			want:       []logged{{0, table.Check, 0}, {1, table.Check, 0}},
+			wantStacks: []int{100, 100},
+		},
+		{
+			name:    "capped after a bet and three raises",
+			stacks:  []int{100, 100},
+			actions: []table.ActionType{table.Raise, table.Raise},
+			want: []logged{
+				{0, table.Bet, 10}, {1, table.Raise, 20}, {0, table.Raise, 20}, {1, table.Raise, 20}, {0, table.Call, 10},
+			},
+			wantStacks: []int{60, 60},
+		},
+		{
+			name:       "check facing a bet folds",
+			stacks:     []int{100, 100},
+			actions:    []table.ActionType{table.Bet, table.Check},
+			want:       []logged{{0, table.Bet, 10}, {1, table.Fold, 0}},
+			wantStacks: []int{90, 100},
+		},
+		{
+			name:       "a forced bet is completed to a full bet",
+			stacks:     []int{100, 100},
+			actions:    []table.ActionType{table.Raise, table.Call},
+			forced:     3,
+			want:       []logged{{0, table.Raise, 10}, {1, table.Call, 10}},
+			wantStacks: []int{90, 90},
+		},
+		{
+			name:       "a short stack raises all-in",
+			stacks:     []int{100, 4},
+			actions:    []table.ActionType{table.Bet, table.Raise},
+			want:       []logged{{0, table.Bet, 10}, {1, table.Call, 4}},
+			wantStacks: []int{90, 0},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, log := newTestBetting(10, tt.stacks, tt.actions)
+			b.CurrentBet = tt.forced
+
+			b.Round(0)
+
+			if !reflect.DeepEqual(*log, tt.want) {
+				t.Errorf("Round() actions = %v, want %v", *log, tt.want)
+			}
+			for i, p := range b.Players {
+				if p.Stack != tt.wantStacks[i] {
+					t.Errorf("player %d stack = %d, want %d", i, p.Stack, tt.wantStacks[i])
+				}
+			}
+		})
+	}
+}
+
+func TestBetting_RoundActed(t *testing.T) {
+	b, log := newTestBetting(10, []int{100, 100, 100}, []table.ActionType{table.Call, table.Call, table.Call})
+	b.Put(0, table.PostBringIn, 3)
+	b.CurrentBet = 3
+
+	// the bring-in does not act again when nobody completes it
+	b.Round(1, 0)
+
+	want := []logged{{0, table.PostBringIn, 3}, {1, table.Call, 3}, {2, table.Call, 3}}
+	if !reflect.DeepEqual(*log, want) {
+		t.Errorf("Round() actions = %v, want %v", *log, want)
+	}
+}
+
+func TestBetting_Award(t *testing.T) {
+	b, _ := newTestBetting(10, []int{100, 100, 100}, nil)
+	for i, committed := range []int{50, 20, 50} {
+		b.Put(i, table.Bet, committed)
+	}
+	b.Players[2].Folded = true
+
+	// the short stack wins the main pot with the lowest score, and the side pot goes to the other player still in
+	pots := b.Award(map[int]int{0: 200, 1: 100}, []int{0, 1, 2})
+
+	if len(pots) != 2 {
+		t.Fatalf("Award() = %d pots, want 2", len(pots))
+	}
+	for i, want := range []int{110, 140, 50} {
+		if got := b.Players[i].Stack; got != want {
+			t.Errorf("player %d stack = %d, want %d", i, got, want)
+		}
+	}
+}
+
+func TestBetting_NextRound(t *testing.T) {
+	b, _ := newTestBetting(10, []int{100, 100}, []table.ActionType{table.Raise, table.Raise})
+	b.Round(0)
+
+	b.NextRound(20)
+
+	if b.CurrentBet != 0 || b.Bets != 0 || b.BetSize != 20 || !b.CanRaise() {
+		t.Errorf("NextRound() = current bet %d, %d bets, bet size %d, want 0, 0, 20", b.CurrentBet, b.Bets, b.BetSize)
+	}
+	for i, p := range b.Players {
+		if p.Bet != 0 {
+			t.Errorf("player %d bet = %d, want 0", i, p.Bet)
+		}
+	}
+	if got := b.Pot(); got != 80 {
+		t.Errorf("Pot() = %d, want 80", got)
+	}
+}
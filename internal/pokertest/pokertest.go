@@ -0,0 +1,31 @@
+// Package pokertest holds the test fixtures shared by the tests of the game packages.
+package pokertest
+
+import (
+	"testing"
+
+	"github.com/YoungsoonLee/poker/internal/limit"
+	"github.com/YoungsoonLee/poker/types"
+)
+
+// Cards parses cards like "AhKh" for a test, and fails the test if they are invalid.
+func Cards(t testing.TB, s string) []types.Card {
+	t.Helper()
+
+	cards, err := types.ParseCards(s)
+	if err != nil {
+		t.Fatalf("ParseCards() error = %v", err)
+	}
+
+	return cards
+}
+
+// Seats returns one seat per stack, named A, B, C and so on, all using the given strategy.
+func Seats[S any](strategy S, stacks ...int) []*limit.Seat[S] {
+	seats := make([]*limit.Seat[S], len(stacks))
+	for i, stack := range stacks {
+		seats[i] = &limit.Seat[S]{Name: string(rune('A' + i)), Stack: stack, Strategy: strategy}
+	}
+
+	return seats
+}
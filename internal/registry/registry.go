@@ -0,0 +1,41 @@
+// Package registry holds the built-in strategies of a game by name, shared by the strategy lists of the table,
+// stud and draw packages.
+package registry
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"time"
+)
+
+// Registry maps the name of a built-in strategy to its constructor.
+type Registry[S any] map[string]func(rng *rand.Rand) S
+
+// Names returns the sorted names of the strategies.
+func (r Registry[S]) Names() []string {
+	names := make([]string, 0, len(r))
+	for name := range r {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// New creates a strategy by name.
+// The random number generator is used by strategies that make random decisions.
+// If rng is nil, a generator seeded with the current time is used.
+func (r Registry[S]) New(name string, rng *rand.Rand) (S, error) {
+	newFunc, ok := r[name]
+	if !ok {
+		var zero S
+		return zero, fmt.Errorf("unknown strategy: %s. strategy should be one of %v", name, r.Names())
+	}
+
+	if rng == nil {
+		rng = rand.New(rand.NewSource(time.Now().UnixNano()))
+	}
+
+	return newFunc(rng), nil
+}
@@ -0,0 +1,40 @@
+package registry
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func TestRegistry(t *testing.T) {
+	r := Registry[int]{
+		"one":    func(*rand.Rand) int { return 1 },
+		"random": func(rng *rand.Rand) int { return rng.Intn(10) + 2 },
+	}
+
+	if got, want := r.Names(), []string{"one", "random"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Names() = %v, want %v", got, want)
+	}
+
+	tests := []struct {
+		name    string
+		want    int
+		wantErr bool
+	}{
+		{name: "one", want: 1},
+		{name: "random"},
+		{name: "two", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// a nil generator is replaced for the strategies that need one
+			got, err := r.New(tt.name, nil)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("New() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.want != 0 && got != tt.want {
+				t.Errorf("New() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
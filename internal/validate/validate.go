@@ -0,0 +1,71 @@
+// Package validate holds the limits and checks of the requests shared by the HTTP API of package server
+// and the gRPC service of package rpc, so both accept the same requests.
+package validate
+
+import (
+	"github.com/YoungsoonLee/poker/poker"
+	"github.com/YoungsoonLee/poker/types"
+)
+
+// Equity iterations used when a request does not give any, and the most a request may ask for.
+const (
+	DefaultIterations = 10000
+	MaxIterations     = 100000
+)
+
+// MaxHoles is the most hands an equity request may have, the seats of a full Hold'em table.
+const MaxHoles = 10
+
+// Hand card counts of the requests.
+const (
+	HandCards  = 5
+	MaxCards   = 7
+	HoleCards  = 2
+	BoardCards = 5
+)
+
+// AddFunc adds the problem of a field of a request, like the validators of the servers.
+type AddFunc func(field, format string, args ...any)
+
+// Equity checks an equity request whose cards are already parsed: the number of holes, the size of the board,
+// the iterations, the variant and that every card is in the deck of the variant. Hole cards are reported with the field
+// holeField returns for their index. It returns the iterations with the default applied and the variant.
+func Equity(add AddFunc, holes [][]types.Card, holeField func(i int) string, board []types.Card, iterations int, variant string) (int, poker.Variant) {
+	if len(holes) < 2 {
+		add("holes", "should have at least 2 hands")
+	}
+	if len(holes) > MaxHoles {
+		add("holes", "should have at most %d hands, got %d", MaxHoles, len(holes))
+	}
+
+	if n := len(board); n == 1 || n == 2 {
+		add("board", "should have 0, 3, 4 or 5 cards, got %d", n)
+	}
+
+	if iterations == 0 {
+		iterations = DefaultIterations
+	}
+	if iterations < 1 || iterations > MaxIterations {
+		add("iterations", "should be between 1 and %d, got %d", MaxIterations, iterations)
+	}
+
+	v, err := poker.ParseVariant(variant)
+	if err != nil {
+		add("variant", "%v", err)
+	}
+	for i, hole := range holes {
+		inDeck(add, holeField(i), hole, v)
+	}
+	inDeck(add, "board", board, v)
+
+	return iterations, v
+}
+
+// inDeck checks that the cards of a field are in the deck of the variant.
+func inDeck(add AddFunc, field string, cards []types.Card, v poker.Variant) {
+	for _, c := range cards {
+		if !v.InDeck(c) {
+			add(field, "card %s is not used in %s", c, v)
+		}
+	}
+}
@@ -0,0 +1,66 @@
+package validate
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/YoungsoonLee/poker/types"
+)
+
+func TestEquity(t *testing.T) {
+	tests := []struct {
+		name           string
+		holes          []string
+		board          string
+		iterations     int
+		variant        string
+		wantFields     []string
+		wantIterations int
+		wantVariant    string
+	}{
+		{name: "defaults", holes: []string{"AhKh", "QsQd"}, wantIterations: DefaultIterations, wantVariant: "standard"},
+		{name: "short-deck", holes: []string{"AhKh", "QsQd"}, board: "6h7h8h", iterations: 5, variant: "short-deck", wantIterations: 5, wantVariant: "short-deck"},
+		{name: "one hand", holes: []string{"AhKh"}, wantFields: []string{"holes"}},
+		{name: "too many hands", holes: []string{"Ah2h", "Ad2d", "Ac2c", "As2s", "Kh3h", "Kd3d", "Kc3c", "Ks3s", "Qh4h", "Qd4d", "Qc4c"}, wantFields: []string{"holes"}},
+		{name: "board of two cards", holes: []string{"AhKh", "QsQd"}, board: "2h7h", wantFields: []string{"board"}},
+		{name: "too many iterations", holes: []string{"AhKh", "QsQd"}, iterations: MaxIterations + 1, wantFields: []string{"iterations"}},
+		{name: "unknown variant", holes: []string{"AhKh", "QsQd"}, variant: "razz", wantFields: []string{"variant"}},
+		{name: "cards not in the short deck", holes: []string{"Ah2h", "QsQd"}, board: "3h7h9c", variant: "short-deck", wantFields: []string{"holes[0]", "board"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			holes := make([][]types.Card, len(tt.holes))
+			for i, s := range tt.holes {
+				holes[i] = mustCards(t, s)
+			}
+
+			var fields []string
+			add := func(field, format string, args ...any) { fields = append(fields, field) }
+			holeField := func(i int) string { return fmt.Sprintf("holes[%d]", i) }
+
+			iterations, variant := Equity(add, holes, holeField, mustCards(t, tt.board), tt.iterations, tt.variant)
+			if !reflect.DeepEqual(fields, tt.wantFields) {
+				t.Fatalf("Equity() fields = %v, want %v", fields, tt.wantFields)
+			}
+			if tt.wantFields != nil {
+				return
+			}
+			if iterations != tt.wantIterations || variant.String() != tt.wantVariant {
+				t.Errorf("Equity() = %v, %v, want %v, %v", iterations, variant, tt.wantIterations, tt.wantVariant)
+			}
+		})
+	}
+}
+
+// mustCards parses cards like "AhKh".
+func mustCards(t *testing.T, s string) []types.Card {
+	t.Helper()
+
+	cards, err := types.ParseCards(s)
+	if err != nil {
+		t.Fatalf("ParseCards() error = %v", err)
+	}
+
+	return cards
+}
@@ -0,0 +1,165 @@
+package live
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/YoungsoonLee/poker/table"
+	"github.com/YoungsoonLee/poker/types"
+)
+
+// Types of the messages clients send.
+const (
+	TypeJoin   = "join"
+	TypeWatch  = "watch"
+	TypeResume = "resume"
+	TypeAction = "action"
+	TypeLeave  = "leave"
+)
+
+// Types of the messages the server sends.
+const (
+	TypeJoined = "joined"
+	TypeLeft   = "left"
+	TypeState  = "state"
+	TypeResult = "result"
+	TypeError  = "error"
+)
+
+// ClientMessage is a message sent by a client.
+//
+//   - join takes a seat in Room as Name. Seat reserves a seat by its index, otherwise the first free seat is taken.
+//   - watch watches Room without taking a seat.
+//   - resume takes back the seat of Token in Room, e.g. after a lost connection.
+//   - action answers the turn of the client with Action (fold, check, call, bet or raise) and, for bets and raises,
+//     Amount, the total bet on the street.
+//   - leave gives up the seat, folding the hand in play.
+type ClientMessage struct {
+	Type   string `json:"type"`
+	Room   string `json:"room,omitempty"`
+	Name   string `json:"name,omitempty"`
+	Seat   *int   `json:"seat,omitempty"`
+	Token  string `json:"token,omitempty"`
+	Action string `json:"action,omitempty"`
+	Amount int    `json:"amount,omitempty"`
+}
+
+// ServerMessage is a message sent to a client.
+//
+//   - joined answers join and resume with the seat and the Token to resume it with.
+//   - left tells a player they lost their seat, with the reason in Error.
+//   - state is the state of the room as the client may see it, sent whenever it changes.
+//   - result is the outcome of a hand.
+//   - error answers a message that could not be handled.
+type ServerMessage struct {
+	Type   string      `json:"type"`
+	Room   string      `json:"room,omitempty"`
+	Seat   *int        `json:"seat,omitempty"`
+	Token  string      `json:"token,omitempty"`
+	State  *State      `json:"state,omitempty"`
+	Result *HandResult `json:"result,omitempty"`
+	Error  string      `json:"error,omitempty"`
+}
+
+// State is a room as one client sees it. Only the client's own hole cards are included.
+// Street is empty between hands, and Turn is the seat whose action is awaited, if any.
+type State struct {
+	HandNo     int          `json:"hand_no"`
+	Street     string       `json:"street,omitempty"`
+	Button     int          `json:"button"`
+	SmallBlind int          `json:"small_blind"`
+	BigBlind   int          `json:"big_blind"`
+	Board      []string     `json:"board"`
+	Pot        int          `json:"pot"`
+	Seats      []SeatState  `json:"seats"`
+	LastAction *ActionState `json:"last_action,omitempty"`
+	Turn       *Turn        `json:"turn,omitempty"`
+}
+
+// SeatState is a seat as one client sees it. A free seat has no name.
+// InHand is set for the seats dealt into the hand in play, and Bet is their bet on the street.
+type SeatState struct {
+	Seat      int      `json:"seat"`
+	Name      string   `json:"name,omitempty"`
+	Stack     int      `json:"stack"`
+	Bet       int      `json:"bet"`
+	Hole      []string `json:"hole,omitempty"`
+	InHand    bool     `json:"in_hand"`
+	Folded    bool     `json:"folded"`
+	AllIn     bool     `json:"all_in"`
+	Connected bool     `json:"connected"`
+}
+
+// ActionState is an action taken in the hand in play.
+type ActionState struct {
+	Seat   int    `json:"seat"`
+	Street string `json:"street"`
+	Type   string `json:"type"`
+	Amount int    `json:"amount"`
+	Total  int    `json:"total"`
+	AllIn  bool   `json:"all_in,omitempty"`
+}
+
+// Turn is the seat whose action is awaited. If no action arrives by Deadline the seat folds, or checks if it can.
+// ToCall is the number of chips needed to call, and MinRaise is the smallest total bet a raise can make.
+type Turn struct {
+	Seat     int       `json:"seat"`
+	ToCall   int       `json:"to_call"`
+	MinRaise int       `json:"min_raise"`
+	Deadline time.Time `json:"deadline"`
+}
+
+// HandResult is the outcome of a hand. Showdown is ranked by EvaluateHands, best first,
+// and only reveals the hole cards of the players who did not fold.
+type HandResult struct {
+	HandNo   int             `json:"hand_no"`
+	Board    []string        `json:"board"`
+	Showdown []ShowdownState `json:"showdown,omitempty"`
+	Pots     []PotState      `json:"pots"`
+}
+
+// ShowdownState is the best hand shown by a seat at showdown.
+type ShowdownState struct {
+	Seat      int      `json:"seat"`
+	Hole      []string `json:"hole"`
+	Cards     []string `json:"cards"`
+	Rank      string   `json:"rank"`
+	RankOrder int      `json:"rank_order"`
+}
+
+// PotState is a main or side pot and the seats that won it.
+type PotState struct {
+	Amount  int   `json:"amount"`
+	Winners []int `json:"winners"`
+}
+
+// parseAction parses the action of an action message.
+func parseAction(m ClientMessage) (table.Action, error) {
+	for _, t := range []table.ActionType{table.Fold, table.Check, table.Call, table.Bet, table.Raise} {
+		if m.Action == t.String() {
+			return table.Action{Type: t, Amount: m.Amount}, nil
+		}
+	}
+
+	return table.Action{}, fmt.Errorf("unknown action: %q", m.Action)
+}
+
+func actionState(a table.ActionLog) *ActionState {
+	return &ActionState{
+		Seat:   a.Seat,
+		Street: a.Street.String(),
+		Type:   a.Type.String(),
+		Amount: a.Amount,
+		Total:  a.Total,
+		AllIn:  a.AllIn,
+	}
+}
+
+func cardStrings(cards []types.Card) []string {
+	s := make([]string, len(cards))
+	for i, c := range cards {
+		s[i] = c.String()
+	}
+
+	return s
+}
@@ -0,0 +1,556 @@
+package live
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/YoungsoonLee/poker/poker"
+	"github.com/YoungsoonLee/poker/table"
+)
+
+// maxNameLength is the longest player name accepted.
+const maxNameLength = 32
+
+// RoomConfig configures the rooms of a lobby.
+// Every player sits down with Stack chips. ActionTimeout is how long a player has to act before they fold,
+// HandDelay is the pause between hands, and a disconnected player loses their seat after ReconnectTimeout.
+type RoomConfig struct {
+	Seats            int
+	SmallBlind       int
+	BigBlind         int
+	Stack            int
+	ActionTimeout    time.Duration
+	HandDelay        time.Duration
+	ReconnectTimeout time.Duration
+}
+
+// DefaultRoomConfig is a six seat table with 5/10 blinds and 1000 chip stacks.
+var DefaultRoomConfig = RoomConfig{
+	Seats:            6,
+	SmallBlind:       5,
+	BigBlind:         10,
+	Stack:            1000,
+	ActionTimeout:    30 * time.Second,
+	HandDelay:        3 * time.Second,
+	ReconnectTimeout: 2 * time.Minute,
+}
+
+func (c RoomConfig) validate() error {
+	if c.Seats < 2 {
+		return fmt.Errorf("a room needs at least 2 seats, got %d", c.Seats)
+	}
+	if c.SmallBlind < 0 || c.BigBlind < 1 || c.SmallBlind > c.BigBlind {
+		return fmt.Errorf("invalid blinds: %d/%d", c.SmallBlind, c.BigBlind)
+	}
+	if c.Stack < c.BigBlind {
+		return fmt.Errorf("stack %d is smaller than the big blind %d", c.Stack, c.BigBlind)
+	}
+	if c.ActionTimeout <= 0 || c.ReconnectTimeout <= 0 || c.HandDelay < 0 {
+		return errors.New("timeouts must be positive")
+	}
+
+	return nil
+}
+
+// seat is a seat of a room. A free seat has no name.
+// The chips of a new player are only put on the table when the next hand starts, as only the run loop touches the table.
+type seat struct {
+	name         string
+	token        string
+	client       *client
+	disconnected time.Time
+	stack        int
+	seated       bool
+	leaving      bool
+	actions      chan table.Action
+}
+
+// Room is a table players join over their connections.
+// Hands are played by the run loop of the room, which waits for the actions of the players as the strategies of their seats.
+type Room struct {
+	id    string
+	cfg   RoomConfig
+	table *table.Table
+	wake  chan struct{}
+	done  chan struct{}
+
+	mu       sync.Mutex
+	seats    []*seat
+	watchers map[*client]bool
+	snapshot *table.Snapshot
+	turn     *Turn
+	handNo   int
+	button   int
+}
+
+// remote is the strategy of a seat, played by the player connected to it.
+type remote struct {
+	r    *Room
+	seat int
+}
+
+// Act waits for the action of the player.
+func (s remote) Act(v table.View) table.Action {
+	return s.r.await(s.seat, v)
+}
+
+func newRoom(id string, cfg RoomConfig) (*Room, error) {
+	r := &Room{
+		id:       id,
+		cfg:      cfg,
+		wake:     make(chan struct{}, 1),
+		done:     make(chan struct{}),
+		watchers: make(map[*client]bool),
+	}
+
+	seats := make([]*table.Seat, cfg.Seats)
+	for i := range seats {
+		seats[i] = &table.Seat{Strategy: remote{r: r, seat: i}}
+		r.seats = append(r.seats, &seat{actions: make(chan table.Action, 1)})
+	}
+
+	t, err := table.New(seats, cfg.SmallBlind, cfg.BigBlind, nil)
+	if err != nil {
+		return nil, err
+	}
+	t.Observer = r.observe
+	r.table = t
+
+	go r.run()
+
+	return r, nil
+}
+
+// run plays hands while at least two players have chips.
+func (r *Room) run() {
+	for {
+		if !r.startHand() {
+			select {
+			case <-r.wake:
+			case <-time.After(r.cfg.ReconnectTimeout):
+			case <-r.done:
+				return
+			}
+			continue
+		}
+
+		result, err := r.table.PlayHand()
+		r.finishHand(result, err)
+
+		select {
+		case <-time.After(r.cfg.HandDelay):
+		case <-r.done:
+			return
+		}
+	}
+}
+
+// close stops the run loop. A hand in play ends with every player folding or checking.
+func (r *Room) close() {
+	close(r.done)
+}
+
+// startHand settles the seats and reports whether enough players have chips for a hand.
+func (r *Room) startHand() bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.settleLocked() {
+		r.broadcastLocked()
+	}
+
+	return r.table.ActiveSeats() >= 2
+}
+
+// finishHand sends the result of a hand to everyone in the room and settles the seats.
+func (r *Room) finishHand(result *table.Result, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.snapshot = nil
+	r.turn = nil
+	r.button = r.table.Button
+
+	if err == nil {
+		for _, p := range result.Players {
+			r.seats[p.Seat].stack = p.EndStack
+		}
+
+		msg := ServerMessage{Type: TypeResult, Room: r.id, Result: handResult(result)}
+		for _, st := range r.seats {
+			if st.client != nil {
+				st.client.send(msg)
+			}
+		}
+		for c := range r.watchers {
+			c.send(msg)
+		}
+	}
+
+	r.settleLocked()
+	r.broadcastLocked()
+}
+
+// settleLocked frees the seats of players who left, busted or did not come back in time, and puts the chips of new players on the table.
+// It must only be called by the run loop between hands, and reports whether any seat changed.
+func (r *Room) settleLocked() bool {
+	changed := false
+	for i, st := range r.seats {
+		ts := r.table.Seats[i]
+
+		switch {
+		case st.name == "":
+			continue
+		case st.leaving:
+			r.freeLocked(i, "left the room")
+		case st.seated && ts.Stack == 0:
+			r.freeLocked(i, "out of chips")
+		case st.client == nil && time.Since(st.disconnected) >= r.cfg.ReconnectTimeout:
+			r.freeLocked(i, "did not reconnect in time")
+		case !st.seated:
+			ts.Name = st.name
+			ts.Stack = r.cfg.Stack
+			st.stack = r.cfg.Stack
+			st.seated = true
+		default:
+			continue
+		}
+		changed = true
+	}
+
+	return changed
+}
+
+// freeLocked frees a seat. Its player, if connected, is told why and keeps watching the room.
+func (r *Room) freeLocked(i int, reason string) {
+	st := r.seats[i]
+	if st.client != nil {
+		st.client.send(ServerMessage{Type: TypeLeft, Room: r.id, Seat: &i, Error: reason})
+		r.watchers[st.client] = true
+	}
+
+	r.table.Seats[i].Name = ""
+	r.table.Seats[i].Stack = 0
+	r.seats[i] = &seat{actions: st.actions}
+}
+
+// observe keeps the snapshot of the hand in play and sends every client their view of it.
+func (r *Room) observe(s table.Snapshot) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.snapshot = &s
+	r.handNo = s.HandNo
+	r.button = s.Button
+	for _, p := range s.Players {
+		r.seats[p.Seat].stack = p.Stack
+	}
+
+	r.broadcastLocked()
+}
+
+// await announces the turn of a seat and waits for the action of its player.
+// If none arrives within the action timeout the seat folds, which the table turns into a check when nothing is owed.
+func (r *Room) await(i int, v table.View) table.Action {
+	fold := table.Action{Type: table.Fold}
+
+	r.mu.Lock()
+	st := r.seats[i]
+	select {
+	case <-st.actions:
+	default:
+	}
+	if st.leaving {
+		r.mu.Unlock()
+		return fold
+	}
+
+	r.turn = &Turn{Seat: i, ToCall: v.ToCall, MinRaise: v.MinRaise, Deadline: time.Now().Add(r.cfg.ActionTimeout)}
+	r.broadcastLocked()
+	actions := st.actions
+	r.mu.Unlock()
+
+	timer := time.NewTimer(r.cfg.ActionTimeout)
+	defer timer.Stop()
+
+	action := fold
+	select {
+	case action = <-actions:
+	case <-timer.C:
+	case <-r.done:
+	}
+
+	r.mu.Lock()
+	r.turn = nil
+	r.mu.Unlock()
+
+	return action
+}
+
+// join seats a client as name, at the given seat or the first free one, and sends them the token of the seat.
+func (r *Room) join(c *client, name string, seatNo *int) error {
+	name = strings.TrimSpace(name)
+	if name == "" || len(name) > maxNameLength {
+		return fmt.Errorf("a name of 1 to %d characters is required", maxNameLength)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if i := r.seatOfLocked(c); i >= 0 {
+		return fmt.Errorf("already seated at seat %d", i)
+	}
+
+	i := -1
+	for k, st := range r.seats {
+		if st.name == name {
+			return fmt.Errorf("name %q is taken", name)
+		}
+		if i < 0 && st.name == "" && seatNo == nil {
+			i = k
+		}
+	}
+
+	if seatNo != nil {
+		if *seatNo < 0 || *seatNo >= len(r.seats) {
+			return fmt.Errorf("invalid seat: %d", *seatNo)
+		}
+		if r.seats[*seatNo].name != "" {
+			return fmt.Errorf("seat %d is taken", *seatNo)
+		}
+		i = *seatNo
+	}
+	if i < 0 {
+		return errors.New("the room is full")
+	}
+
+	st := r.seats[i]
+	st.name = name
+	st.token = newToken()
+	st.client = c
+	delete(r.watchers, c)
+
+	c.send(ServerMessage{Type: TypeJoined, Room: r.id, Seat: &i, Token: st.token})
+	r.broadcastLocked()
+	r.signal()
+
+	return nil
+}
+
+// watch sends the room to a client without seating them.
+func (r *Room) watch(c *client) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	i := r.seatOfLocked(c)
+	if i < 0 {
+		r.watchers[c] = true
+	}
+	c.send(ServerMessage{Type: TypeState, Room: r.id, State: r.stateLocked(i)})
+}
+
+// resume gives a client back the seat of a token, e.g. after a lost connection, and sends them the state of the room.
+// A previous connection of the seat keeps watching the room.
+func (r *Room) resume(c *client, token string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i, st := range r.seats {
+		if token == "" || st.token != token {
+			continue
+		}
+
+		if old := st.client; old != nil && old != c {
+			old.send(ServerMessage{Type: TypeLeft, Room: r.id, Seat: &i, Error: "resumed from another connection"})
+			r.watchers[old] = true
+		}
+		st.client = c
+		delete(r.watchers, c)
+
+		c.send(ServerMessage{Type: TypeJoined, Room: r.id, Seat: &i, Token: st.token})
+		r.broadcastLocked()
+
+		return nil
+	}
+
+	return errors.New("unknown token")
+}
+
+// act passes the action of a client to the hand in play if it is their turn.
+func (r *Room) act(c *client, a table.Action) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	i := r.seatOfLocked(c)
+	if i < 0 {
+		return errors.New("not seated")
+	}
+	if r.turn == nil || r.turn.Seat != i {
+		return errors.New("not your turn")
+	}
+
+	r.turn = nil
+	select {
+	case r.seats[i].actions <- a:
+	default:
+	}
+
+	return nil
+}
+
+// leave gives up the seat of a client. A hand in play is folded, and the seat is freed when it is over.
+func (r *Room) leave(c *client) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	i := r.seatOfLocked(c)
+	if i < 0 {
+		return errors.New("not seated")
+	}
+
+	r.seats[i].leaving = true
+	if r.turn != nil && r.turn.Seat == i {
+		r.turn = nil
+		select {
+		case r.seats[i].actions <- table.Action{Type: table.Fold}:
+		default:
+		}
+	}
+	r.signal()
+
+	return nil
+}
+
+// disconnect removes a closed connection from the room. Its seat is kept for the reconnect timeout.
+func (r *Room) disconnect(c *client) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.watchers, c)
+	if i := r.seatOfLocked(c); i >= 0 {
+		r.seats[i].client = nil
+		r.seats[i].disconnected = time.Now()
+		r.broadcastLocked()
+	}
+}
+
+// signal wakes the run loop if it is waiting for players.
+func (r *Room) signal() {
+	select {
+	case r.wake <- struct{}{}:
+	default:
+	}
+}
+
+func (r *Room) seatOfLocked(c *client) int {
+	for i, st := range r.seats {
+		if st.client == c {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// broadcastLocked sends every client their view of the room.
+func (r *Room) broadcastLocked() {
+	for i, st := range r.seats {
+		if st.client != nil {
+			st.client.send(ServerMessage{Type: TypeState, Room: r.id, State: r.stateLocked(i)})
+		}
+	}
+
+	if len(r.watchers) > 0 {
+		msg := ServerMessage{Type: TypeState, Room: r.id, State: r.stateLocked(-1)}
+		for c := range r.watchers {
+			c.send(msg)
+		}
+	}
+}
+
+// stateLocked returns the room as seen from a seat, or by a watcher if the seat is -1.
+// Hole cards are only included for the seat itself, so they never leak to other clients.
+func (r *Room) stateLocked(viewer int) *State {
+	s := &State{
+		HandNo:     r.handNo,
+		Button:     r.button,
+		SmallBlind: r.cfg.SmallBlind,
+		BigBlind:   r.cfg.BigBlind,
+		Board:      []string{},
+	}
+	if r.turn != nil {
+		turn := *r.turn
+		s.Turn = &turn
+	}
+
+	players := make(map[int]table.PlayerSnapshot)
+	if r.snapshot != nil {
+		s.Street = r.snapshot.Street.String()
+		s.Board = cardStrings(r.snapshot.Board)
+		s.Pot = r.snapshot.Pot
+		if r.snapshot.Action != nil {
+			s.LastAction = actionState(*r.snapshot.Action)
+		}
+		for _, p := range r.snapshot.Players {
+			players[p.Seat] = p
+		}
+	}
+
+	for i, st := range r.seats {
+		ss := SeatState{Seat: i, Name: st.name, Stack: st.stack, Connected: st.client != nil}
+		if p, ok := players[i]; ok {
+			ss.InHand = true
+			ss.Bet = p.Bet
+			ss.Folded = p.Folded
+			ss.AllIn = p.AllIn
+			if i == viewer {
+				ss.Hole = cardStrings(p.Hole)
+			}
+		}
+		s.Seats = append(s.Seats, ss)
+	}
+
+	return s
+}
+
+// handResult returns the outcome of a hand, with the showdown ranked by EvaluateHands.
+func handResult(result *table.Result) *HandResult {
+	hr := &HandResult{HandNo: result.HandNo, Board: cardStrings(result.Board)}
+
+	hands := make(poker.Hands, len(result.Showdown))
+	for i, s := range result.Showdown {
+		hands[i] = s.Hand
+	}
+	// the best hands are identified by their seats
+	for _, e := range poker.EvaluateHands(hands) {
+		p, _ := result.Player(e.HandID)
+		hr.Showdown = append(hr.Showdown, ShowdownState{
+			Seat:      e.HandID,
+			Hole:      cardStrings(p.Hole),
+			Cards:     cardStrings(e.Card),
+			Rank:      e.Rank,
+			RankOrder: e.RankOrder,
+		})
+	}
+
+	for _, p := range result.Pots {
+		hr.Pots = append(hr.Pots, PotState{Amount: p.Amount, Winners: p.Winners})
+	}
+
+	return hr
+}
+
+// newToken returns a random token a player resumes their seat with.
+func newToken() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		panic(err)
+	}
+
+	return hex.EncodeToString(b)
+}
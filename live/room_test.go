@@ -0,0 +1,172 @@
+package live
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/YoungsoonLee/poker/poker"
+	"github.com/YoungsoonLee/poker/types"
+)
+
+// play answers the turns of a seat with act until hands results are sent, and returns every message sent.
+// A seat of -1 only watches.
+func (c *testClient) play(seat int, act func(Turn) ClientMessage, hands int) ([]ServerMessage, error) {
+	var msgs []ServerMessage
+	var answered time.Time
+
+	c.conn.SetReadDeadline(time.Now().Add(20 * time.Second))
+	for results := 0; results < hands; {
+		var m ServerMessage
+		if err := c.conn.ReadJSON(&m); err != nil {
+			return msgs, err
+		}
+		msgs = append(msgs, m)
+
+		switch m.Type {
+		case TypeResult:
+			results++
+		case TypeError:
+			return msgs, errors.New(m.Error)
+		case TypeState:
+			// the same turn is sent again whenever the state changes before it is answered
+			if turn := m.State.Turn; turn != nil && turn.Seat == seat && !turn.Deadline.Equal(answered) {
+				answered = turn.Deadline
+				if err := c.conn.WriteJSON(act(*turn)); err != nil {
+					return msgs, err
+				}
+			}
+		}
+	}
+
+	return msgs, nil
+}
+
+// leaks returns an error if a state shows hole cards of another seat than the given one.
+func leaks(msgs []ServerMessage, seat int) error {
+	for _, m := range msgs {
+		if m.Type != TypeState {
+			continue
+		}
+		for _, s := range m.State.Seats {
+			if len(s.Hole) > 0 && s.Seat != seat {
+				return fmt.Errorf("seat %d was sent the hole cards of seat %d in hand %d", seat, s.Seat, m.State.HandNo)
+			}
+		}
+	}
+
+	return nil
+}
+
+func TestRoom_PlayHands(t *testing.T) {
+	url := newLobby(t, testConfig)
+	const hands = 3
+
+	watcher := dial(t, url)
+	watcher.send(ClientMessage{Type: TypeWatch, Room: "r"})
+	watcher.next(TypeState)
+
+	alice, bob := dial(t, url), dial(t, url)
+	seats := []int{*alice.join("r", "alice").Seat, *bob.join("r", "bob").Seat}
+
+	call := func(Turn) ClientMessage { return ClientMessage{Type: TypeAction, Action: "call"} }
+	clients := []*testClient{alice, bob, watcher}
+	msgs := make([][]ServerMessage, len(clients))
+	errs := make([]error, len(clients))
+
+	var wg sync.WaitGroup
+	for i, c := range clients {
+		seat := -1
+		if i < len(seats) {
+			seat = seats[i]
+		}
+
+		wg.Add(1)
+		go func(i, seat int, c *testClient) {
+			defer wg.Done()
+			msgs[i], errs[i] = c.play(seat, call, hands)
+		}(i, seat, c)
+	}
+	wg.Wait()
+
+	for i := range clients {
+		if errs[i] != nil {
+			t.Fatalf("client %d error = %v", i, errs[i])
+		}
+
+		seat := -1
+		if i < len(seats) {
+			seat = seats[i]
+		}
+		if err := leaks(msgs[i], seat); err != nil {
+			t.Error(err)
+		}
+	}
+
+	// the players call every hand down, so both hands are shown and ranked best first
+	for _, m := range msgs[2] {
+		if m.Type != TypeResult {
+			continue
+		}
+
+		r := m.Result
+		if len(r.Board) != 5 || len(r.Showdown) != 2 || len(r.Pots) != 1 || r.Pots[0].Amount != 20 {
+			t.Fatalf("result = %+v, want a showdown of 2 hands for a pot of 20", r)
+		}
+
+		var scores []int
+		for _, s := range r.Showdown {
+			cards, err := types.ParseCards(fmt.Sprint(s.Cards))
+			if err != nil {
+				t.Fatalf("types.ParseCards() error = %v", err)
+			}
+			scores = append(scores, poker.Hand{Cards: cards}.Score())
+		}
+		if scores[0] > scores[1] {
+			t.Errorf("showdown scores = %v, want the best hand first", scores)
+		}
+
+		winner := r.Showdown[0].Seat
+		if scores[0] < scores[1] && (len(r.Pots[0].Winners) != 1 || r.Pots[0].Winners[0] != winner) {
+			t.Errorf("pot winners = %v, want [%d]", r.Pots[0].Winners, winner)
+		}
+	}
+}
+
+func TestRoom_ActionTimeout(t *testing.T) {
+	cfg := testConfig
+	cfg.ActionTimeout = 100 * time.Millisecond
+	url := newLobby(t, cfg)
+
+	alice, bob := dial(t, url), dial(t, url)
+	aliceSeat := *alice.join("r", "alice").Seat
+	bobSeat := *bob.join("r", "bob").Seat
+
+	// alice raises every turn and bob never answers, so bob folds when his time is up
+	raise := func(turn Turn) ClientMessage {
+		return ClientMessage{Type: TypeAction, Action: "raise", Amount: turn.MinRaise}
+	}
+	start := time.Now()
+	msgs, err := alice.play(aliceSeat, raise, 1)
+	if err != nil {
+		t.Fatalf("play() error = %v", err)
+	}
+	if elapsed := time.Since(start); elapsed < cfg.ActionTimeout {
+		t.Errorf("hand took %v, want at least the action timeout %v", elapsed, cfg.ActionTimeout)
+	}
+
+	folded := false
+	for _, m := range msgs {
+		if m.Type == TypeState && m.State.LastAction != nil {
+			a := m.State.LastAction
+			folded = folded || (a.Seat == bobSeat && a.Type == "fold")
+		}
+	}
+
+	r := msgs[len(msgs)-1].Result
+	if !folded || len(r.Showdown) != 0 || len(r.Pots) != 1 || len(r.Pots[0].Winners) != 1 || r.Pots[0].Winners[0] != aliceSeat {
+		t.Errorf("result = %+v, want bob to fold and alice to win the pot", r)
+	}
+}
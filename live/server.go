@@ -0,0 +1,275 @@
+// Package live hosts real-time Texas Hold'em games over WebSocket.
+//
+// Clients send and receive JSON messages, see ClientMessage and ServerMessage:
+//
+//   - A client joins a room by its name and gets a seat and a token, or watches it without a seat.
+//     Rooms are created when they are first joined.
+//   - Hands start when at least two players have chips. When it is a player's turn every client is sent the turn,
+//     and a player who does not act before its deadline folds, or checks if they can.
+//   - Every client is sent the state of the room whenever it changes. Only a player's own hole cards are included,
+//     and the hole cards of the players who did not fold are revealed in the result of the hand.
+//   - A player who loses their connection keeps their seat for a while and resumes it with the token
+//     on a new connection, which is sent the full state of the room.
+package live
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+const (
+	// maxRooms is the largest number of rooms a lobby hosts.
+	maxRooms = 1000
+	// maxRoomLength is the longest room name accepted.
+	maxRoomLength = 64
+	// maxMessageSize is the largest message accepted from a client.
+	maxMessageSize = 4096
+	// sendBuffer is the number of messages queued for a client. A client that falls further behind is disconnected.
+	sendBuffer = 64
+
+	writeWait    = 10 * time.Second
+	pongWait     = 60 * time.Second
+	pingInterval = pongWait * 9 / 10
+)
+
+// Config configures a lobby.
+// AllowOrigins are the origins browsers may connect from, e.g. "http://localhost:3000", or "*" for any origin.
+// Without them only pages of the same host may connect.
+type Config struct {
+	Room         RoomConfig
+	AllowOrigins []string
+}
+
+// Lobby is the WebSocket handler of the rooms.
+type Lobby struct {
+	cfg      Config
+	upgrader websocket.Upgrader
+
+	mu     sync.Mutex
+	rooms  map[string]*Room
+	closed bool
+}
+
+// NewLobby returns a lobby whose rooms are configured by cfg.
+func NewLobby(cfg Config) (*Lobby, error) {
+	if err := cfg.Room.validate(); err != nil {
+		return nil, err
+	}
+
+	l := &Lobby{cfg: cfg, rooms: make(map[string]*Room)}
+	if len(cfg.AllowOrigins) > 0 {
+		l.upgrader.CheckOrigin = l.checkOrigin
+	}
+
+	return l, nil
+}
+
+// Close stops the hands of every room.
+func (l *Lobby) Close() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.closed {
+		return
+	}
+	l.closed = true
+	for _, r := range l.rooms {
+		r.close()
+	}
+}
+
+// ServeHTTP upgrades the request to a WebSocket connection and serves the client until it disconnects.
+func (l *Lobby) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	conn, err := l.upgrader.Upgrade(w, req, nil)
+	if err != nil {
+		// the upgrader has answered the request
+		return
+	}
+
+	c := newClient(conn)
+	go c.writeLoop()
+	l.serve(c)
+}
+
+// serve reads the messages of a client until it disconnects.
+func (l *Lobby) serve(c *client) {
+	defer c.close()
+
+	c.conn.SetReadLimit(maxMessageSize)
+	c.conn.SetReadDeadline(time.Now().Add(pongWait))
+	c.conn.SetPongHandler(func(string) error {
+		return c.conn.SetReadDeadline(time.Now().Add(pongWait))
+	})
+
+	var room *Room
+	defer func() {
+		if room != nil {
+			room.disconnect(c)
+		}
+	}()
+
+	for {
+		_, data, err := c.conn.ReadMessage()
+		if err != nil {
+			return
+		}
+
+		var m ClientMessage
+		if err := json.Unmarshal(data, &m); err != nil {
+			c.send(ServerMessage{Type: TypeError, Error: "invalid message: " + err.Error()})
+			continue
+		}
+
+		if err := l.handle(c, &room, m); err != nil {
+			c.send(ServerMessage{Type: TypeError, Room: m.Room, Error: err.Error()})
+		}
+	}
+}
+
+// handle handles a message of a client in the given room, and sets the room when the client enters one.
+func (l *Lobby) handle(c *client, room **Room, m ClientMessage) error {
+	switch m.Type {
+	case TypeJoin, TypeWatch, TypeResume:
+		if *room != nil && (*room).id != m.Room {
+			return fmt.Errorf("already in room %s", (*room).id)
+		}
+
+		r, err := l.room(m.Room)
+		if err != nil {
+			return err
+		}
+
+		switch m.Type {
+		case TypeJoin:
+			err = r.join(c, m.Name, m.Seat)
+		case TypeWatch:
+			r.watch(c)
+		case TypeResume:
+			err = r.resume(c, m.Token)
+		}
+		if err != nil {
+			return err
+		}
+		*room = r
+
+		return nil
+	case TypeAction, TypeLeave:
+		if *room == nil {
+			return errors.New("not in a room")
+		}
+
+		if m.Type == TypeLeave {
+			return (*room).leave(c)
+		}
+		a, err := parseAction(m)
+		if err != nil {
+			return err
+		}
+
+		return (*room).act(c, a)
+	default:
+		return fmt.Errorf("unknown message type: %q", m.Type)
+	}
+}
+
+// room returns the room of the given name, creating it if needed.
+func (l *Lobby) room(id string) (*Room, error) {
+	if id == "" || len(id) > maxRoomLength {
+		return nil, fmt.Errorf("a room name of 1 to %d characters is required", maxRoomLength)
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.closed {
+		return nil, errors.New("the lobby is closed")
+	}
+	if r, ok := l.rooms[id]; ok {
+		return r, nil
+	}
+	if len(l.rooms) >= maxRooms {
+		return nil, errors.New("too many rooms")
+	}
+
+	r, err := newRoom(id, l.cfg.Room)
+	if err != nil {
+		return nil, err
+	}
+	l.rooms[id] = r
+
+	return r, nil
+}
+
+func (l *Lobby) checkOrigin(req *http.Request) bool {
+	origin := req.Header.Get("Origin")
+	for _, o := range l.cfg.AllowOrigins {
+		if o == "*" || o == origin {
+			return true
+		}
+	}
+
+	return false
+}
+
+// client is the connection of a client. Messages are written by its write loop, so sending never blocks a room.
+type client struct {
+	conn *websocket.Conn
+	out  chan ServerMessage
+	done chan struct{}
+	once sync.Once
+}
+
+func newClient(conn *websocket.Conn) *client {
+	return &client{
+		conn: conn,
+		out:  make(chan ServerMessage, sendBuffer),
+		done: make(chan struct{}),
+	}
+}
+
+// send queues a message for the client, and disconnects the client if too many messages are queued.
+func (c *client) send(m ServerMessage) {
+	select {
+	case c.out <- m:
+	default:
+		c.close()
+	}
+}
+
+// close closes the connection, which ends its read and write loops.
+func (c *client) close() {
+	c.once.Do(func() {
+		close(c.done)
+		c.conn.Close()
+	})
+}
+
+// writeLoop writes the queued messages and pings the client until the connection is closed.
+func (c *client) writeLoop() {
+	ping := time.NewTicker(pingInterval)
+	defer ping.Stop()
+
+	for {
+		select {
+		case m := <-c.out:
+			c.conn.SetWriteDeadline(time.Now().Add(writeWait))
+			if err := c.conn.WriteJSON(m); err != nil {
+				c.close()
+				return
+			}
+		case <-ping.C:
+			if err := c.conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(writeWait)); err != nil {
+				c.close()
+				return
+			}
+		case <-c.done:
+			return
+		}
+	}
+}
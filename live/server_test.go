@@ -0,0 +1,174 @@
+package live
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// testConfig is a heads-up room that deals quickly and waits long enough for scripted clients.
+var testConfig = RoomConfig{
+	Seats:            2,
+	SmallBlind:       5,
+	BigBlind:         10,
+	Stack:            100,
+	ActionTimeout:    5 * time.Second,
+	HandDelay:        10 * time.Millisecond,
+	ReconnectTimeout: time.Minute,
+}
+
+// newLobby serves a lobby on a test server and returns its WebSocket URL.
+func newLobby(t *testing.T, cfg RoomConfig) string {
+	t.Helper()
+
+	l, err := NewLobby(Config{Room: cfg})
+	if err != nil {
+		t.Fatalf("NewLobby() error = %v", err)
+	}
+	srv := httptest.NewServer(l)
+	t.Cleanup(func() {
+		l.Close()
+		srv.Close()
+	})
+
+	return "ws" + strings.TrimPrefix(srv.URL, "http")
+}
+
+// testClient is a scripted client of a lobby.
+type testClient struct {
+	t    *testing.T
+	conn *websocket.Conn
+}
+
+func dial(t *testing.T, url string) *testClient {
+	t.Helper()
+
+	conn, _, err := websocket.DefaultDialer.Dial(url, nil)
+	if err != nil {
+		t.Fatalf("Dial() error = %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	return &testClient{t: t, conn: conn}
+}
+
+func (c *testClient) send(m ClientMessage) {
+	c.t.Helper()
+
+	if err := c.conn.WriteJSON(m); err != nil {
+		c.t.Fatalf("WriteJSON() error = %v", err)
+	}
+}
+
+// until reads messages until one satisfies ok, and returns it.
+func (c *testClient) until(ok func(ServerMessage) bool) ServerMessage {
+	c.t.Helper()
+
+	c.conn.SetReadDeadline(time.Now().Add(10 * time.Second))
+	for {
+		var m ServerMessage
+		if err := c.conn.ReadJSON(&m); err != nil {
+			c.t.Fatalf("ReadJSON() error = %v", err)
+		}
+		if ok(m) {
+			return m
+		}
+	}
+}
+
+// next reads messages until one of the given type.
+func (c *testClient) next(typ string) ServerMessage {
+	c.t.Helper()
+
+	return c.until(func(m ServerMessage) bool { return m.Type == typ })
+}
+
+// join joins a room and returns the joined message.
+func (c *testClient) join(room, name string) ServerMessage {
+	c.t.Helper()
+
+	c.send(ClientMessage{Type: TypeJoin, Room: room, Name: name})
+	m := c.until(func(m ServerMessage) bool { return m.Type == TypeJoined || m.Type == TypeError })
+	if m.Type == TypeError {
+		c.t.Fatalf("join %s as %s error = %s", room, name, m.Error)
+	}
+
+	return m
+}
+
+func TestLobby_Join(t *testing.T) {
+	url := newLobby(t, testConfig)
+	seat := func(i int) *int { return &i }
+
+	alice := dial(t, url)
+	alice.send(ClientMessage{Type: TypeJoin, Room: "r", Name: "alice", Seat: seat(1)})
+	if m := alice.next(TypeJoined); *m.Seat != 1 || m.Token == "" {
+		t.Fatalf("joined = seat %d with token %q, want seat 1 with a token", *m.Seat, m.Token)
+	}
+
+	tests := []struct {
+		name string
+		msg  ClientMessage
+		want string
+	}{
+		{name: "reserved seat", msg: ClientMessage{Type: TypeJoin, Room: "r", Name: "bob", Seat: seat(1)}, want: "seat 1 is taken"},
+		{name: "invalid seat", msg: ClientMessage{Type: TypeJoin, Room: "r", Name: "bob", Seat: seat(2)}, want: "invalid seat: 2"},
+		{name: "name taken", msg: ClientMessage{Type: TypeJoin, Room: "r", Name: "alice"}, want: `name "alice" is taken`},
+		{name: "no name", msg: ClientMessage{Type: TypeJoin, Room: "r"}, want: "a name of 1 to 32 characters is required"},
+		{name: "no room", msg: ClientMessage{Type: TypeJoin, Name: "bob"}, want: "a room name of 1 to 64 characters is required"},
+		{name: "bad token", msg: ClientMessage{Type: TypeResume, Room: "r", Token: "x"}, want: "unknown token"},
+		{name: "action outside a room", msg: ClientMessage{Type: TypeAction, Action: "call"}, want: "not in a room"},
+		{name: "unknown type", msg: ClientMessage{Type: "sit"}, want: `unknown message type: "sit"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := dial(t, url)
+			c.send(tt.msg)
+			if m := c.next(TypeError); m.Error != tt.want {
+				t.Errorf("error = %q, want %q", m.Error, tt.want)
+			}
+		})
+	}
+
+	// the free seat is taken by the next player, and then the room is full
+	if m := dial(t, url).join("r", "bob"); *m.Seat != 0 {
+		t.Errorf("joined = seat %d, want 0", *m.Seat)
+	}
+	carol := dial(t, url)
+	carol.send(ClientMessage{Type: TypeJoin, Room: "r", Name: "carol"})
+	if m := carol.next(TypeError); m.Error != "the room is full" {
+		t.Errorf("error = %q, want the room is full", m.Error)
+	}
+}
+
+func TestLobby_Resume(t *testing.T) {
+	url := newLobby(t, testConfig)
+
+	alice := dial(t, url)
+	joined := alice.join("r", "alice")
+	dial(t, url).join("r", "bob")
+
+	// wait for the hole cards of the first hand
+	dealt := alice.until(func(m ServerMessage) bool {
+		return m.Type == TypeState && m.State.Street == "preflop" && len(m.State.Seats[*joined.Seat].Hole) == 2
+	})
+	hole := dealt.State.Seats[*joined.Seat].Hole
+
+	alice.conn.Close()
+
+	// the seat is kept while alice is away, and her new connection is sent the hand in play with her hole cards
+	again := dial(t, url)
+	again.send(ClientMessage{Type: TypeResume, Room: "r", Token: joined.Token})
+	if m := again.next(TypeJoined); *m.Seat != *joined.Seat || m.Token != joined.Token {
+		t.Fatalf("joined = seat %d, want seat %d with the same token", *m.Seat, *joined.Seat)
+	}
+
+	m := again.next(TypeState)
+	got := m.State.Seats[*joined.Seat]
+	if m.State.HandNo != dealt.State.HandNo || !got.Connected || !got.InHand || strings.Join(got.Hole, "") != strings.Join(hole, "") {
+		t.Errorf("resumed seat = %+v in hand %d, want hole cards %v in hand %d", got, m.State.HandNo, hole, dealt.State.HandNo)
+	}
+}
@@ -0,0 +1,185 @@
+package poker
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/YoungsoonLee/poker/types"
+)
+
+// DrawObjective values the five cards a player holds after the draw, where a bigger value is better.
+// Dead returns the cards known to be out of the deck besides the hand, e.g. the cards of an opponent, which cannot be drawn.
+// Value must not keep the cards, since the same slice is reused for every redraw.
+type DrawObjective interface {
+	Value(cards []types.Card) float64
+	Dead() []types.Card
+}
+
+// Paytable is a DrawObjective that pays a video poker hand by its rank order for a bet of one unit.
+// One Pair only pays with a pair of MinPair or better, e.g. "J" for Jacks or Better, and every pair pays if MinPair is empty.
+type Paytable struct {
+	Name    string
+	Pays    map[int]float64
+	MinPair string
+}
+
+// JacksOrBetter is the full pay 9/6 Jacks or Better paytable.
+var JacksOrBetter = Paytable{
+	Name:    "jacks",
+	Pays:    map[int]float64{1: 800, 2: 50, 3: 25, 4: 9, 5: 6, 6: 4, 7: 3, 8: 2, 9: 1},
+	MinPair: "J",
+}
+
+// TensOrBetter is the 6/5 Tens or Better paytable.
+var TensOrBetter = Paytable{
+	Name:    "tens",
+	Pays:    map[int]float64{1: 800, 2: 50, 3: 25, 4: 6, 5: 5, 6: 4, 7: 3, 8: 2, 9: 1},
+	MinPair: "T",
+}
+
+// Paytables are the built-in paytables, which ParsePaytable accepts by name.
+var Paytables = []Paytable{JacksOrBetter, TensOrBetter}
+
+// ParsePaytable returns a built-in paytable by name, e.g. "jacks", or a custom paytable from the pays of the
+// Royal Flush down to One Pair separated by commas, e.g. "800,50,25,8,5,4,3,2,1", paying pairs of jacks or better.
+func ParsePaytable(s string) (Paytable, error) {
+	for _, p := range Paytables {
+		if strings.EqualFold(s, p.Name) {
+			return p, nil
+		}
+	}
+
+	fields := strings.Split(s, ",")
+	if len(fields) != 9 {
+		names := make([]string, len(Paytables))
+		for i, p := range Paytables {
+			names[i] = p.Name
+		}
+		return Paytable{}, fmt.Errorf("unknown paytable: %s. paytable should be one of %v or 9 pays from Royal Flush to One Pair", s, names)
+	}
+
+	p := Paytable{Name: "custom", Pays: make(map[int]float64), MinPair: JacksOrBetter.MinPair}
+	for i, field := range fields {
+		pay, err := strconv.ParseFloat(strings.TrimSpace(field), 64)
+		if err != nil || pay < 0 {
+			return Paytable{}, fmt.Errorf("invalid pay of %s: %s", RankName(i+1), field)
+		}
+		p.Pays[i+1] = pay
+	}
+
+	return p, nil
+}
+
+// Value implements DrawObjective.
+func (p Paytable) Value(cards []types.Card) float64 {
+	rankOrder, kickers := Standard.classify(cards)
+	if rankOrder == 9 && kickers[0] < types.RankMap[p.MinPair] {
+		return 0
+	}
+
+	return p.Pays[rankOrder]
+}
+
+// Dead implements DrawObjective. A paytable has no dead cards.
+func (p Paytable) Dead() []types.Card {
+	return nil
+}
+
+// OpponentEquity is a DrawObjective that values a hand by its share of the pot against the final five cards of an opponent,
+// e.g. an opponent who stood pat: 1 for a win, 0.5 for a tie and 0 for a loss. The cards of the opponent cannot be drawn.
+type OpponentEquity struct {
+	cards []types.Card
+	score int
+}
+
+// NewOpponentEquity creates an OpponentEquity against the five cards of an opponent.
+// It returns an error if the cards are not a valid hand.
+func NewOpponentEquity(cards []types.Card) (OpponentEquity, error) {
+	h := Hand{Cards: cards}
+	if err := h.Validate(); err != nil {
+		return OpponentEquity{}, fmt.Errorf("opponent: %w", err)
+	}
+
+	return OpponentEquity{cards: cards, score: h.Score()}, nil
+}
+
+// Value implements DrawObjective.
+func (o OpponentEquity) Value(cards []types.Card) float64 {
+	switch score := scoreCards(cards); {
+	case score < o.score:
+		return 1
+	case score == o.score:
+		return 0.5
+	default:
+		return 0
+	}
+}
+
+// Dead implements DrawObjective. The cards of the opponent are dead.
+func (o OpponentEquity) Dead() []types.Card {
+	return o.cards
+}
+
+// Hold is a choice of the cards to keep from a five-card hand, and its expected value under a DrawObjective.
+// Discards are the indexes of the cards thrown away, and Draws is the number of equally likely redraws the EV averages.
+type Hold struct {
+	Keep     []types.Card
+	Discards []int
+	EV       float64
+	Draws    int
+}
+
+// AdviseDraw computes the expected value of each of the 32 ways to hold and discard the cards of a five-card hand,
+// by enumerating every redraw from the cards left in the deck and averaging their value under the objective.
+// The holds are sorted from the highest EV, so the first one is the optimal hold. Holds with the same EV keep the most cards first.
+// It returns an error if the hand is not valid, or a dead card is invalid or in the hand.
+func (h Hand) AdviseDraw(objective DrawObjective) ([]Hold, error) {
+	if err := h.Validate(); err != nil {
+		return nil, err
+	}
+
+	dead := objective.Dead()
+	known := Hand{HandID: h.HandID, Cards: append(append([]types.Card(nil), h.Cards...), dead...)}
+	if err := known.validateCards(false); err != nil {
+		return nil, err
+	}
+
+	deck := NewDeck(nil)
+	deck.Remove(known.Cards...)
+
+	holds := make([]Hold, 0, 1<<handCardCount)
+	final := make([]types.Card, handCardCount)
+	for mask := 0; mask < 1<<handCardCount; mask++ {
+		// bit i of the mask discards the card at index i
+		hold := Hold{}
+		for i, c := range h.Cards {
+			if mask&(1<<i) != 0 {
+				hold.Discards = append(hold.Discards, i)
+			} else {
+				hold.Keep = append(hold.Keep, c)
+			}
+		}
+
+		copy(final, hold.Keep)
+		total := 0.0
+		enumerate(deck.Cards, len(hold.Discards), func(drawn []types.Card) {
+			copy(final[len(hold.Keep):], drawn)
+			total += objective.Value(final)
+			hold.Draws++
+		})
+		hold.EV = total / float64(hold.Draws)
+
+		holds = append(holds, hold)
+	}
+
+	sort.SliceStable(holds, func(i, j int) bool {
+		if holds[i].EV != holds[j].EV {
+			return holds[i].EV > holds[j].EV
+		}
+		return len(holds[i].Discards) < len(holds[j].Discards)
+	})
+
+	return holds, nil
+}
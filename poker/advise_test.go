@@ -0,0 +1,190 @@
+package poker
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestParsePaytable(t *testing.T) {
+	tests := []struct {
+		name    string
+		s       string
+		want    Paytable
+		wantErr bool
+	}{
+		{name: "jacks or better", s: "jacks", want: JacksOrBetter},
+		{name: "name is case insensitive", s: "Tens", want: TensOrBetter},
+		{
+			name: "custom pays",
+			s:    "800, 50, 25, 8, 5, 4, 3, 2, 1",
+			want: Paytable{Name: "custom", Pays: map[int]float64{1: 800, 2: 50, 3: 25, 4: 8, 5: 5, 6: 4, 7: 3, 8: 2, 9: 1}, MinPair: "J"},
+		},
+		{name: "unknown name", s: "bonus", wantErr: true},
+		{name: "too few pays", s: "800,50,25,9,6,4,3,2", wantErr: true},
+		{name: "invalid pay", s: "800,50,25,9,six,4,3,2,1", wantErr: true},
+		{name: "negative pay", s: "800,50,25,9,6,4,3,2,-1", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParsePaytable(tt.s)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParsePaytable() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParsePaytable() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPaytable_Value(t *testing.T) {
+	tests := []struct {
+		name     string
+		paytable Paytable
+		cards    string
+		want     float64
+	}{
+		{name: "royal flush", paytable: JacksOrBetter, cards: "ASKSQSJSTS", want: 800},
+		{name: "full house", paytable: JacksOrBetter, cards: "2S2D2HKCKD", want: 9},
+		{name: "jacks pay", paytable: JacksOrBetter, cards: "JSJD4H7C9D", want: 1},
+		{name: "tens do not pay in jacks or better", paytable: JacksOrBetter, cards: "TSTD4H7C9D", want: 0},
+		{name: "tens pay in tens or better", paytable: TensOrBetter, cards: "TSTD4H7C9D", want: 1},
+		{name: "any pair pays without a minimum", paytable: Paytable{Pays: map[int]float64{9: 1}}, cards: "2S2D4H7C9D", want: 1},
+		{name: "high card", paytable: JacksOrBetter, cards: "AS3D4H7C9D", want: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.paytable.Value(mustCards(t, tt.cards)); got != tt.want {
+				t.Errorf("Paytable.Value() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOpponentEquity_Value(t *testing.T) {
+	o, err := NewOpponentEquity(mustCards(t, "3C3D3H7S8S"))
+	if err != nil {
+		t.Fatalf("NewOpponentEquity() error = %v", err)
+	}
+
+	tests := []struct {
+		name  string
+		cards string
+		want  float64
+	}{
+		{name: "higher trips win", cards: "ASADAH2C9D", want: 1},
+		{name: "two pair loses", cards: "ASADKCKH2S", want: 0},
+		{name: "same hand ties", cards: "3C3D3H7D8D", want: 0.5},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := o.Value(mustCards(t, tt.cards)); got != tt.want {
+				t.Errorf("OpponentEquity.Value() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	if _, err := NewOpponentEquity(mustCards(t, "3C3D3H7S")); err == nil {
+		t.Errorf("NewOpponentEquity() of 4 cards error = nil, want error")
+	}
+}
+
+func TestHand_AdviseDraw(t *testing.T) {
+	if testing.Short() {
+		t.Skip("enumerates every redraw of every hold")
+	}
+
+	opponent, err := NewOpponentEquity(mustCards(t, "3C3D3H7S8S"))
+	if err != nil {
+		t.Fatalf("NewOpponentEquity() error = %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		cards     string
+		objective DrawObjective
+		wantKeep  string
+		wantEV    float64
+		wantDraws int
+	}{
+		{name: "dealt royal flush", cards: "ASKSQSJSTS", objective: JacksOrBetter, wantKeep: "ASKSQSJSTS", wantEV: 800, wantDraws: 1},
+		// 1 royal flush, 8 flushes, 3 straights and 12 high pairs out of 47 cards
+		{name: "four to a royal flush", cards: "ASKSQSJS2D", objective: JacksOrBetter, wantKeep: "ASKSQSJS", wantEV: 872.0 / 47, wantDraws: 47},
+		{name: "pair of aces against trips", cards: "ASAD9C7H2S", objective: opponent, wantKeep: "ASAD"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			holds, err := Hand{Cards: mustCards(t, tt.cards)}.AdviseDraw(tt.objective)
+			if err != nil {
+				t.Fatalf("Hand.AdviseDraw() error = %v", err)
+			}
+			if len(holds) != 32 {
+				t.Fatalf("Hand.AdviseDraw() returned %d holds, want 32", len(holds))
+			}
+
+			best := holds[0]
+			if keep := mustCards(t, tt.wantKeep); !reflect.DeepEqual(best.Keep, keep) {
+				t.Errorf("best hold = %v, want %v", best.Keep, keep)
+			}
+			if tt.wantDraws > 0 && best.Draws != tt.wantDraws {
+				t.Errorf("best hold draws = %d, want %d", best.Draws, tt.wantDraws)
+			}
+			if tt.wantEV > 0 && math.Abs(best.EV-tt.wantEV) > 1e-9 {
+				t.Errorf("best hold EV = %v, want %v", best.EV, tt.wantEV)
+			}
+			for i := 1; i < len(holds); i++ {
+				if holds[i].EV > holds[i-1].EV {
+					t.Errorf("hold %d has a higher EV than hold %d", i, i-1)
+				}
+			}
+		})
+	}
+}
+
+func TestHand_AdviseDraw_TwoPairAgainstTrips(t *testing.T) {
+	opponent, err := NewOpponentEquity(mustCards(t, "3C3D3H7S8S"))
+	if err != nil {
+		t.Fatalf("NewOpponentEquity() error = %v", err)
+	}
+
+	holds, err := Hand{Cards: mustCards(t, "ASADKCKH2S")}.AdviseDraw(opponent)
+	if err != nil {
+		t.Fatalf("Hand.AdviseDraw() error = %v", err)
+	}
+
+	// two pair only wins by filling up with one of the 2 aces and 2 kings left in the 42 unseen cards
+	for _, h := range holds {
+		if reflect.DeepEqual(h.Discards, []int{4}) {
+			if h.Draws != 42 || math.Abs(h.EV-4.0/42) > 1e-9 {
+				t.Errorf("two pair hold = %v over %d draws, want %v over 42", h.EV, h.Draws, 4.0/42)
+			}
+			return
+		}
+	}
+	t.Errorf("no hold discards only the deuce")
+}
+
+func TestHand_AdviseDraw_Invalid(t *testing.T) {
+	opponent, err := NewOpponentEquity(mustCards(t, "3C3D3H7S8S"))
+	if err != nil {
+		t.Fatalf("NewOpponentEquity() error = %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		cards     string
+		objective DrawObjective
+	}{
+		{name: "four cards", cards: "ASKSQSJS", objective: JacksOrBetter},
+		{name: "repeated card", cards: "ASASQSJSTS", objective: JacksOrBetter},
+		{name: "card of the opponent", cards: "3CKSQSJSTS", objective: opponent},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := (Hand{Cards: mustCards(t, tt.cards)}).AdviseDraw(tt.objective); err == nil {
+				t.Errorf("Hand.AdviseDraw() error = nil, want error")
+			}
+		})
+	}
+}
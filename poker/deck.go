@@ -0,0 +1,83 @@
+package poker
+
+import (
+	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/YoungsoonLee/poker/types"
+)
+
+// Deck represents a deck of playing cards.
+// Cards are dealt from the top of the deck, which is the front of the Cards slice.
+type Deck struct {
+	Cards []types.Card
+	rng   *rand.Rand
+}
+
+// NewDeck creates a new ordered deck of 52 cards.
+// The given random number generator is used for shuffling.
+// If rng is nil, a generator seeded with the current time is used.
+func NewDeck(rng *rand.Rand) *Deck {
+	if rng == nil {
+		rng = rand.New(rand.NewSource(time.Now().UnixNano()))
+	}
+
+	cards := make([]types.Card, 0, len(types.Ranks)*len(types.Suits))
+	for _, suit := range types.Suits {
+		for _, rank := range types.Ranks {
+			cards = append(cards, types.Card{Rank: rank, Suit: suit})
+		}
+	}
+
+	return &Deck{Cards: cards, rng: rng}
+}
+
+// Shuffle shuffles the remaining cards in the deck.
+func (d *Deck) Shuffle() {
+	d.rng.Shuffle(len(d.Cards), func(i, j int) {
+		d.Cards[i], d.Cards[j] = d.Cards[j], d.Cards[i]
+	})
+}
+
+// Len returns the number of cards remaining in the deck.
+func (d *Deck) Len() int {
+	return len(d.Cards)
+}
+
+// Deal removes n cards from the top of the deck and returns them.
+// It returns an error if the deck does not have enough cards.
+func (d *Deck) Deal(n int) ([]types.Card, error) {
+	if n < 0 || n > len(d.Cards) {
+		return nil, fmt.Errorf("cannot deal %d cards from a deck of %d cards", n, len(d.Cards))
+	}
+
+	cards := make([]types.Card, n)
+	copy(cards, d.Cards[:n])
+	d.Cards = d.Cards[n:]
+
+	return cards, nil
+}
+
+// Remove removes the given cards from the deck, e.g. cards already known to be in play.
+// Cards that are not in the deck are ignored.
+func (d *Deck) Remove(cards ...types.Card) {
+	remaining := d.Cards[:0]
+	for _, c := range d.Cards {
+		if !containsCard(cards, c) {
+			remaining = append(remaining, c)
+		}
+	}
+	d.Cards = remaining
+}
+
+// containsCard reports whether the card is in the given cards.
+func containsCard(cards []types.Card, card types.Card) bool {
+	for _, c := range cards {
+		if c == card {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,91 @@
+package poker
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/YoungsoonLee/poker/types"
+)
+
+func TestNewDeck(t *testing.T) {
+	d := NewDeck(rand.New(rand.NewSource(1)))
+	d.Shuffle()
+
+	if d.Len() != 52 {
+		t.Fatalf("NewDeck() = %v cards, want %v", d.Len(), 52)
+	}
+
+	seen := make(map[types.Card]bool)
+	for _, c := range d.Cards {
+		if seen[c] {
+			t.Errorf("NewDeck() has duplicate card %v", c)
+		}
+		seen[c] = true
+	}
+}
+
+func TestDeck_Deal(t *testing.T) {
+	tests := []struct {
+		name    string
+		n       int
+		wantLen int
+		wantErr bool
+	}{
+		{
+			name:    "deal five cards",
+			n:       5,
+			wantLen: 47,
+			wantErr: false,
+		},
+		{
+			name:    "deal whole deck",
+			n:       52,
+			wantLen: 0,
+			wantErr: false,
+		},
+		{
+			name:    "deal too many cards",
+			n:       53,
+			wantLen: 52,
+			wantErr: true,
+		},
+		{
+			name:    "deal negative cards",
+			n:       -1,
+			wantLen: 52,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := NewDeck(nil)
+			got, err := d.Deal(tt.n)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Deck.Deal() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && len(got) != tt.n {
+				t.Errorf("Deck.Deal() = %v cards, want %v", len(got), tt.n)
+			}
+			if d.Len() != tt.wantLen {
+				t.Errorf("Deck.Len() = %v, want %v", d.Len(), tt.wantLen)
+			}
+		})
+	}
+}
+
+func TestDeck_Remove(t *testing.T) {
+	d := NewDeck(nil)
+	removed := []types.Card{{Rank: "A", Suit: "S"}, {Rank: "K", Suit: "H"}, {Rank: "X", Suit: "S"}}
+
+	d.Remove(removed...)
+
+	if d.Len() != 50 {
+		t.Errorf("Deck.Remove() left %v cards, want %v", d.Len(), 50)
+	}
+	for _, c := range d.Cards {
+		if c == removed[0] || c == removed[1] {
+			t.Errorf("Deck.Remove() did not remove %v", c)
+		}
+	}
+}
@@ -0,0 +1,296 @@
+package poker
+
+import (
+	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/YoungsoonLee/poker/types"
+)
+
+// boardCardCount is the number of community cards in Texas Hold'em.
+const boardCardCount = 5
+
+// Equity is the share of the pot a hand wins in Texas Hold'em.
+// Win is the fraction of boards the hand wins outright, Tie the fraction it splits,
+// and Equity the share of the pot with split pots divided between the tied hands.
+type Equity struct {
+	Win    float64
+	Tie    float64
+	Equity float64
+}
+
+// CalculateEquity calculates the equity of each of the hole cards against the others with the given board.
+// If the number of possible run-outs is not bigger than iterations, every run-out is enumerated and the result is exact.
+// Otherwise iterations random run-outs are dealt. If rng is nil, a generator seeded with the current time is used.
+func CalculateEquity(holes [][]types.Card, board []types.Card, iterations int, rng *rand.Rand) ([]Equity, error) {
+	return Standard.CalculateEquity(holes, board, iterations, rng)
+}
+
+// CalculateEquity calculates the equity of each of the hole cards like CalculateEquity,
+// dealing the run-outs from the deck of the variant and ranking the hands by its rules.
+func (v Variant) CalculateEquity(holes [][]types.Card, board []types.Card, iterations int, rng *rand.Rand) ([]Equity, error) {
+	if len(holes) < 2 {
+		return nil, fmt.Errorf("need at least 2 hands to calculate equity, got %d", len(holes))
+	}
+
+	dead := append([]types.Card(nil), board...)
+	for _, hole := range holes {
+		dead = append(dead, hole...)
+	}
+	if err := v.validateEquityCards(holes, board, dead); err != nil {
+		return nil, err
+	}
+
+	deck := v.NewDeck(rng)
+	deck.Remove(dead...)
+	need := boardCardCount - len(board)
+	if deck.Len() < need {
+		return nil, fmt.Errorf("%d hands and a board of %d cards leave %d cards in the deck of %s, but the board needs %d more", len(holes), len(board), deck.Len(), v, need)
+	}
+
+	wins := make([]float64, len(holes))
+	ties := make([]float64, len(holes))
+	shares := make([]float64, len(holes))
+	total := 0
+
+	showdown := func(runout []types.Card) {
+		total++
+		winners := v.showdownWinners(holes, runout)
+		for _, w := range winners {
+			if len(winners) == 1 {
+				wins[w]++
+			} else {
+				ties[w]++
+			}
+			shares[w] += 1 / float64(len(winners))
+		}
+	}
+
+	full := make([]types.Card, boardCardCount)
+	copy(full, board)
+
+	if combinations(deck.Len(), need) <= iterations {
+		enumerate(deck.Cards, need, func(cards []types.Card) {
+			copy(full[len(board):], cards)
+			showdown(full)
+		})
+	} else {
+		if iterations < 1 {
+			return nil, fmt.Errorf("invalid number of iterations: %d", iterations)
+		}
+		for i := 0; i < iterations; i++ {
+			sampleCards(deck, full[len(board):])
+			showdown(full)
+		}
+	}
+
+	equities := make([]Equity, len(holes))
+	for i := range equities {
+		equities[i] = Equity{
+			Win:    wins[i] / float64(total),
+			Tie:    ties[i] / float64(total),
+			Equity: shares[i] / float64(total),
+		}
+	}
+
+	return equities, nil
+}
+
+// RangeEquity calculates the equity of the hole cards against a random hand from the villain's range with the given board.
+// Every iteration picks a combo of the range that does not conflict with the known cards, and deals a random run-out.
+// If rng is nil, a generator seeded with the current time is used.
+func RangeEquity(hole []types.Card, villain Range, board []types.Card, iterations int, rng *rand.Rand) (Equity, error) {
+	return Standard.RangeEquity(hole, villain, board, iterations, rng)
+}
+
+// RangeEquity calculates the equity of the hole cards against a range like RangeEquity, under the rules of the variant.
+// Combos of the range with cards that are not in the deck of the variant are left out.
+func (v Variant) RangeEquity(hole []types.Card, villain Range, board []types.Card, iterations int, rng *rand.Rand) (Equity, error) {
+	if iterations < 1 {
+		return Equity{}, fmt.Errorf("invalid number of iterations: %d", iterations)
+	}
+
+	dead := append(append([]types.Card(nil), hole...), board...)
+	if err := v.validateEquityCards([][]types.Card{hole}, board, dead); err != nil {
+		return Equity{}, err
+	}
+
+	var combos Range
+	for _, c := range villain.Without(dead...) {
+		if v.hasRank(c[0].Rank) && v.hasRank(c[1].Rank) {
+			combos = append(combos, c)
+		}
+	}
+	if len(combos) == 0 {
+		return Equity{}, fmt.Errorf("villain range has no combos left after removing the known cards")
+	}
+
+	if rng == nil {
+		rng = rand.New(rand.NewSource(time.Now().UnixNano()))
+	}
+
+	deck := v.NewDeck(rng)
+	deck.Remove(dead...)
+
+	var result Equity
+	full := make([]types.Card, boardCardCount)
+	copy(full, board)
+	stub := &Deck{rng: rng}
+
+	for i := 0; i < iterations; i++ {
+		combo := combos[rng.Intn(len(combos))]
+
+		stub.Cards = stub.Cards[:0]
+		for _, c := range deck.Cards {
+			if c != combo[0] && c != combo[1] {
+				stub.Cards = append(stub.Cards, c)
+			}
+		}
+		sampleCards(stub, full[len(board):])
+
+		winners := v.showdownWinners([][]types.Card{hole, combo.Cards()}, full)
+		switch {
+		case len(winners) == 2:
+			result.Tie++
+			result.Equity += 0.5
+		case winners[0] == 0:
+			result.Win++
+			result.Equity++
+		}
+	}
+
+	result.Win /= float64(iterations)
+	result.Tie /= float64(iterations)
+	result.Equity /= float64(iterations)
+
+	return result, nil
+}
+
+// validateEquityCards checks the hole cards and the board, and that no card is used twice or is not in the deck.
+func (v Variant) validateEquityCards(holes [][]types.Card, board []types.Card, dead []types.Card) error {
+	for i, hole := range holes {
+		if len(hole) != 2 {
+			return fmt.Errorf("hand %d: hole should have 2 cards, got %d", i+1, len(hole))
+		}
+	}
+
+	if len(board) > boardCardCount || len(board) == 1 || len(board) == 2 {
+		return fmt.Errorf("board should have 0, 3, 4 or 5 cards, got %d", len(board))
+	}
+
+	seen := make(map[types.Card]bool)
+	for _, c := range dead {
+		if c.IsJoker() && v.Joker {
+			if seen[c] {
+				return fmt.Errorf("card %s is used more than once", c)
+			}
+			seen[c] = true
+			continue
+		}
+		if _, ok := types.RankMap[c.Rank]; !ok {
+			return fmt.Errorf("invalid card: %s", c)
+		}
+		if !v.hasRank(c.Rank) {
+			return fmt.Errorf("card %s is not used in %s", c, v)
+		}
+		if _, ok := types.SuitMap[c.Suit]; !ok {
+			return fmt.Errorf("invalid card: %s", c)
+		}
+		if seen[c] {
+			return fmt.Errorf("card %s is used more than once", c)
+		}
+		seen[c] = true
+	}
+
+	return nil
+}
+
+// showdownWinners returns the indexes of the hole cards that make the best hand with the board.
+func (v Variant) showdownWinners(holes [][]types.Card, board []types.Card) []int {
+	var winners []int
+	best := 0
+
+	cards := make([]types.Card, 0, len(board)+2)
+	for i, hole := range holes {
+		cards = append(append(cards[:0], hole...), board...)
+		score := v.bestScore(cards)
+
+		switch {
+		case len(winners) == 0 || score < best:
+			winners, best = []int{i}, score
+		case score == best:
+			winners = append(winners, i)
+		}
+	}
+
+	return winners
+}
+
+// bestScore returns the score of the strongest five card hand that can be made from the cards.
+// It is the allocation free version of BestHand used by the equity calculations.
+func (v Variant) bestScore(cards []types.Card) int {
+	best := 0
+	var combination [handCardCount]types.Card
+
+	var choose func(start, depth int)
+	choose = func(start, depth int) {
+		if depth == handCardCount {
+			if _, score := v.rankCards(combination[:]); best == 0 || score < best {
+				best = score
+			}
+			return
+		}
+
+		for i := start; i <= len(cards)-(handCardCount-depth); i++ {
+			combination[depth] = cards[i]
+			choose(i+1, depth+1)
+		}
+	}
+	choose(0, 0)
+
+	return best
+}
+
+// sampleCards fills out with random cards from the deck without removing them from the deck.
+func sampleCards(d *Deck, out []types.Card) {
+	// partial Fisher-Yates shuffle of the first len(out) cards
+	for i := range out {
+		j := i + d.rng.Intn(len(d.Cards)-i)
+		d.Cards[i], d.Cards[j] = d.Cards[j], d.Cards[i]
+		out[i] = d.Cards[i]
+	}
+}
+
+// enumerate calls f with every combination of n cards from the given cards.
+func enumerate(cards []types.Card, n int, f func([]types.Card)) {
+	combination := make([]types.Card, n)
+
+	var choose func(start, depth int)
+	choose = func(start, depth int) {
+		if depth == n {
+			f(combination)
+			return
+		}
+
+		for i := start; i <= len(cards)-(n-depth); i++ {
+			combination[depth] = cards[i]
+			choose(i+1, depth+1)
+		}
+	}
+	choose(0, 0)
+}
+
+// combinations returns the binomial coefficient n choose k.
+func combinations(n, k int) int {
+	if k < 0 || k > n {
+		return 0
+	}
+
+	result := 1
+	for i := 1; i <= k; i++ {
+		result = result * (n - k + i) / i
+	}
+
+	return result
+}
@@ -0,0 +1,124 @@
+package poker
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/YoungsoonLee/poker/types"
+)
+
+func TestCalculateEquity(t *testing.T) {
+	tests := []struct {
+		name       string
+		holes      []string
+		board      string
+		iterations int
+		want       []float64
+		tolerance  float64
+		wantErr    bool
+	}{
+		{
+			name:       "aces against kings preflop",
+			holes:      []string{"AsAh", "KsKh"},
+			iterations: 20000,
+			want:       []float64{0.82, 0.18},
+			tolerance:  0.015,
+		},
+		{
+			name:       "made flush on the river",
+			holes:      []string{"AhKh", "QsQc"},
+			board:      "2h7h9hQd3c",
+			iterations: 1,
+			want:       []float64{1, 0},
+		},
+		{
+			name:       "same hand splits the pot",
+			holes:      []string{"AhKd", "AsKc"},
+			board:      "2h7s9dQd3c",
+			iterations: 1,
+			want:       []float64{0.5, 0.5},
+		},
+		{
+			name:       "flush draw on the turn is exact",
+			holes:      []string{"AhKh", "QsQc"},
+			board:      "2h7h9dTs",
+			iterations: 100,
+			// 9 hearts, 3 aces and 3 kings out of 44 cards
+			want: []float64{15.0 / 44, 29.0 / 44},
+		},
+		{
+			name:    "card used twice",
+			holes:   []string{"AhKh", "AhQc"},
+			wantErr: true,
+		},
+		{
+			name:    "one hand",
+			holes:   []string{"AhKh"},
+			wantErr: true,
+		},
+		{
+			name:    "two card board",
+			holes:   []string{"AhKh", "QsQc"},
+			board:   "2c3c",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			holes := make([][]types.Card, len(tt.holes))
+			for i, h := range tt.holes {
+				holes[i], _ = types.ParseCards(h)
+			}
+			board, _ := types.ParseCards(tt.board)
+
+			got, err := CalculateEquity(holes, board, tt.iterations, rand.New(rand.NewSource(1)))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("CalculateEquity() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+
+			for i, want := range tt.want {
+				if math.Abs(got[i].Equity-want) > tt.tolerance+1e-9 {
+					t.Errorf("CalculateEquity() hand %d = %v, want %v", i+1, got[i].Equity, want)
+				}
+			}
+		})
+	}
+}
+
+func TestRangeEquity(t *testing.T) {
+	hole, _ := types.ParseCards("AsAh")
+
+	tests := []struct {
+		name      string
+		villain   string
+		want      float64
+		tolerance float64
+		wantErr   bool
+	}{
+		{name: "aces against kings", villain: "KK", want: 0.82, tolerance: 0.02},
+		{name: "aces against any two cards", villain: "any", want: 0.85, tolerance: 0.02},
+		{name: "no combos left", villain: "AsAh", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			villain, err := ParseRange(tt.villain)
+			if err != nil {
+				t.Fatalf("ParseRange() error = %v", err)
+			}
+
+			got, err := RangeEquity(hole, villain, nil, 10000, rand.New(rand.NewSource(1)))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("RangeEquity() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && math.Abs(got.Equity-tt.want) > tt.tolerance {
+				t.Errorf("RangeEquity() = %v, want %v", got.Equity, tt.want)
+			}
+		})
+	}
+}
@@ -90,6 +90,49 @@ func (h Hand) HasValidSuits() bool {
 	return true
 }
 
+// Validate checks that the hand can be evaluated: it has exactly five cards, every card has a valid rank and suit,
+// and no card appears twice. It returns an error describing the first problem found, or nil if the hand is valid.
+// A hand from NewHand is invalid until its cards are set.
+func (h Hand) Validate() error {
+	return h.validate(false)
+}
+
+// validate checks the hand like Validate, accepting the joker if joker is set.
+func (h Hand) validate(joker bool) error {
+	if len(h.Cards) != handCardCount {
+		return fmt.Errorf("hand %d: should have %d cards, got %d", h.HandID, handCardCount, len(h.Cards))
+	}
+
+	return h.validateCards(joker)
+}
+
+// validateCards checks that every card of the hand has a valid rank and suit and that no card appears twice,
+// whatever the number of cards. The joker is accepted if joker is set.
+func (h Hand) validateCards(joker bool) error {
+	for i, card := range h.Cards {
+		if card == (types.Card{}) {
+			return fmt.Errorf("hand %d: card %d is not set", h.HandID, i+1)
+		}
+		if joker && card.IsJoker() {
+			if containsCard(h.Cards[:i], card) {
+				return fmt.Errorf("hand %d: card %s is used more than once", h.HandID, card)
+			}
+			continue
+		}
+		if _, ok := types.RankMap[card.Rank]; !ok {
+			return fmt.Errorf("hand %d: card %d has the invalid rank %q. rank should be 2,3,4,5,6,7,8,9,T,J,Q,K,A", h.HandID, i+1, card.Rank)
+		}
+		if _, ok := types.SuitMap[card.Suit]; !ok {
+			return fmt.Errorf("hand %d: card %d has the invalid suit %q. suit should be S,H,D,C", h.HandID, i+1, card.Suit)
+		}
+		if containsCard(h.Cards[:i], card) {
+			return fmt.Errorf("hand %d: card %s is used more than once", h.HandID, card)
+		}
+	}
+
+	return nil
+}
+
 // ExtractRanksToInt extracts the ranks of the cards in the hand and returns them as a sorted slice of integers.
 // The ranks are mapped to their corresponding integer values using the RankMap defined in the types package.
 func (h Hand) ExtractRanksToInt() []int {
@@ -125,10 +168,19 @@ func (h Hand) ExtractSuits() []string {
 
 // IsFlush checks if the hand is a flush, which means all cards have the same suit.
 // It returns true if all cards have the same suit, and false otherwise.
+// Like the other checks it is only true for a hand of exactly five cards.
 func (h Hand) IsFlush() bool {
-	suits := h.ExtractSuits()
+	if len(h.Cards) != handCardCount {
+		return false
+	}
 
-	return suits[0] == suits[1] && suits[1] == suits[2] && suits[2] == suits[3] && suits[3] == suits[4]
+	for _, card := range h.Cards {
+		if card.Suit != h.Cards[0].Suit {
+			return false
+		}
+	}
+
+	return true
 }
 
 // IsStraight checks if the hand represents a straight in poker.
@@ -137,6 +189,9 @@ func (h Hand) IsFlush() bool {
 // Returns true if the hand is a straight, false otherwise.
 func (h Hand) IsStraight() bool {
 	ranks := h.ExtractRanksToInt()
+	if len(ranks) != handCardCount {
+		return false
+	}
 
 	// check low straight
 	if ranks[0] == 2 && ranks[1] == 3 && ranks[2] == 4 && ranks[3] == 5 && ranks[4] == 14 {
@@ -152,6 +207,9 @@ func (h Hand) IsStraight() bool {
 // Returns true if the hand is a royal flush, false otherwise.
 func (h Hand) IsRoyalFlush() bool {
 	ranks := h.ExtractRanksToInt()
+	if len(ranks) != handCardCount {
+		return false
+	}
 
 	return (ranks[0] == 10 && ranks[1] == 11 && ranks[2] == 12 && ranks[3] == 13 && ranks[4] == 14) && h.IsFlush()
 }
@@ -167,6 +225,9 @@ func (h Hand) IsStraightFlush() bool {
 // It returns true if the hand has four of a kind, otherwise it returns false.
 func (h Hand) IsFourOfAKind() bool {
 	ranks := h.ExtractRanksToInt()
+	if len(ranks) != handCardCount {
+		return false
+	}
 
 	return (ranks[0] == ranks[1] && ranks[1] == ranks[2] && ranks[2] == ranks[3]) || (ranks[1] == ranks[2] && ranks[2] == ranks[3] && ranks[3] == ranks[4])
 }
@@ -175,6 +236,9 @@ func (h Hand) IsFourOfAKind() bool {
 // It returns true if the hand has three cards of the same rank, otherwise false.
 func (h Hand) IsThreeOfAKind() bool {
 	ranks := h.ExtractRanksToInt()
+	if len(ranks) != handCardCount {
+		return false
+	}
 
 	return (ranks[0] == ranks[1] && ranks[1] == ranks[2]) || (ranks[1] == ranks[2] && ranks[2] == ranks[3]) || (ranks[2] == ranks[3] && ranks[3] == ranks[4])
 }
@@ -183,6 +247,9 @@ func (h Hand) IsThreeOfAKind() bool {
 // It returns true if the hand has two pairs, otherwise it returns false.
 func (h Hand) IsTwoPair() bool {
 	ranks := h.ExtractRanksToInt()
+	if len(ranks) != handCardCount {
+		return false
+	}
 
 	return (ranks[0] == ranks[1] && ranks[2] == ranks[3]) || (ranks[0] == ranks[1] && ranks[3] == ranks[4]) || (ranks[1] == ranks[2] && ranks[3] == ranks[4])
 }
@@ -191,6 +258,9 @@ func (h Hand) IsTwoPair() bool {
 // It returns true if a pair is found, otherwise it returns false.
 func (h Hand) IsOnePair() bool {
 	ranks := h.ExtractRanksToInt()
+	if len(ranks) != handCardCount {
+		return false
+	}
 
 	return (ranks[0] == ranks[1]) || (ranks[1] == ranks[2]) || (ranks[2] == ranks[3]) || (ranks[3] == ranks[4])
 }
@@ -203,15 +273,31 @@ func (h Hand) IsFullHouse() bool {
 }
 
 // HighCard returns the highest card rank in the hand.
-// It extracts the ranks of the cards in the hand and returns the highest rank.
+// It extracts the ranks of the cards in the hand and returns the highest rank, or an empty string for a hand without cards.
 func (h Hand) HighCard() string {
 	ranks := h.ExtractRanksToInt()
+	if len(ranks) == 0 {
+		return ""
+	}
 
-	return types.RankMapReverse[ranks[4]]
+	return types.RankMapReverse[ranks[len(ranks)-1]]
 }
 
+// InvalidRankOrder is the rank order Evaluate returns for a hand it cannot evaluate.
+// It is bigger than every rank order, so an invalid hand ranks below a high card.
+const InvalidRankOrder = 11
+
+// invalidHandName is the name Evaluate returns for a hand it cannot evaluate.
+const invalidHandName = "Invalid Hand"
+
 // Evaluate returns the name of the hand and its rank
+// A hand that does not have exactly five cards with valid ranks, e.g. a hand from NewHand, does not panic,
+// and is evaluated as "Invalid Hand" with InvalidRankOrder. Use EvaluateResult to get the reason.
 func (h Hand) Evaluate() (string, int) {
+	if len(h.Cards) != handCardCount || !h.HasValidRanks() {
+		return invalidHandName, InvalidRankOrder
+	}
+
 	if h.IsRoyalFlush() {
 		return "Royal Flush", 1
 	} else if h.IsStraightFlush() {
@@ -235,6 +321,18 @@ func (h Hand) Evaluate() (string, int) {
 	}
 }
 
+// EvaluateResult is like Evaluate, but validates the hand first and returns its full result including the score.
+// It returns an error instead of evaluating a malformed hand, so it is safe to use with untrusted input.
+func (h Hand) EvaluateResult() (HandResult, error) {
+	if err := h.Validate(); err != nil {
+		return HandResult{}, err
+	}
+
+	rank, rankOrder := h.Evaluate()
+
+	return HandResult{HandID: h.HandID, Card: h.Cards, Rank: rank, RankOrder: rankOrder, Score: h.Score()}, nil
+}
+
 // Hands represents a collection of Hand objects.
 type Hands []Hand
 
@@ -255,7 +353,7 @@ func RandomCardsToHands(num int) Hands {
 	return hands
 }
 
-// MinHeap is a type representing a minimum heap of Hand objects.
+// MinHeap is a type representing a minimum heap of Hand objects, ordered by their scores. See Hand.Score.
 type MinHeap []Hand
 
 func (h MinHeap) Len() int {
@@ -263,10 +361,7 @@ func (h MinHeap) Len() int {
 }
 
 func (h MinHeap) Less(i, j int) bool {
-	_, r1 := h[i].Evaluate()
-	_, r2 := h[j].Evaluate()
-
-	return r1 < r2
+	return h[i].Score() < h[j].Score()
 }
 
 func (h MinHeap) Swap(i, j int) {
@@ -292,15 +387,20 @@ func (h *MinHeap) Pop() interface{} {
 // Card is the list of cards in the hand.
 // Rank is the rank of the hand.
 // RankOrder is the order of the hand's rank.
+// Score is the order of the hand including its kickers. See Hand.Score.
 type HandResult struct {
 	HandID    int
 	Card      []types.Card
 	Rank      string
 	RankOrder int
+	Score     int
 }
 
 // EvaluateHands evaluates a collection of hands and returns the hand with the highest rank (the smallest number of rank).
 // It's using minHeap to get the highest rank. the highest rank is the smallest number of rank.
+// The results are ordered by Score, so hands with the same rank are ordered by their kickers, e.g. a pair of aces
+// with a king kicker comes before a pair of aces with a queen kicker, and hands with the same score tie in any order.
+// A hand with missing or invalid cards, e.g. a hand from NewHand, is ranked after every complete hand.
 func EvaluateHands(hands Hands) []HandResult {
 	var minHeap MinHeap
 
@@ -319,6 +419,7 @@ func EvaluateHands(hands Hands) []HandResult {
 			Card:      hand.Cards,
 			Rank:      rank,
 			RankOrder: rankOrder,
+			Score:     hand.Score(),
 		})
 	}
 
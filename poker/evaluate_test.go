@@ -858,6 +858,18 @@ func TestHand_Evaluate(t *testing.T) {
 			want:     "High Card - {9}",
 			wantRank: 10,
 		},
+		{
+			name:     "hand from NewHand",
+			h:        NewHand(1),
+			want:     "Invalid Hand",
+			wantRank: InvalidRankOrder,
+		},
+		{
+			name:     "four cards",
+			h:        Hand{Cards: mustCards(t, "ASAHADAC")},
+			want:     "Invalid Hand",
+			wantRank: InvalidRankOrder,
+		},
 	}
 
 	for _, tt := range tests {
@@ -1005,3 +1017,122 @@ func TestHand_EvaluateHands(t *testing.T) {
 
 	}
 }
+
+func TestEvaluateHands_Order(t *testing.T) {
+	tests := []struct {
+		name  string
+		hands Hands
+		want  []int
+	}{
+		{
+			name:  "kickers break ties within a rank",
+			hands: Hands{{HandID: 1, Cards: mustCards(t, "ASAH9D5C2S")}, {HandID: 2, Cards: mustCards(t, "ADACKD5H2D")}, {HandID: 3, Cards: mustCards(t, "KSKHQDJC9S")}},
+			want:  []int{2, 1, 3},
+		},
+		{
+			name:  "last kicker breaks a tie",
+			hands: Hands{{HandID: 1, Cards: mustCards(t, "ASKHQDJC8S")}, {HandID: 2, Cards: mustCards(t, "ADKCQSJH9D")}},
+			want:  []int{2, 1},
+		},
+		{
+			name:  "full house by three of a kind before pair",
+			hands: Hands{{HandID: 1, Cards: mustCards(t, "2S2H2DACAS")}, {HandID: 2, Cards: mustCards(t, "3S3H3D4C4S")}},
+			want:  []int{2, 1},
+		},
+		{
+			name:  "an empty hand ranks last",
+			hands: Hands{{HandID: 1, Cards: mustCards(t, "2S3D4H5C7S")}, {HandID: 2}},
+			want:  []int{1, 2},
+		},
+		{
+			name:  "a hand from NewHand ranks last",
+			hands: Hands{NewHand(1), {HandID: 2, Cards: mustCards(t, "2S3D4H5C7S")}},
+			want:  []int{2, 1},
+		},
+		{
+			name:  "a short hand loses to a complete hand",
+			hands: Hands{{HandID: 1, Cards: mustCards(t, "ASKHQDJC")}, {HandID: 2, Cards: mustCards(t, "ASKHQDJC2S")}},
+			want:  []int{2, 1},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []int
+			for _, r := range EvaluateHands(tt.hands) {
+				got = append(got, r.HandID)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("EvaluateHands() order = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHand_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		h       Hand
+		wantErr string
+	}{
+		{name: "valid", h: Hand{HandID: 1, Cards: []types.Card{{Rank: "A", Suit: "S"}, {Rank: "K", Suit: "S"}, {Rank: "Q", Suit: "S"}, {Rank: "J", Suit: "S"}, {Rank: "T", Suit: "S"}}}},
+		{name: "too few cards", h: Hand{HandID: 1, Cards: []types.Card{{Rank: "A", Suit: "S"}}}, wantErr: "hand 1: should have 5 cards, got 1"},
+		{name: "no cards", h: Hand{HandID: 2}, wantErr: "hand 2: should have 5 cards, got 0"},
+		{name: "cards of a new hand are not set", h: NewHand(3), wantErr: "hand 3: card 1 is not set"},
+		{
+			name:    "invalid rank",
+			h:       Hand{HandID: 1, Cards: []types.Card{{Rank: "A", Suit: "S"}, {Rank: "1", Suit: "S"}, {Rank: "Q", Suit: "S"}, {Rank: "J", Suit: "S"}, {Rank: "T", Suit: "S"}}},
+			wantErr: `hand 1: card 2 has the invalid rank "1". rank should be 2,3,4,5,6,7,8,9,T,J,Q,K,A`,
+		},
+		{
+			name:    "lower case suit",
+			h:       Hand{HandID: 1, Cards: []types.Card{{Rank: "A", Suit: "S"}, {Rank: "K", Suit: "S"}, {Rank: "Q", Suit: "s"}, {Rank: "J", Suit: "S"}, {Rank: "T", Suit: "S"}}},
+			wantErr: `hand 1: card 3 has the invalid suit "s". suit should be S,H,D,C`,
+		},
+		{
+			name:    "same card twice",
+			h:       Hand{HandID: 1, Cards: []types.Card{{Rank: "A", Suit: "S"}, {Rank: "K", Suit: "S"}, {Rank: "Q", Suit: "S"}, {Rank: "J", Suit: "S"}, {Rank: "K", Suit: "S"}}},
+			wantErr: "hand 1: card KS is used more than once",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.h.Validate()
+			if tt.wantErr == "" && err != nil || tt.wantErr != "" && (err == nil || err.Error() != tt.wantErr) {
+				t.Errorf("Hand.Validate() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestHand_EvaluateResult(t *testing.T) {
+	tests := []struct {
+		name    string
+		h       Hand
+		want    HandResult
+		wantErr bool
+	}{
+		{
+			name: "full house",
+			h:    Hand{HandID: 7, Cards: []types.Card{{Rank: "2", Suit: "C"}, {Rank: "2", Suit: "D"}, {Rank: "2", Suit: "H"}, {Rank: "3", Suit: "C"}, {Rank: "3", Suit: "D"}}},
+			want: HandResult{HandID: 7, Rank: "Full House", RankOrder: 4},
+		},
+		{name: "short hand", h: Hand{HandID: 1, Cards: []types.Card{{Rank: "2", Suit: "C"}, {Rank: "2", Suit: "D"}}}, wantErr: true},
+		{name: "new hand", h: NewHand(1), wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.h.EvaluateResult()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Hand.EvaluateResult() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			tt.want.Card, tt.want.Score = tt.h.Cards, tt.h.Score()
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Hand.EvaluateResult() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,117 @@
+package poker
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/YoungsoonLee/poker/types"
+)
+
+// lowNames are the names of the categories of an ace-to-five low hand with paired ranks, starting with 1 for one pair.
+var lowNames = [...]string{"One Pair", "Two Pair", "Three of a Kind", "Full House", "Four of a Kind"}
+
+// lowRank returns the value of a rank in ace-to-five low, where the ace is 1 and the king 13.
+func lowRank(rank string) int {
+	if rank == "A" {
+		return 1
+	}
+
+	return types.RankMap[rank]
+}
+
+// lowRanks returns the category of the cards in ace-to-five low and their ranks ordered for breaking ties:
+// the most repeated ranks first, and then from the highest to the lowest.
+// The category is 0 for unpaired cards, 1 for one pair, up to 5 for four of a kind.
+func lowRanks(cards []types.Card) (int, []int) {
+	var counts [14]int
+	for _, c := range cards {
+		counts[lowRank(c.Rank)]++
+	}
+
+	var groups [handCardCount + 1]int
+	ranks := make([]int, 0, len(cards))
+	for n := handCardCount; n >= 1; n-- {
+		for r := 13; r >= 1; r-- {
+			if counts[r] == n {
+				groups[n]++
+				ranks = append(ranks, r)
+			}
+		}
+	}
+
+	var category int
+	switch {
+	case groups[4] > 0 || groups[5] > 0:
+		category = 5
+	case groups[3] > 0 && groups[2] > 0:
+		category = 4
+	case groups[3] > 0:
+		category = 3
+	case groups[2] > 1:
+		category = 2
+	case groups[2] > 0:
+		category = 1
+	}
+
+	return category, ranks
+}
+
+// LowScore returns a comparable number for the hand in ace-to-five low, as played in Razz.
+// The ace is the lowest card, and straights and flushes do not count, so the best hand is 5-4-3-2-A.
+// Like Score, a smaller score is a stronger hand, and unpaired hands beat paired ones.
+// Hands of fewer than five cards, e.g. the up cards of a Razz player, can be compared with hands of the same size,
+// and a missing card ranks above any card, so a hand with a missing card loses to the same hand with all five.
+func (h Hand) LowScore() int {
+	category, ranks := lowRanks(h.Cards)
+
+	score := category
+	for i := 0; i < handCardCount; i++ {
+		score <<= scoreKickerBits
+		if i < len(ranks) {
+			score |= ranks[i]
+		} else {
+			score |= missingKicker
+		}
+	}
+
+	return score
+}
+
+// EvaluateLow returns the name of the hand in ace-to-five low, e.g. "7-5-4-3-A" for an unpaired hand,
+// or the name of the category of a paired hand, e.g. "One Pair".
+func (h Hand) EvaluateLow() string {
+	category, ranks := lowRanks(h.Cards)
+	if category > 0 {
+		return lowNames[category-1]
+	}
+
+	names := make([]string, len(ranks))
+	for i, r := range ranks {
+		names[i] = types.RankMapReverse[r]
+		if r == 1 {
+			names[i] = "A"
+		}
+	}
+
+	return strings.Join(names, "-")
+}
+
+// BestLowHand returns the strongest five card ace-to-five low hand that can be made from the given cards,
+// e.g. the seven cards of a Razz player. It returns an error if fewer than five cards are given.
+func BestLowHand(handID int, cards []types.Card) (Hand, error) {
+	if len(cards) < handCardCount {
+		return Hand{}, fmt.Errorf("need at least %d cards to make a hand, got %d", handCardCount, len(cards))
+	}
+
+	var best Hand
+	bestScore := -1
+
+	enumerate(cards, handCardCount, func(combination []types.Card) {
+		hand := Hand{HandID: handID, Cards: combination}
+		if score := hand.LowScore(); bestScore < 0 || score < bestScore {
+			best, bestScore = Hand{HandID: handID, Cards: append([]types.Card(nil), combination...)}, score
+		}
+	})
+
+	return best, nil
+}
@@ -0,0 +1,76 @@
+package poker
+
+import (
+	"testing"
+)
+
+func TestHand_LowScore(t *testing.T) {
+	tests := []struct {
+		name string
+		a    string
+		b    string
+		want int
+	}{
+		{name: "the wheel is the best low", a: "5S4H3D2CAS", b: "6S4H3D2CAS", want: 1},
+		{name: "straights and flushes do not count", a: "5S4S3S2SAS", b: "6H4H3D2CAD", want: 1},
+		{name: "highest card decides", a: "8S7H6D5C4S", b: "9S4H3D2CAS", want: 1},
+		{name: "next card decides", a: "8S5H4D3C2S", b: "8S6H3D2CAS", want: 1},
+		{name: "ace is low", a: "KSQHJDTCAS", b: "KSQHJDTC9S", want: 1},
+		{name: "unpaired beats a pair", a: "KSQHJDTC9S", b: "ASAH2D3C4S", want: 1},
+		{name: "lower pair wins", a: "ASAH8D7C6S", b: "2S2H3D4C5S", want: 1},
+		{name: "same ranks tie", a: "7S5H4D3C2S", b: "7H5D4C3S2H", want: 0},
+		{name: "up cards", a: "AS2H", b: "AH3D", want: 1},
+		{name: "a missing card ranks above any card", a: "5S4H3D2C", b: "5S4H3D2CAS", want: -1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, b := Hand{Cards: mustCards(t, tt.a)}.LowScore(), Hand{Cards: mustCards(t, tt.b)}.LowScore()
+			got := 0
+			switch {
+			case a < b:
+				got = 1
+			case a > b:
+				got = -1
+			}
+			if got != tt.want {
+				t.Errorf("LowScore() of %s = %d and %s = %d, want %d", tt.a, a, tt.b, b, tt.want)
+			}
+		})
+	}
+}
+
+func TestHand_EvaluateLow(t *testing.T) {
+	tests := []struct {
+		name  string
+		cards string
+		want  string
+	}{
+		{name: "wheel", cards: "5S4S3S2SAS", want: "5-4-3-2-A"},
+		{name: "ten low", cards: "TS9H4D3CAS", want: "T-9-4-3-A"},
+		{name: "one pair", cards: "ASAH8D7C6S", want: "One Pair"},
+		{name: "full house", cards: "ASAHAD7C7S", want: "Full House"},
+		{name: "four of a kind", cards: "ASAHADAC7S", want: "Four of a Kind"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := (Hand{Cards: mustCards(t, tt.cards)}).EvaluateLow(); got != tt.want {
+				t.Errorf("EvaluateLow() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBestLowHand(t *testing.T) {
+	// both kings are left out of the best low
+	best, err := BestLowHand(1, mustCards(t, "KSKH8D5C3S2HAD"))
+	if err != nil {
+		t.Fatalf("BestLowHand() error = %v", err)
+	}
+	if got := best.EvaluateLow(); got != "8-5-3-2-A" {
+		t.Errorf("BestLowHand() = %v, %s, want 8-5-3-2-A", best.Cards, got)
+	}
+
+	if _, err := BestLowHand(1, mustCards(t, "AS2H3D4C")); err == nil {
+		t.Errorf("BestLowHand() of 4 cards error = nil, want an error")
+	}
+}
@@ -0,0 +1,68 @@
+package poker
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/YoungsoonLee/poker/types"
+)
+
+// ParseHands parses a single hand or a showdown of comma separated hands, e.g. "3s4h5d6c7s,9H3CTSQSAS".
+// Every hand is five cards in any form ParseCards accepts, and hands are numbered from 1 in the given order.
+// A card may only appear once across all the hands.
+func ParseHands(s string) (Hands, error) {
+	return parseHands(s, types.ParseCards)
+}
+
+// ParseHands parses hands like ParseHands, accepting the joker if the variant has one,
+// and checks every hand with Validate, so every card must be in the deck of the variant.
+func (v Variant) ParseHands(s string) (Hands, error) {
+	parse := types.ParseCards
+	if v.Joker {
+		parse = types.ParseWildCards
+	}
+
+	hands, err := parseHands(s, parse)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, h := range hands {
+		if err := v.Validate(h); err != nil {
+			return nil, err
+		}
+	}
+
+	return hands, nil
+}
+
+// parseHands parses comma separated hands with the given card parser. See ParseHands.
+func parseHands(s string, parse func(string) ([]types.Card, error)) (Hands, error) {
+	if strings.TrimSpace(s) == "" {
+		return nil, errors.New("no hands")
+	}
+
+	var hands Hands
+	var seen []types.Card
+	for i, part := range strings.Split(s, ",") {
+		cards, err := parse(part)
+		if err != nil {
+			return nil, fmt.Errorf("hand %d: %w", i+1, err)
+		}
+		if len(cards) != handCardCount {
+			return nil, fmt.Errorf("hand %d: %q has %d cards, a hand should have %d cards", i+1, strings.TrimSpace(part), len(cards), handCardCount)
+		}
+
+		for _, c := range cards {
+			if containsCard(seen, c) {
+				return nil, fmt.Errorf("hand %d: card %s is dealt more than once", i+1, c)
+			}
+			seen = append(seen, c)
+		}
+
+		hands = append(hands, Hand{HandID: i + 1, Cards: cards})
+	}
+
+	return hands, nil
+}
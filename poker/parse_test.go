@@ -0,0 +1,103 @@
+package poker
+
+import (
+	"testing"
+
+	"github.com/YoungsoonLee/poker/types"
+)
+
+func TestParseHands(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    []string
+		wantErr bool
+	}{
+		{name: "single hand", input: "3s4h5d6c7s", want: []string{"3S4H5D6C7S"}},
+		{name: "showdown", input: "3s4h5d6c7s,9H3CTSQSAD", want: []string{"3S4H5D6C7S", "9H3CTSQSAD"}},
+		{name: "spaces", input: " As Ks Qs Js Ts , 2c 3c 4c 5c 7d ", want: []string{"ASKSQSJSTS", "2C3C4C5C7D"}},
+		{name: "too few cards", input: "3s4h5d6c", wantErr: true},
+		{name: "too many cards", input: "3s4h5d6c7s8s", wantErr: true},
+		{name: "invalid card", input: "3s4h5d6c7x", wantErr: true},
+		{name: "card in two hands", input: "3s4h5d6c7s,9H3CTSQSAS,4DASAC7H9C", wantErr: true},
+		{name: "same card twice in a hand", input: "3s3s5d6c7s", wantErr: true},
+		{name: "empty hand", input: "3s4h5d6c7s,", wantErr: true},
+		{name: "empty", input: "  ", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseHands(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseHands() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("ParseHands() = %v hands, want %v", len(got), len(tt.want))
+			}
+			for i, h := range got {
+				cards := ""
+				for _, c := range h.Cards {
+					cards += c.String()
+				}
+				if h.HandID != i+1 || cards != tt.want[i] {
+					t.Errorf("ParseHands()[%d] = %d %s, want %d %s", i, h.HandID, cards, i+1, tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestVariant_ParseHands(t *testing.T) {
+	tests := []struct {
+		name    string
+		v       Variant
+		input   string
+		want    int
+		wantErr bool
+	}{
+		{name: "joker", v: Jokers, input: "ASKSQSJSXx,2H3H4H5H7D", want: 2},
+		{name: "joker as JK", v: Bug, input: "ASKSQSJSJK", want: 1},
+		{name: "joker in two hands", v: Jokers, input: "ASKSQSJSXX,2H3H4H5HJK", wantErr: true},
+		{name: "no joker in standard", v: Standard, input: "ASKSQSJSXX", wantErr: true},
+		{name: "no joker in deuces wild", v: DeucesWild, input: "ASKSQSJSXX", wantErr: true},
+		{name: "two is not in the short deck", v: ShortDeck, input: "2S7S8S9STS", wantErr: true},
+		{name: "standard", v: Standard, input: "3s4h5d6c7s", want: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.v.ParseHands(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Variant.ParseHands() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if len(got) != tt.want {
+				t.Errorf("Variant.ParseHands() = %v hands, want %v", len(got), tt.want)
+			}
+		})
+	}
+}
+
+func FuzzParseHands(f *testing.F) {
+	for _, s := range []string{"3s4h5d6c7s,9H3CTSQSAS", "3s4h5d6c7s", "3s 4h 5d 6c 7s", "3s4h5d6c", "3s4h5d6c7s,3s4h5d6c7s", "", ","} {
+		f.Add(s)
+	}
+
+	f.Fuzz(func(t *testing.T, s string) {
+		hands, err := ParseHands(s)
+		if err != nil {
+			return
+		}
+
+		var seen []types.Card
+		for i, h := range hands {
+			if h.HandID != i+1 || len(h.Cards) != handCardCount || !h.HasValidRanks() || !h.HasValidSuits() {
+				t.Fatalf("ParseHands(%q) hand %d = %+v, want five valid cards", s, i+1, h)
+			}
+			for _, c := range h.Cards {
+				if containsCard(seen, c) {
+					t.Fatalf("ParseHands(%q) deals %s twice", s, c)
+				}
+				seen = append(seen, c)
+			}
+		}
+	})
+}
@@ -0,0 +1,44 @@
+package poker
+
+import (
+	"fmt"
+
+	"github.com/YoungsoonLee/poker/types"
+)
+
+// PartialScore returns a comparable number for an incomplete hand of 1 to 4 cards, e.g. the up cards of a stud player.
+// Partial hands are ranked only by their pairs, three and four of a kind and then their high cards,
+// since straights and flushes need five cards, and they keep the rank orders of Evaluate in the high bits like Score,
+// so a smaller score is a stronger hand: a pair of kings scores lower than ace high.
+// A missing card ranks below any card, so ace-king beats a lone ace. A hand of five cards is scored by Score.
+func (h Hand) PartialScore() int {
+	if len(h.Cards) == handCardCount {
+		return h.Score()
+	}
+
+	rankOrder, kickers := Standard.classify(h.Cards)
+	return Standard.score(rankOrder, kickers)
+}
+
+// EvaluatePartial validates an incomplete hand of 1 to 5 cards and returns its result with the name, rank order and PartialScore,
+// e.g. "One Pair" or "High Card - {A}". A hand of five cards is evaluated like EvaluateResult.
+// It returns an error if the hand has no cards or more than five, or an invalid or repeated card.
+func (h Hand) EvaluatePartial() (HandResult, error) {
+	if len(h.Cards) < 1 || len(h.Cards) > handCardCount {
+		return HandResult{}, fmt.Errorf("hand %d: should have 1 to %d cards, got %d", h.HandID, handCardCount, len(h.Cards))
+	}
+	if len(h.Cards) == handCardCount {
+		return h.EvaluateResult()
+	}
+	if err := h.validateCards(false); err != nil {
+		return HandResult{}, err
+	}
+
+	rankOrder, kickers := Standard.classify(h.Cards)
+	rank := RankName(rankOrder)
+	if rankOrder == 10 {
+		rank = fmt.Sprintf("High Card - {%s}", types.RankMapReverse[kickers[0]])
+	}
+
+	return HandResult{HandID: h.HandID, Card: h.Cards, Rank: rank, RankOrder: rankOrder, Score: h.PartialScore()}, nil
+}
@@ -0,0 +1,96 @@
+package poker
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestHand_PartialScore(t *testing.T) {
+	tests := []struct {
+		name string
+		a    string
+		b    string
+		want int
+	}{
+		{name: "pair of kings beats ace high", a: "KSKH", b: "ASQD", want: 1},
+		{name: "higher pair", a: "5C5H", b: "4S4D", want: 1},
+		{name: "kicker decides", a: "ASAD9H", b: "AHAC8S", want: 1},
+		{name: "trips beat two pair", a: "2S2D2H7C", b: "KSKDQHQC", want: 1},
+		{name: "quads beat trips", a: "2S2D2H2C", b: "ASADAHKC", want: 1},
+		{name: "four flush is not a flush", a: "3D3H5C7D", b: "2S4S6S8S", want: 1},
+		{name: "four straight is not a straight", a: "2D2H", b: "9S8H", want: 1},
+		{name: "ace king beats a lone ace", a: "ASKD", b: "AH", want: 1},
+		{name: "same ranks tie", a: "ASKD", b: "AHKC", want: 0},
+		{name: "one card", a: "KS", b: "QS", want: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, b := Hand{Cards: mustCards(t, tt.a)}.PartialScore(), Hand{Cards: mustCards(t, tt.b)}.PartialScore()
+			got := 0
+			switch {
+			case a < b:
+				got = 1
+			case a > b:
+				got = -1
+			}
+			if got != tt.want {
+				t.Errorf("PartialScore() of %s = %d and %s = %d, want %d", tt.a, a, tt.b, b, tt.want)
+			}
+		})
+	}
+}
+
+func TestHand_PartialScore_RankOrder(t *testing.T) {
+	rng := rand.New(rand.NewSource(7))
+
+	// like Score, the rank order is kept in the high bits, and five cards score like Score
+	for n := 1; n <= handCardCount; n++ {
+		for i := 0; i < 500; i++ {
+			h := randomHand(rng, n)
+			result, err := h.EvaluatePartial()
+			if err != nil {
+				t.Fatalf("EvaluatePartial() of %v error = %v", h.Cards, err)
+			}
+			if got := result.Score >> (scoreKickerBits * handCardCount); got != result.RankOrder {
+				t.Fatalf("PartialScore() rank order of %v = %d, want %d", h.Cards, got, result.RankOrder)
+			}
+			if n == handCardCount && result.Score != h.Score() {
+				t.Fatalf("PartialScore() of %v = %d, want Score() = %d", h.Cards, result.Score, h.Score())
+			}
+		}
+	}
+}
+
+func TestHand_EvaluatePartial(t *testing.T) {
+	tests := []struct {
+		name          string
+		cards         string
+		want          string
+		wantRankOrder int
+		wantErr       bool
+	}{
+		{name: "ace high", cards: "ASQD7H", want: "High Card - {A}", wantRankOrder: 10},
+		{name: "one card", cards: "9C", want: "High Card - {9}", wantRankOrder: 10},
+		{name: "pair of kings", cards: "KSKH", want: "One Pair", wantRankOrder: 9},
+		{name: "two pair", cards: "KSKHQDQC", want: "Two Pair", wantRankOrder: 8},
+		{name: "three of a kind", cards: "7S7H7D", want: "Three of a Kind", wantRankOrder: 7},
+		{name: "four of a kind", cards: "7S7H7D7C", want: "Four of a Kind", wantRankOrder: 3},
+		{name: "two suited cards are not a flush", cards: "ASKS", want: "High Card - {A}", wantRankOrder: 10},
+		{name: "five cards", cards: "ASKSQSJSTS", want: "Royal Flush", wantRankOrder: 1},
+		{name: "no cards", cards: "", wantErr: true},
+		{name: "six cards", cards: "ASKSQSJSTS9S", wantErr: true},
+		{name: "repeated card", cards: "ASAS", wantErr: true},
+		{name: "invalid card", cards: "1SAS", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Hand{Cards: mustCards(t, tt.cards)}.EvaluatePartial()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("EvaluatePartial() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && (got.Rank != tt.want || got.RankOrder != tt.wantRankOrder) {
+				t.Errorf("EvaluatePartial() = %s, %d, want %s, %d", got.Rank, got.RankOrder, tt.want, tt.wantRankOrder)
+			}
+		})
+	}
+}
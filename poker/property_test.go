@@ -0,0 +1,171 @@
+package poker
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/YoungsoonLee/poker/types"
+)
+
+// randomHand deals n cards from a shuffled deck.
+func randomHand(rng *rand.Rand, n int) Hand {
+	deck := NewDeck(rng)
+	deck.Shuffle()
+	cards, _ := deck.Deal(n)
+
+	return Hand{Cards: cards}
+}
+
+func TestHand_Evaluate_PermutationInvariance(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+
+	for i := 0; i < 2000; i++ {
+		h := randomHand(rng, handCardCount)
+		rank, rankOrder := h.Evaluate()
+		score := h.Score()
+
+		shuffled := Hand{Cards: append([]types.Card(nil), h.Cards...)}
+		rng.Shuffle(len(shuffled.Cards), func(i, j int) {
+			shuffled.Cards[i], shuffled.Cards[j] = shuffled.Cards[j], shuffled.Cards[i]
+		})
+
+		if gotRank, gotOrder := shuffled.Evaluate(); gotRank != rank || gotOrder != rankOrder {
+			t.Fatalf("Evaluate() of %v = %s, %d, want %s, %d as for %v", shuffled.Cards, gotRank, gotOrder, rank, rankOrder, h.Cards)
+		}
+		if got := shuffled.Score(); got != score {
+			t.Fatalf("Score() of %v = %d, want %d as for %v", shuffled.Cards, got, score, h.Cards)
+		}
+	}
+}
+
+func TestBestHand_PermutationInvariance(t *testing.T) {
+	rng := rand.New(rand.NewSource(2))
+
+	for i := 0; i < 500; i++ {
+		cards := randomHand(rng, 7).Cards
+		best, _ := BestHand(1, cards)
+
+		shuffled := append([]types.Card(nil), cards...)
+		rng.Shuffle(len(shuffled), func(i, j int) { shuffled[i], shuffled[j] = shuffled[j], shuffled[i] })
+
+		if got, _ := BestHand(1, shuffled); got.Score() != best.Score() {
+			t.Fatalf("BestHand() score of %v = %d, want %d as for %v", shuffled, got.Score(), best.Score(), cards)
+		}
+	}
+}
+
+func TestHand_Evaluate_SuitRelabelingInvariance(t *testing.T) {
+	rng := rand.New(rand.NewSource(3))
+
+	for i := 0; i < 2000; i++ {
+		h := randomHand(rng, handCardCount)
+		rank, rankOrder := h.Evaluate()
+		score := h.Score()
+
+		perm := rng.Perm(len(types.Suits))
+		relabeled := Hand{Cards: make([]types.Card, len(h.Cards))}
+		for k, c := range h.Cards {
+			relabeled.Cards[k] = types.Card{Rank: c.Rank, Suit: types.Suits[perm[suitIndex(c.Suit)]]}
+		}
+
+		if gotRank, gotOrder := relabeled.Evaluate(); gotRank != rank || gotOrder != rankOrder {
+			t.Fatalf("Evaluate() of %v = %s, %d, want %s, %d as for %v", relabeled.Cards, gotRank, gotOrder, rank, rankOrder, h.Cards)
+		}
+		if got := relabeled.Score(); got != score {
+			t.Fatalf("Score() of %v = %d, want %d as for %v", relabeled.Cards, got, score, h.Cards)
+		}
+	}
+}
+
+func TestCompare_Properties(t *testing.T) {
+	rng := rand.New(rand.NewSource(4))
+
+	// hands are drawn from a small pool so that ties are common
+	pool := make([]Hand, 40)
+	for i := range pool {
+		pool[i] = randomHand(rng, handCardCount)
+		if i%2 == 1 {
+			// the same ranks in other suits tie with the previous hand, unless either is a flush
+			prev := pool[i-1].Cards
+			for k := range pool[i].Cards {
+				pool[i].Cards[k] = types.Card{Rank: prev[k].Rank, Suit: types.Suits[(suitIndex(prev[k].Suit)+k)%len(types.Suits)]}
+			}
+		}
+	}
+
+	for _, a := range pool {
+		if got := Compare(a, a); got != 0 {
+			t.Fatalf("Compare(%v, %v) = %d, want 0", a.Cards, a.Cards, got)
+		}
+
+		for _, b := range pool {
+			ab, ba := Compare(a, b), Compare(b, a)
+			if ab != -ba {
+				t.Fatalf("Compare(%v, %v) = %d, but Compare(%v, %v) = %d", a.Cards, b.Cards, ab, b.Cards, a.Cards, ba)
+			}
+
+			for _, c := range pool {
+				bc, ac := Compare(b, c), Compare(a, c)
+				switch {
+				case ab >= 0 && bc >= 0 && ac < 0:
+					t.Fatalf("%v beats or ties %v, which beats or ties %v, but Compare(a, c) = %d", a.Cards, b.Cards, c.Cards, ac)
+				case ab == 0 && bc == 0 && ac != 0:
+					t.Fatalf("%v ties %v, which ties %v, but Compare(a, c) = %d", a.Cards, b.Cards, c.Cards, ac)
+				}
+			}
+		}
+	}
+}
+
+func TestHand_Evaluate_MalformedLengths(t *testing.T) {
+	rng := rand.New(rand.NewSource(5))
+
+	for n := 0; n <= 9; n++ {
+		// valid cards, zero cards and cards with an invalid rank and a suit of two characters
+		invalid := Hand{Cards: make([]types.Card, n)}
+		for k := range invalid.Cards {
+			invalid.Cards[k] = types.Card{Rank: "X", Suit: "SH"}
+		}
+		hands := []Hand{randomHand(rng, n), {Cards: make([]types.Card, n)}, invalid}
+
+		for _, h := range hands {
+			rank, rankOrder := h.Evaluate()
+			if n != handCardCount && rankOrder != InvalidRankOrder {
+				t.Errorf("Evaluate() of %d cards %v = %s, %d, want an invalid hand", n, h.Cards, rank, rankOrder)
+			}
+
+			h.Score()
+			h.IsFlush()
+			h.IsStraight()
+			h.IsFullHouse()
+			EvaluateHands(Hands{h, h})
+		}
+	}
+}
+
+func FuzzHand_Evaluate(f *testing.F) {
+	for _, s := range []string{"ASKSQSJSTS", "2C2D2H3C3D", "AS2H", "", "XXYYZZ", "AS2H3D4C5S6S7S"} {
+		f.Add(s)
+	}
+
+	f.Fuzz(func(t *testing.T, s string) {
+		// every pair of bytes is a card, valid or not, and an odd byte is a card without a suit
+		var h Hand
+		for i := 0; i < len(s); i += 2 {
+			c := types.Card{Rank: s[i : i+1]}
+			if i+1 < len(s) {
+				c.Suit = s[i+1 : i+2]
+			}
+			h.Cards = append(h.Cards, c)
+		}
+
+		_, rankOrder := h.Evaluate()
+		score := h.Score()
+
+		if len(h.Cards) == handCardCount && h.HasValidRanks() && h.HasValidSuits() {
+			if got := score >> (scoreKickerBits * handCardCount); got != rankOrder {
+				t.Fatalf("Score() rank order of %v = %d, want %d", h.Cards, got, rankOrder)
+			}
+		}
+	})
+}
@@ -0,0 +1,258 @@
+package poker
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/YoungsoonLee/poker/types"
+)
+
+// Combo is a combination of two hole cards.
+type Combo [2]types.Card
+
+// NewCombo creates a Combo with the higher card first, so the same two cards always make the same Combo.
+func NewCombo(a, b types.Card) Combo {
+	ra, rb := types.RankMap[a.Rank], types.RankMap[b.Rank]
+	if rb > ra || rb == ra && suitIndex(b.Suit) < suitIndex(a.Suit) {
+		a, b = b, a
+	}
+
+	return Combo{a, b}
+}
+
+// String returns the combo in the two character form, e.g. "AsKd".
+func (c Combo) String() string {
+	return c[0].String() + c[1].String()
+}
+
+// Cards returns the cards of the combo as a slice.
+func (c Combo) Cards() []types.Card {
+	return []types.Card{c[0], c[1]}
+}
+
+// Range is a set of hole card combos a player may hold.
+type Range []Combo
+
+// ParseRange parses a comma separated range in the usual notation, for example "22+, A2s+, KTo+, QJ, T9s-T6s, AhKh".
+//
+//   - "TT" is a pair, "AKs" suited, "AKo" offsuit, and "AK" both suited and offsuit.
+//   - "TT+" adds every higher pair, and "A2s+" every higher kicker up to AKs.
+//   - "TT-77" and "K9s-K6s" add everything in between.
+//   - "AhKh" is a single combo, and "any" is every combo.
+//
+// Duplicate combos are only added once.
+func ParseRange(s string) (Range, error) {
+	var r Range
+	seen := make(map[Combo]bool)
+
+	for _, token := range strings.Split(s, ",") {
+		token = strings.TrimSpace(token)
+		if token == "" {
+			continue
+		}
+
+		combos, err := parseRangeToken(token)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, c := range combos {
+			if !seen[c] {
+				seen[c] = true
+				r = append(r, c)
+			}
+		}
+	}
+
+	if len(r) == 0 {
+		return nil, fmt.Errorf("invalid range: %q. range should be like 22+, A2s+, KTo+", s)
+	}
+
+	return r, nil
+}
+
+// parseRangeToken parses one token of a range. See ParseRange.
+func parseRangeToken(token string) ([]Combo, error) {
+	upper := strings.ToUpper(token)
+	if upper == "ANY" || upper == "RANDOM" {
+		return AllCombos(), nil
+	}
+
+	if len(upper) == 4 && isSuit(upper[1]) && isSuit(upper[3]) {
+		cards, err := types.ParseCards(token)
+		if err != nil {
+			return nil, err
+		}
+		if cards[0] == cards[1] {
+			return nil, fmt.Errorf("invalid range: %s. combo has the same card twice", token)
+		}
+		return []Combo{NewCombo(cards[0], cards[1])}, nil
+	}
+
+	if from, to, ok := strings.Cut(upper, "-"); ok {
+		a, err := parseShape(from, token)
+		if err != nil {
+			return nil, err
+		}
+		b, err := parseShape(to, token)
+		if err != nil {
+			return nil, err
+		}
+
+		if a.pair() != b.pair() || !a.pair() && (a.high != b.high || a.suited != b.suited) {
+			return nil, fmt.Errorf("invalid range: %s. both ends should be pairs, or have the same first card and suitedness", token)
+		}
+
+		var combos []Combo
+		if a.pair() {
+			for r := min(a.high, b.high); r <= max(a.high, b.high); r++ {
+				combos = append(combos, shape{high: r, low: r}.combos()...)
+			}
+			return combos, nil
+		}
+
+		for r := min(a.low, b.low); r <= max(a.low, b.low); r++ {
+			combos = append(combos, shape{high: a.high, low: r, suited: a.suited}.combos()...)
+		}
+		return combos, nil
+	}
+
+	plus := strings.HasSuffix(upper, "+")
+	sh, err := parseShape(strings.TrimSuffix(upper, "+"), token)
+	if err != nil {
+		return nil, err
+	}
+
+	if !plus {
+		return sh.combos(), nil
+	}
+
+	var combos []Combo
+	if sh.pair() {
+		for r := sh.high; r <= types.RankMap["A"]; r++ {
+			combos = append(combos, shape{high: r, low: r}.combos()...)
+		}
+		return combos, nil
+	}
+
+	for r := sh.low; r < sh.high; r++ {
+		combos = append(combos, shape{high: sh.high, low: r, suited: sh.suited}.combos()...)
+	}
+	return combos, nil
+}
+
+// shape is a starting hand without suits, like "AKs", "AKo", "AK" or "TT".
+// suited is 'S' for suited, 'O' for offsuit and 0 for both.
+type shape struct {
+	high   int
+	low    int
+	suited byte
+}
+
+func (sh shape) pair() bool {
+	return sh.high == sh.low
+}
+
+// parseShape parses a starting hand like "AKs". token is the whole range token for error messages.
+func parseShape(s, token string) (shape, error) {
+	if len(s) != 2 && len(s) != 3 {
+		return shape{}, fmt.Errorf("invalid range: %s. hand should be like AKs, AKo, AK or TT", token)
+	}
+
+	high, ok1 := types.RankMap[s[:1]]
+	low, ok2 := types.RankMap[s[1:2]]
+	if !ok1 || !ok2 {
+		return shape{}, fmt.Errorf("invalid range: %s. rank should be 2,3,4,5,6,7,8,9,T,J,Q,K,A", token)
+	}
+
+	if low > high {
+		high, low = low, high
+	}
+
+	sh := shape{high: high, low: low}
+	if len(s) == 3 {
+		sh.suited = s[2]
+		if sh.suited != 'S' && sh.suited != 'O' || sh.pair() {
+			return shape{}, fmt.Errorf("invalid range: %s. only non pair hands can be suited (s) or offsuit (o)", token)
+		}
+	}
+
+	return sh, nil
+}
+
+// combos returns every combo of the shape.
+func (sh shape) combos() []Combo {
+	high, low := types.RankMapReverse[sh.high], types.RankMapReverse[sh.low]
+
+	var combos []Combo
+	for i, s1 := range types.Suits {
+		for j, s2 := range types.Suits {
+			switch {
+			case sh.pair() && j <= i:
+				continue
+			case sh.suited == 'S' && i != j:
+				continue
+			case sh.suited == 'O' && i == j:
+				continue
+			}
+			combos = append(combos, NewCombo(types.Card{Rank: high, Suit: s1}, types.Card{Rank: low, Suit: s2}))
+		}
+	}
+
+	return combos
+}
+
+// AllCombos returns all 1326 combos of two cards.
+func AllCombos() []Combo {
+	deck := NewDeck(nil).Cards
+
+	combos := make([]Combo, 0, len(deck)*(len(deck)-1)/2)
+	for i := 0; i < len(deck); i++ {
+		for j := i + 1; j < len(deck); j++ {
+			combos = append(combos, NewCombo(deck[i], deck[j]))
+		}
+	}
+
+	return combos
+}
+
+// Without returns the combos of the range that do not use any of the dead cards,
+// e.g. the hole cards of the hero and the board.
+func (r Range) Without(dead ...types.Card) Range {
+	var out Range
+	for _, c := range r {
+		if !containsCard(dead, c[0]) && !containsCard(dead, c[1]) {
+			out = append(out, c)
+		}
+	}
+
+	return out
+}
+
+// Contains reports whether the combo is in the range.
+func (r Range) Contains(c Combo) bool {
+	c = NewCombo(c[0], c[1])
+	for _, x := range r {
+		if x == c {
+			return true
+		}
+	}
+
+	return false
+}
+
+func isSuit(b byte) bool {
+	_, ok := types.SuitMap[string(b)]
+	return ok
+}
+
+// suitIndex returns the position of the suit in types.Suits.
+func suitIndex(suit string) int {
+	for i, s := range types.Suits {
+		if s == suit {
+			return i
+		}
+	}
+
+	return len(types.Suits)
+}
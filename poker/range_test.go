@@ -0,0 +1,97 @@
+package poker
+
+import (
+	"testing"
+
+	"github.com/YoungsoonLee/poker/types"
+)
+
+func TestParseRange(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    int
+		wantErr bool
+	}{
+		{name: "pair", input: "TT", want: 6},
+		{name: "pairs and higher", input: "22+", want: 78},
+		{name: "pair span", input: "TT-77", want: 24},
+		{name: "suited", input: "AKs", want: 4},
+		{name: "offsuit", input: "AKo", want: 12},
+		{name: "suited and offsuit", input: "AK", want: 16},
+		{name: "suited kickers and higher", input: "A2s+", want: 48},
+		{name: "offsuit kickers and higher", input: "KTo+", want: 36},
+		{name: "suited span", input: "T9s-T6s", want: 16},
+		{name: "single combo", input: "AhKh", want: 1},
+		{name: "any", input: "any", want: 1326},
+		{name: "duplicates are added once", input: "AKs, AK, AsKs", want: 16},
+		{name: "several tokens", input: "22+, A2s+, KTo+", want: 78 + 48 + 36},
+		{name: "reversed ranks", input: "ka", want: 16},
+		{name: "invalid rank", input: "AX", wantErr: true},
+		{name: "suited pair", input: "AAs", wantErr: true},
+		{name: "span with different first cards", input: "K9s-Q6s", wantErr: true},
+		{name: "same card twice", input: "AhAh", wantErr: true},
+		{name: "empty", input: " , ", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseRange(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseRange() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if len(got) != tt.want {
+				t.Errorf("ParseRange() = %v combos, want %v", len(got), tt.want)
+			}
+		})
+	}
+}
+
+func TestRange_Without(t *testing.T) {
+	r, err := ParseRange("AA, AKs")
+	if err != nil {
+		t.Fatalf("ParseRange() error = %v", err)
+	}
+
+	got := r.Without(types.Card{Rank: "A", Suit: "S"})
+	// AA loses the 3 combos with the ace of spades and AKs loses AsKs
+	if len(got) != 6 {
+		t.Errorf("Range.Without() = %v combos, want %v", len(got), 6)
+	}
+
+	if got.Contains(NewCombo(types.Card{Rank: "K", Suit: "S"}, types.Card{Rank: "A", Suit: "S"})) {
+		t.Errorf("Range.Without() still contains AsKs")
+	}
+	if !got.Contains(NewCombo(types.Card{Rank: "K", Suit: "H"}, types.Card{Rank: "A", Suit: "H"})) {
+		t.Errorf("Range.Without() does not contain AhKh")
+	}
+}
+
+func FuzzParseRange(f *testing.F) {
+	for _, s := range []string{"22+, A2s+, KTo+, QJ, T9s-T6s, AhKh", "any", "AhAh", "TT-77", "K9s-Q6s", "AKs+", "", "A", "ſſſſ"} {
+		f.Add(s)
+	}
+
+	f.Fuzz(func(t *testing.T, s string) {
+		r, err := ParseRange(s)
+		if err != nil {
+			return
+		}
+
+		if len(r) == 0 || len(r) > len(AllCombos()) {
+			t.Fatalf("ParseRange(%q) has %d combos", s, len(r))
+		}
+
+		seen := make(map[Combo]bool)
+		for _, c := range r {
+			h := Hand{Cards: c.Cards()}
+			if c[0] == c[1] || !h.HasValidRanks() || !h.HasValidSuits() {
+				t.Fatalf("ParseRange(%q) has the invalid combo %s", s, c)
+			}
+			if c != NewCombo(c[1], c[0]) || seen[c] {
+				t.Fatalf("ParseRange(%q) has the combo %s twice or out of order", s, c)
+			}
+			seen[c] = true
+		}
+	})
+}
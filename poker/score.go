@@ -0,0 +1,256 @@
+package poker
+
+import (
+	"fmt"
+
+	"github.com/YoungsoonLee/poker/types"
+)
+
+// scoreKickerBits is the number of bits used by each tie-break rank in a score.
+const scoreKickerBits = 4
+
+// missingKicker is the tie-break value of a kicker slot without a card, which is worse than the value of any rank.
+const missingKicker = 1<<scoreKickerBits - 1
+
+// Score returns a comparable number for the hand including its kickers.
+// Like the rank order returned by Evaluate, a smaller score is a stronger hand,
+// and equal hands always have the same score.
+// The rank order is kept in the high bits, so hands of different categories compare the same way as their rank orders.
+// Score is computed in a single pass over the cards, so it is much faster than Evaluate.
+func (h Hand) Score() int {
+	return scoreCards(h.Cards)
+}
+
+// scoreCards returns the score of five cards. See Hand.Score.
+func scoreCards(cards []types.Card) int {
+	_, score := Standard.rankCards(cards)
+	return score
+}
+
+// rankCards returns the rank order and the score of five cards under the rules of the variant.
+// The rank order is the category of the hand like the one returned by Evaluate,
+// and the score keeps the strength of the category in the variant in its high bits. See Hand.Score.
+func (v Variant) rankCards(cards []types.Card) (int, int) {
+	rankOrder, kickers := v.categorize(cards)
+	return rankOrder, v.score(rankOrder, kickers)
+}
+
+// score composes the score of a hand from its rank order and kickers. See rankCards.
+// A missing kicker ranks below any card, so a hand with a missing card loses to every hand of its category with all five.
+func (v Variant) score(rankOrder int, kickers [handCardCount]int) int {
+	score := v.strength(rankOrder)
+	for _, r := range kickers {
+		score <<= scoreKickerBits
+		if r > 0 {
+			score |= types.RankMap["A"] - r
+		} else {
+			score |= missingKicker
+		}
+	}
+
+	return score
+}
+
+// categorize returns the rank order and the ranks breaking ties within it for five cards under the rules of the variant.
+// Wild cards are replaced by the ranks that make the strongest hand, see substitute.
+func (v Variant) categorize(cards []types.Card) (int, [handCardCount]int) {
+	if !v.wild() {
+		return v.classify(cards)
+	}
+
+	naturals := make([]types.Card, 0, len(cards))
+	for _, c := range cards {
+		if !v.isWild(c) {
+			naturals = append(naturals, c)
+		}
+	}
+	if len(naturals) == len(cards) {
+		return v.classify(cards)
+	}
+
+	return v.substitute(naturals, len(cards)-len(naturals))
+}
+
+// substitute tries every choice of ranks for the wild cards, and returns the strongest category and kickers found.
+// The wild cards take the suit of the first natural card, since a flush is the only hand where their suit matters.
+// In bug mode a wild card other than an ace may only complete a straight or a flush.
+func (v Variant) substitute(naturals []types.Card, wilds int) (int, [handCardCount]int) {
+	suit := types.Suits[0]
+	if len(naturals) > 0 {
+		suit = naturals[0].Suit
+	}
+
+	ranks := v.ranks()
+	cards := append(append(make([]types.Card, 0, len(naturals)+wilds), naturals...), make([]types.Card, wilds)...)
+
+	bestOrder, bestKickers, bestScore := 0, [handCardCount]int{}, 0
+	var choose func(start, depth int, aces bool)
+	choose = func(start, depth int, aces bool) {
+		if depth == wilds {
+			rankOrder, kickers := v.classify(cards)
+			if v.Bug && !aces && rankOrder != 1 && rankOrder != 2 && rankOrder != 5 && rankOrder != 6 {
+				return
+			}
+
+			if score := v.score(rankOrder, kickers); bestScore == 0 || score < bestScore {
+				bestOrder, bestKickers, bestScore = rankOrder, kickers, score
+			}
+			return
+		}
+
+		// ranks are chosen in ascending order, since the order of the wild cards does not matter
+		for i := start; i < len(ranks); i++ {
+			cards[len(naturals)+depth] = types.Card{Rank: ranks[i], Suit: suit}
+			choose(i, depth+1, aces && ranks[i] == "A")
+		}
+	}
+	choose(0, 0, true)
+
+	return bestOrder, bestKickers
+}
+
+// classify returns the rank order and the ranks breaking ties within it for up to five cards without wild cards.
+// Five of a kind, which can only be made with wild cards, is rank order 0 in wild card variants.
+func (v Variant) classify(cards []types.Card) (int, [handCardCount]int) {
+	var counts [15]int
+	// straights and flushes need five cards, so partial hands only make pairs, trips and quads
+	flush := len(cards) == handCardCount
+	low, high := 15, 0
+	for _, c := range cards {
+		r := types.RankMap[c.Rank]
+		counts[r]++
+		// a card without a valid rank counts as a missing card, so it makes neither a flush nor a kicker
+		if c.Suit != cards[0].Suit || r == 0 {
+			flush = false
+		}
+		if r < low {
+			low = r
+		}
+		if r > high {
+			high = r
+		}
+	}
+
+	// kickers are ordered by how often their rank appears and then by rank,
+	// so a full house yields the three of a kind rank first and then the pair rank
+	var kickers [handCardCount]int
+	var groups [handCardCount + 1]int
+	k := 0
+	for n := handCardCount; n >= 1; n-- {
+		for r := 14; r >= 2; r-- {
+			if counts[r] == n {
+				groups[n]++
+				if k < len(kickers) {
+					kickers[k] = r
+					k++
+				}
+			}
+		}
+	}
+
+	straight := false
+	if groups[1] == handCardCount && len(cards) == handCardCount {
+		switch {
+		case high-low == 4:
+			straight = true
+		case high == 14 && low == v.lowestRank() && kickers[1] == low+3:
+			// the wheel is the ace with the four lowest ranks of the deck, playing the ace low,
+			// so (A, 2, 3, 4, 5) is five high, and (A, 6, 7, 8, 9) nine high in short-deck
+			straight = true
+			kickers = [handCardCount]int{low + 3}
+		}
+	}
+	if straight {
+		kickers = [handCardCount]int{kickers[0]}
+	}
+
+	var rankOrder int
+	switch {
+	case groups[5] > 0 && v.wild():
+		rankOrder = 0
+	case straight && flush && kickers[0] == 14:
+		rankOrder = 1
+	case straight && flush:
+		rankOrder = 2
+	case groups[4] > 0 || groups[5] > 0:
+		rankOrder = 3
+	case groups[3] > 0 && groups[2] > 0:
+		rankOrder = 4
+	case flush:
+		rankOrder = 5
+	case straight:
+		rankOrder = 6
+	case groups[3] > 0:
+		rankOrder = 7
+	case groups[2] > 1:
+		rankOrder = 8
+	case groups[2] > 0:
+		rankOrder = 9
+	default:
+		rankOrder = 10
+	}
+
+	return rankOrder, kickers
+}
+
+// rankNames are the names of the rank orders returned by Evaluate, starting with 0 for five of a kind,
+// which is only made with wild cards.
+var rankNames = [...]string{"Five of a Kind", "Royal Flush", "Straight Flush", "Four of a Kind", "Full House", "Flush", "Straight", "Three of a Kind", "Two Pair", "One Pair", "High Card"}
+
+// RankName returns the name of a rank order returned by Evaluate, e.g. "Full House" for 4.
+// Unlike Evaluate it names every high card hand "High Card", so it can be used to group hands by category.
+// It returns an empty string for an invalid rank order.
+func RankName(rankOrder int) string {
+	if rankOrder < 0 || rankOrder >= len(rankNames) {
+		return ""
+	}
+
+	return rankNames[rankOrder]
+}
+
+// Compare compares two hands by their scores.
+// It returns 1 if a beats b, -1 if b beats a and 0 if they tie.
+func Compare(a, b Hand) int {
+	sa, sb := a.Score(), b.Score()
+
+	switch {
+	case sa < sb:
+		return 1
+	case sa > sb:
+		return -1
+	default:
+		return 0
+	}
+}
+
+// BestHand returns the strongest five card hand that can be made from the given cards,
+// e.g. two hole cards and five board cards in Texas Hold'em.
+// It returns an error if fewer than five cards are given.
+func BestHand(handID int, cards []types.Card) (Hand, error) {
+	if len(cards) < handCardCount {
+		return Hand{}, fmt.Errorf("need at least %d cards to make a hand, got %d", handCardCount, len(cards))
+	}
+
+	var best Hand
+	bestScore := 0
+
+	combination := make([]types.Card, handCardCount)
+	var choose func(start, depth int)
+	choose = func(start, depth int) {
+		if depth == handCardCount {
+			hand := Hand{HandID: handID, Cards: append([]types.Card(nil), combination...)}
+			if score := hand.Score(); bestScore == 0 || score < bestScore {
+				best, bestScore = hand, score
+			}
+			return
+		}
+
+		for i := start; i <= len(cards)-(handCardCount-depth); i++ {
+			combination[depth] = cards[i]
+			choose(i+1, depth+1)
+		}
+	}
+	choose(0, 0)
+
+	return best, nil
+}
@@ -0,0 +1,201 @@
+package poker
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/YoungsoonLee/poker/types"
+)
+
+// mustCards parses a card string like "AsKd" into cards, two characters per card.
+func mustCards(t *testing.T, s string) []types.Card {
+	t.Helper()
+
+	var cards []types.Card
+	for i := 0; i+1 < len(s); i += 2 {
+		cards = append(cards, types.Card{Rank: string(s[i]), Suit: string(s[i+1])})
+	}
+
+	return cards
+}
+
+func TestCompare(t *testing.T) {
+	tests := []struct {
+		name string
+		a    string
+		b    string
+		want int
+	}{
+		{
+			name: "higher pair wins",
+			a:    "ASAH2D3C4S",
+			b:    "KSKHQDJCTS",
+			want: 1,
+		},
+		{
+			name: "same pair, kicker decides",
+			a:    "9S9HAD3C4S",
+			b:    "9D9CKDQCJS",
+			want: 1,
+		},
+		{
+			name: "same two pair, kicker decides",
+			a:    "9S9H3D3C4S",
+			b:    "9D9C3S3H5S",
+			want: -1,
+		},
+		{
+			name: "full house is decided by three of a kind first",
+			a:    "2S2H2DACAS",
+			b:    "KSKHQDQCQS",
+			want: -1,
+		},
+		{
+			name: "low straight loses to six high straight",
+			a:    "AS2H3D4C5S",
+			b:    "2S3H4D5C6S",
+			want: -1,
+		},
+		{
+			name: "flush is decided by every card",
+			a:    "AS9S7S5S3S",
+			b:    "AH9H7H5H2H",
+			want: 1,
+		},
+		{
+			name: "equal hands tie",
+			a:    "ASKHQDJC9S",
+			b:    "ADKCQSJH9D",
+			want: 0,
+		},
+		{
+			name: "category beats kickers",
+			a:    "2S2H3D4C5S",
+			b:    "ASKHQDJC9S",
+			want: 1,
+		},
+		{
+			name: "a missing card ranks below any card",
+			a:    "ASKHQDJC",
+			b:    "ASKHQDJC2S",
+			want: -1,
+		},
+		{
+			name: "an empty hand loses to any hand",
+			a:    "",
+			b:    "2S3D4H5C7S",
+			want: -1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := Hand{Cards: mustCards(t, tt.a)}
+			b := Hand{Cards: mustCards(t, tt.b)}
+			if got := Compare(a, b); got != tt.want {
+				t.Errorf("Compare() = %v, want %v", got, tt.want)
+			}
+			if got := Compare(b, a); got != -tt.want {
+				t.Errorf("Compare() reversed = %v, want %v", got, -tt.want)
+			}
+		})
+	}
+}
+
+func TestBestHand(t *testing.T) {
+	tests := []struct {
+		name     string
+		cards    string
+		wantRank string
+		wantErr  bool
+	}{
+		{
+			name:     "flush on the board with a pair in the hole",
+			cards:    "2C2D" + "AHKH9H5H3H",
+			wantRank: "Flush",
+		},
+		{
+			name:     "straight using both hole cards",
+			cards:    "9S8D" + "7H6C5S2D2C",
+			wantRank: "Straight",
+		},
+		{
+			name:     "full house from two pair and trips",
+			cards:    "KSKH" + "KDQCQS2D3C",
+			wantRank: "Full House",
+		},
+		{
+			name:     "five cards",
+			cards:    "ASKHQDJC9S",
+			wantRank: "High Card - {A}",
+		},
+		{
+			name:    "too few cards",
+			cards:   "ASKHQD",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := BestHand(1, mustCards(t, tt.cards))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("BestHand() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			if rank, _ := got.Evaluate(); rank != tt.wantRank {
+				t.Errorf("BestHand() = %v, want %v", rank, tt.wantRank)
+			}
+			if len(got.Cards) != handCardCount {
+				t.Errorf("BestHand() = %v cards, want %v", len(got.Cards), handCardCount)
+			}
+		})
+	}
+}
+
+func TestHand_Score_MatchesEvaluate(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+
+	for i := 0; i < 2000; i++ {
+		deck := NewDeck(rng)
+		deck.Shuffle()
+		cards, _ := deck.Deal(handCardCount)
+
+		h := Hand{Cards: cards}
+		_, rankOrder := h.Evaluate()
+		if got := h.Score() >> (scoreKickerBits * handCardCount); got != rankOrder {
+			t.Fatalf("Hand.Score() rank order of %v = %v, want %v", cards, got, rankOrder)
+		}
+	}
+}
+
+func TestRankName(t *testing.T) {
+	tests := []struct {
+		name      string
+		rankOrder int
+		want      string
+	}{
+		{name: "royal flush", rankOrder: 1, want: "Royal Flush"},
+		{name: "full house", rankOrder: 4, want: "Full House"},
+		{name: "high card", rankOrder: 10, want: "High Card"},
+		{name: "five of a kind", rankOrder: 0, want: "Five of a Kind"},
+		{name: "negative", rankOrder: -1, want: ""},
+		{name: "too big", rankOrder: 11, want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := RankName(tt.rankOrder); got != tt.want {
+				t.Errorf("RankName() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	// the names must match Evaluate for every category but high card
+	for i, cards := range []string{"AsKsQsJsTs", "9s8s7s6s5s", "AsAhAdAc2s", "AsAhAdKcKs", "As9s7s5s3s", "9s8h7d6c5s", "AsAhAd3c2s", "AsAhKdKc2s", "AsAhKd3c2s"} {
+		name, rankOrder := Hand{Cards: mustCards(t, cards)}.Evaluate()
+		if rankOrder != i+1 || name != RankName(rankOrder) {
+			t.Errorf("RankName(%d) = %v, Evaluate() = %v, %v", i+1, RankName(i+1), name, rankOrder)
+		}
+	}
+}
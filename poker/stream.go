@@ -0,0 +1,97 @@
+package poker
+
+import (
+	"context"
+	"runtime"
+)
+
+// StreamJob is a showdown to evaluate in a stream.
+// ID is passed through to the result, e.g. the line the hands were read from.
+// The hands are ranked by the rules of the Variant, standard poker by default.
+type StreamJob struct {
+	ID      int
+	Hands   Hands
+	Variant Variant
+}
+
+// StreamResult is the evaluation of a StreamJob, with the results ordered from the strongest hand like EvaluateHands.
+type StreamResult struct {
+	ID      int
+	Results []HandResult
+}
+
+// EvaluateStream evaluates the jobs received from jobs on the given number of workers, or one per CPU if workers is less than 1.
+// The results are sent in the order the jobs were received, and at most two jobs per worker are held at a time,
+// so streams of any length are evaluated in constant memory.
+// The returned channel is closed after the last result once jobs is closed, or as soon as ctx is done.
+func EvaluateStream(ctx context.Context, jobs <-chan StreamJob, workers int) <-chan StreamResult {
+	if workers < 1 {
+		workers = runtime.NumCPU()
+	}
+
+	type task struct {
+		job  StreamJob
+		done chan StreamResult
+	}
+
+	tasks := make(chan task)
+	// pending holds the result channel of every job in flight in the order the jobs were received
+	pending := make(chan chan StreamResult, 2*workers)
+	out := make(chan StreamResult)
+
+	for w := 0; w < workers; w++ {
+		go func() {
+			for t := range tasks {
+				t.done <- StreamResult{ID: t.job.ID, Results: t.job.Variant.EvaluateHands(t.job.Hands)}
+			}
+		}()
+	}
+
+	go func() {
+		defer close(tasks)
+		defer close(pending)
+		for {
+			var job StreamJob
+			var ok bool
+			select {
+			case job, ok = <-jobs:
+				if !ok {
+					return
+				}
+			case <-ctx.Done():
+				return
+			}
+
+			t := task{job: job, done: make(chan StreamResult, 1)}
+			select {
+			case pending <- t.done:
+			case <-ctx.Done():
+				return
+			}
+			select {
+			case tasks <- t:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	go func() {
+		defer close(out)
+		for done := range pending {
+			var r StreamResult
+			select {
+			case r = <-done:
+			case <-ctx.Done():
+				return
+			}
+			select {
+			case out <- r:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return out
+}
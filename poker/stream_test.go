@@ -0,0 +1,93 @@
+package poker
+
+import (
+	"context"
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func TestEvaluateStream(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+
+	var jobs []StreamJob
+	for i := 0; i < 500; i++ {
+		d := NewDeck(rng)
+		d.Shuffle()
+
+		var hands Hands
+		for h := 0; h < 1+i%4; h++ {
+			cards, err := d.Deal(handCardCount)
+			if err != nil {
+				t.Fatalf("Deck.Deal() error = %v", err)
+			}
+			hands = append(hands, Hand{HandID: h + 1, Cards: cards})
+		}
+		jobs = append(jobs, StreamJob{ID: i * 2, Hands: hands})
+	}
+
+	tests := []struct {
+		name    string
+		workers int
+	}{
+		{name: "single worker", workers: 1},
+		{name: "several workers", workers: 8},
+		{name: "one worker per cpu", workers: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			in := make(chan StreamJob)
+			go func() {
+				defer close(in)
+				for _, job := range jobs {
+					in <- job
+				}
+			}()
+
+			i := 0
+			for r := range EvaluateStream(context.Background(), in, tt.workers) {
+				if i >= len(jobs) {
+					t.Fatalf("EvaluateStream() sent more than %d results", len(jobs))
+				}
+				if r.ID != jobs[i].ID {
+					t.Fatalf("EvaluateStream() result %d has ID %d, want %d", i, r.ID, jobs[i].ID)
+				}
+				if want := EvaluateHands(jobs[i].Hands); !reflect.DeepEqual(r.Results, want) {
+					t.Errorf("EvaluateStream() result %d = %v, want %v", i, r.Results, want)
+				}
+				i++
+			}
+			if i != len(jobs) {
+				t.Errorf("EvaluateStream() sent %d results, want %d", i, len(jobs))
+			}
+		})
+	}
+}
+
+func TestEvaluateStream_Cancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	// the jobs are never closed, so the results only end when the context is cancelled
+	in := make(chan StreamJob)
+	go func() {
+		hand := Hand{HandID: 1, Cards: mustCards(t, "ASKSQSJSTS")}
+		for {
+			select {
+			case in <- StreamJob{Hands: Hands{hand}}:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	results := EvaluateStream(ctx, in, 4)
+	for i := 0; i < 10; i++ {
+		if _, ok := <-results; !ok {
+			t.Fatalf("EvaluateStream() closed after %d results", i)
+		}
+	}
+	cancel()
+
+	for range results {
+	}
+}
@@ -0,0 +1,254 @@
+package poker
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"strings"
+
+	"github.com/YoungsoonLee/poker/types"
+)
+
+// Variant is a set of rules for the deck and the ranking of five card hands.
+// Ranks are the ranks of the deck in ascending order, and the wheel straight is the ace with the four lowest of them.
+// FlushBeatsFullHouse and TripsBeatStraight swap the order of those categories.
+// Joker adds the joker to the deck, and every card of WildRank is wild too, e.g. "2" for deuces wild.
+// A wild card takes the rank and suit that make the strongest hand, so five of a kind becomes the top category,
+// unless Bug is set, which only lets a wild card be an ace or complete a straight or a flush.
+// The zero value is standard poker with a 52 card deck.
+type Variant struct {
+	Name                string
+	Ranks               []string
+	FlushBeatsFullHouse bool
+	TripsBeatStraight   bool
+	Joker               bool
+	WildRank            string
+	Bug                 bool
+}
+
+// Standard is poker with a 52 card deck, where A-2-3-4-5 is the lowest straight.
+var Standard = Variant{Name: "standard"}
+
+// ShortDeck is short-deck (6+) Hold'em with a 36 card deck without the twos to fives.
+// A-6-7-8-9 is the lowest straight and a flush beats a full house, since it is harder to make.
+var ShortDeck = Variant{Name: "short-deck", Ranks: types.ShortDeckRanks, FlushBeatsFullHouse: true}
+
+// ShortDeckTrips is short-deck Hold'em where three of a kind also beats a straight.
+var ShortDeckTrips = Variant{Name: "short-deck-trips", Ranks: types.ShortDeckRanks, FlushBeatsFullHouse: true, TripsBeatStraight: true}
+
+// Jokers is poker with a 53 card deck, where the joker is wild.
+var Jokers = Variant{Name: "jokers", Joker: true}
+
+// Bug is poker with a 53 card deck, where the joker is the bug: it can only be an ace or complete a straight or a flush.
+var Bug = Variant{Name: "bug", Joker: true, Bug: true}
+
+// DeucesWild is poker with a 52 card deck, where the four twos are wild.
+var DeucesWild = Variant{Name: "deuces-wild", WildRank: "2"}
+
+// Variants lists the variants that can be found by name with ParseVariant.
+var Variants = []Variant{Standard, ShortDeck, ShortDeckTrips, Jokers, Bug, DeucesWild}
+
+// ParseVariant returns the variant with the given name, e.g. "short-deck". An empty name is the standard variant.
+func ParseVariant(name string) (Variant, error) {
+	if name == "" {
+		return Standard, nil
+	}
+
+	var names []string
+	for _, v := range Variants {
+		if strings.EqualFold(v.Name, name) {
+			return v, nil
+		}
+		names = append(names, v.Name)
+	}
+
+	return Variant{}, fmt.Errorf("invalid variant: %s. variant should be %s", name, strings.Join(names, ", "))
+}
+
+// String returns the name of the variant.
+func (v Variant) String() string {
+	if v.Name == "" {
+		return Standard.Name
+	}
+
+	return v.Name
+}
+
+// ranks returns the ranks of the deck in ascending order.
+func (v Variant) ranks() []string {
+	if len(v.Ranks) == 0 {
+		return types.Ranks
+	}
+
+	return v.Ranks
+}
+
+// lowestRank returns the value of the lowest rank of the deck, 2 for the standard deck.
+func (v Variant) lowestRank() int {
+	if len(v.Ranks) == 0 {
+		return types.RankMap["2"]
+	}
+
+	return types.RankMap[v.Ranks[0]]
+}
+
+// standard reports whether the variant ranks hands like standard poker.
+func (v Variant) standard() bool {
+	return v.lowestRank() == types.RankMap["2"] && !v.FlushBeatsFullHouse && !v.TripsBeatStraight && !v.wild()
+}
+
+// wild reports whether the variant has wild cards.
+func (v Variant) wild() bool {
+	return v.Joker || v.WildRank != ""
+}
+
+// isWild reports whether the card is wild in the variant.
+func (v Variant) isWild(c types.Card) bool {
+	return (v.Joker && c.IsJoker()) || (v.WildRank != "" && c.Rank == v.WildRank)
+}
+
+// strength returns the position of a rank order in the ranking of the variant, 1 for the strongest category.
+// Wild card variants shift every category down by one to make room for five of a kind.
+func (v Variant) strength(rankOrder int) int {
+	strength := rankOrder
+	switch {
+	case v.FlushBeatsFullHouse && rankOrder == 4:
+		strength = 5
+	case v.FlushBeatsFullHouse && rankOrder == 5:
+		strength = 4
+	case v.TripsBeatStraight && rankOrder == 6:
+		strength = 7
+	case v.TripsBeatStraight && rankOrder == 7:
+		strength = 6
+	}
+	if v.wild() {
+		strength++
+	}
+
+	return strength
+}
+
+// hasRank reports whether the rank is in the deck of the variant.
+func (v Variant) hasRank(rank string) bool {
+	for _, r := range v.ranks() {
+		if r == rank {
+			return true
+		}
+	}
+
+	return false
+}
+
+// InDeck reports whether the card is in the deck of the variant, e.g. false for 2S in short-deck.
+func (v Variant) InDeck(c types.Card) bool {
+	if c.IsJoker() {
+		return v.Joker
+	}
+
+	_, ok := types.SuitMap[c.Suit]
+	return ok && v.hasRank(c.Rank)
+}
+
+// NewDeck creates a new ordered deck with the ranks of the variant, e.g. 36 cards for short-deck,
+// and the joker last if the variant has one. See NewDeck for the random number generator.
+func (v Variant) NewDeck(rng *rand.Rand) *Deck {
+	d := NewDeck(rng)
+	if len(v.Ranks) > 0 {
+		cards := d.Cards[:0]
+		for _, c := range d.Cards {
+			if v.hasRank(c.Rank) {
+				cards = append(cards, c)
+			}
+		}
+		d.Cards = cards
+	}
+	if v.Joker {
+		d.Cards = append(d.Cards, types.Joker)
+	}
+
+	return d
+}
+
+// Validate checks the hand like Hand.Validate, and that every card is in the deck of the variant.
+// The joker is valid in variants that have one.
+func (v Variant) Validate(h Hand) error {
+	if err := h.validate(v.Joker); err != nil {
+		return err
+	}
+
+	for _, c := range h.Cards {
+		if !v.InDeck(c) {
+			return fmt.Errorf("hand %d: card %s is not used in %s", h.HandID, c, v)
+		}
+	}
+
+	return nil
+}
+
+// Evaluate returns the name and the rank order of the hand like Hand.Evaluate, recognizing the wheel of the variant.
+// The rank order is the category of the hand, so the same category always has the same rank order and name,
+// while the order of the categories in the variant is kept by Score.
+func (v Variant) Evaluate(h Hand) (string, int) {
+	if v.standard() {
+		return h.Evaluate()
+	}
+
+	rankOrder, kickers := v.categorize(h.Cards)
+	if len(h.Cards) != handCardCount {
+		return invalidHandName, InvalidRankOrder
+	}
+	if rankOrder == 10 {
+		// the high card may be a wild card playing an ace in bug mode
+		return fmt.Sprintf("High Card - {%s}", types.RankMapReverse[kickers[0]]), rankOrder
+	}
+
+	return RankName(rankOrder), rankOrder
+}
+
+// Score returns a comparable number for the hand under the rules of the variant, like Hand.Score.
+// A smaller score is a stronger hand, and the strength of the category in the variant is kept in the high bits,
+// so a flush scores lower than a full house in short-deck.
+func (v Variant) Score(h Hand) int {
+	_, score := v.rankCards(h.Cards)
+	return score
+}
+
+// BestHand returns the strongest five card hand that can be made from the given cards under the rules of the variant.
+// See BestHand.
+func (v Variant) BestHand(handID int, cards []types.Card) (Hand, error) {
+	if len(cards) < handCardCount {
+		return Hand{}, fmt.Errorf("need at least %d cards to make a hand, got %d", handCardCount, len(cards))
+	}
+
+	var best Hand
+	bestScore := 0
+
+	enumerate(cards, handCardCount, func(combination []types.Card) {
+		hand := Hand{HandID: handID, Cards: combination}
+		if score := v.Score(hand); bestScore == 0 || score < bestScore {
+			best, bestScore = Hand{HandID: handID, Cards: append([]types.Card(nil), combination...)}, score
+		}
+	})
+
+	return best, nil
+}
+
+// EvaluateHands evaluates the hands under the rules of the variant like EvaluateHands, strongest first.
+// Hands that tie keep their given order.
+func (v Variant) EvaluateHands(hands Hands) []HandResult {
+	if v.standard() {
+		return EvaluateHands(hands)
+	}
+
+	results := make([]HandResult, len(hands))
+	for i, hand := range hands {
+		rank, rankOrder := v.Evaluate(hand)
+		results[i] = HandResult{HandID: hand.HandID, Card: hand.Cards, Rank: rank, RankOrder: rankOrder, Score: v.Score(hand)}
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].Score < results[j].Score
+	})
+
+	return results
+}
@@ -0,0 +1,404 @@
+package poker
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+
+	"github.com/YoungsoonLee/poker/types"
+)
+
+func TestParseVariant(t *testing.T) {
+	tests := []struct {
+		name    string
+		want    string
+		wantErr bool
+	}{
+		{name: "", want: "standard"},
+		{name: "standard", want: "standard"},
+		{name: "Short-Deck", want: "short-deck"},
+		{name: "short-deck-trips", want: "short-deck-trips"},
+		{name: "Deuces-Wild", want: "deuces-wild"},
+		{name: "bug", want: "bug"},
+		{name: "razz", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseVariant(tt.name)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseVariant() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && got.String() != tt.want {
+				t.Errorf("ParseVariant() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestVariant_NewDeck(t *testing.T) {
+	tests := []struct {
+		name    string
+		v       Variant
+		want    int
+		wantOut string
+	}{
+		{name: "zero value", v: Variant{}, want: 52},
+		{name: "standard", v: Standard, want: 52},
+		{name: "short-deck", v: ShortDeck, want: 36, wantOut: "2345"},
+		{name: "jokers", v: Jokers, want: 53},
+		{name: "deuces wild", v: DeucesWild, want: 52},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := tt.v.NewDeck(nil)
+			if d.Len() != tt.want {
+				t.Fatalf("Variant.NewDeck() has %d cards, want %d", d.Len(), tt.want)
+			}
+			for _, c := range d.Cards {
+				for _, r := range tt.wantOut {
+					if c.Rank == string(r) {
+						t.Errorf("Variant.NewDeck() has %s", c)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestVariant_Compare(t *testing.T) {
+	tests := []struct {
+		name string
+		v    Variant
+		a    string
+		b    string
+		want int
+	}{
+		{name: "standard full house beats flush", v: Standard, a: "2S2H2D3C3S", b: "AH8H7H6H9H", want: 1},
+		{name: "short-deck flush beats full house", v: ShortDeck, a: "2S2H2D3C3S", b: "AH8H7H6HTH", want: -1},
+		{name: "short-deck straight beats trips", v: ShortDeck, a: "9S9H9DKCQS", b: "TS9H8D7C6S", want: -1},
+		{name: "trips beat a straight with the trips rule", v: ShortDeckTrips, a: "9S9H9DKCQS", b: "TS9H8D7C6S", want: 1},
+		{name: "short-deck wheel is a straight", v: ShortDeck, a: "AS6H7D8C9S", b: "KSKHKDQCJS", want: 1},
+		{name: "short-deck wheel is the lowest straight", v: ShortDeck, a: "AS6H7D8C9S", b: "6S7H8D9CTS", want: -1},
+		{name: "A-2-3-4-5 is not a short-deck wheel", v: ShortDeck, a: "AS2H3D4C5S", b: "KS6H7D8CTS", want: 1},
+		{name: "A-6-7-8-9 is not a standard straight", v: Standard, a: "AS6H7D8C9S", b: "2S2H3D4C5S", want: -1},
+		{name: "short-deck wheel straight flush", v: ShortDeck, a: "AS6S7S8S9S", b: "KSKHKDKCJS", want: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, b := tt.v.Score(Hand{Cards: mustCards(t, tt.a)}), tt.v.Score(Hand{Cards: mustCards(t, tt.b)})
+			got := 0
+			switch {
+			case a < b:
+				got = 1
+			case a > b:
+				got = -1
+			}
+			if got != tt.want {
+				t.Errorf("Variant.Score() of %s = %d and %s = %d, want %d", tt.a, a, tt.b, b, tt.want)
+			}
+		})
+	}
+}
+
+func TestVariant_Evaluate(t *testing.T) {
+	tests := []struct {
+		name     string
+		v        Variant
+		cards    string
+		want     string
+		wantRank int
+	}{
+		{name: "short-deck wheel", v: ShortDeck, cards: "AS6H7D8C9S", want: "Straight", wantRank: 6},
+		{name: "short-deck wheel straight flush", v: ShortDeck, cards: "AS6S7S8S9S", want: "Straight Flush", wantRank: 2},
+		{name: "flush keeps its rank order", v: ShortDeck, cards: "AH8H7H6HTH", want: "Flush", wantRank: 5},
+		{name: "standard high card", v: Standard, cards: "AS6H7D8C9S", want: "High Card - {A}", wantRank: 10},
+		{name: "short hand", v: ShortDeck, cards: "ASAH", want: "Invalid Hand", wantRank: InvalidRankOrder},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got, gotRank := tt.v.Evaluate(Hand{Cards: mustCards(t, tt.cards)}); got != tt.want || gotRank != tt.wantRank {
+				t.Errorf("Variant.Evaluate() = %s, %d, want %s, %d", got, gotRank, tt.want, tt.wantRank)
+			}
+		})
+	}
+}
+
+func TestVariant_Evaluate_Wild(t *testing.T) {
+	tests := []struct {
+		name     string
+		v        Variant
+		cards    string
+		want     string
+		wantRank int
+	}{
+		{name: "five aces", v: Jokers, cards: "ASAHADACXX", want: "Five of a Kind", wantRank: 0},
+		{name: "joker completes a royal flush", v: Jokers, cards: "KSQSJSTSXX", want: "Royal Flush", wantRank: 1},
+		{name: "joker makes the highest straight", v: Jokers, cards: "2S3S4S5HXX", want: "Straight", wantRank: 6},
+		{name: "joker makes four of a kind", v: Jokers, cards: "KSKHKD9CXX", want: "Four of a Kind", wantRank: 3},
+		{name: "joker pairs the highest card", v: Jokers, cards: "AS2H7D9CXX", want: "One Pair", wantRank: 9},
+		{name: "bug is an ace", v: Bug, cards: "KSKHKD9CXX", want: "Three of a Kind", wantRank: 7},
+		{name: "bug is an ace high card", v: Bug, cards: "KS2H7D9CXX", want: "High Card - {A}", wantRank: 10},
+		{name: "bug completes a straight", v: Bug, cards: "KSQSJSTHXX", want: "Straight", wantRank: 6},
+		{name: "bug completes a flush", v: Bug, cards: "KS9S7S3SXX", want: "Flush", wantRank: 5},
+		{name: "bug makes five aces", v: Bug, cards: "ASAHADACXX", want: "Five of a Kind", wantRank: 0},
+		{name: "deuces make four aces", v: DeucesWild, cards: "2S2HADACKS", want: "Four of a Kind", wantRank: 3},
+		{name: "four deuces", v: DeucesWild, cards: "2S2H2D2CAS", want: "Five of a Kind", wantRank: 0},
+		{name: "deuces wild without deuces", v: DeucesWild, cards: "3S4S5S6S7S", want: "Straight Flush", wantRank: 2},
+		{name: "deuces are not wild in standard", v: Standard, cards: "2S2H2DACKS", want: "Three of a Kind", wantRank: 7},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got, gotRank := tt.v.Evaluate(Hand{Cards: mustCards(t, tt.cards)}); got != tt.want || gotRank != tt.wantRank {
+				t.Errorf("Variant.Evaluate() = %s, %d, want %s, %d", got, gotRank, tt.want, tt.wantRank)
+			}
+		})
+	}
+}
+
+func TestVariant_Compare_Wild(t *testing.T) {
+	tests := []struct {
+		name string
+		v    Variant
+		a    string
+		b    string
+		want int
+	}{
+		{name: "five of a kind beats a royal flush", v: Jokers, a: "ASAHADACXX", b: "KHQHJHTHAH", want: 1},
+		{name: "natural four aces beat four kings with the joker", v: Jokers, a: "KSKHKD9CXX", b: "ASAHADACKC", want: -1},
+		{name: "four kings with the joker beat four queens", v: Jokers, a: "KSKHKD9CXX", b: "QSQHQDQCAS", want: 1},
+		{name: "joker makes the highest flush", v: Jokers, a: "AS9S7S3SXX", b: "AHKH9H7H2H", want: 1},
+		{name: "same hand with and without the joker ties", v: Jokers, a: "ASAHKDKCXX", b: "AHADACKSKH", want: 0},
+		{name: "deuces wild straight flush beats four of a kind", v: DeucesWild, a: "2S4H5H6H7H", b: "ASAHADAC3C", want: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, b := tt.v.Score(Hand{Cards: mustCards(t, tt.a)}), tt.v.Score(Hand{Cards: mustCards(t, tt.b)})
+			got := 0
+			switch {
+			case a < b:
+				got = 1
+			case a > b:
+				got = -1
+			}
+			if got != tt.want {
+				t.Errorf("Variant.Score() of %s = %d and %s = %d, want %d", tt.a, a, tt.b, b, tt.want)
+			}
+		})
+	}
+}
+
+func TestVariant_Score_WildNeverWorse(t *testing.T) {
+	rng := rand.New(rand.NewSource(6))
+
+	// the joker can always be the card it replaces, so replacing a card with it never makes a hand weaker
+	for i := 0; i < 1000; i++ {
+		h := randomHand(rng, handCardCount)
+		score := Jokers.Score(h)
+
+		for k := range h.Cards {
+			wild := Hand{Cards: append([]types.Card(nil), h.Cards...)}
+			wild.Cards[k] = types.Joker
+			if got := Jokers.Score(wild); got > score {
+				t.Fatalf("Variant.Score() of %v = %d, weaker than %d of %v", wild.Cards, got, score, h.Cards)
+			}
+		}
+	}
+}
+
+func TestVariant_Frequencies(t *testing.T) {
+	// the number of hands of every category in a 36 card deck, from a royal flush to a high card
+	want := []int{4, 20, 288, 1728, 480, 6120, 16128, 36288, 193536, 122400}
+
+	got := make([]int, len(want))
+	enumerate(ShortDeck.NewDeck(nil).Cards, handCardCount, func(cards []types.Card) {
+		_, rankOrder := ShortDeck.Evaluate(Hand{Cards: cards})
+		got[rankOrder-1]++
+	})
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("short-deck frequencies = %v, want %v", got, want)
+	}
+}
+
+func TestVariant_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		v       Variant
+		cards   string
+		wantErr bool
+	}{
+		{name: "standard", v: Standard, cards: "2S3S4S5S6S"},
+		{name: "short-deck", v: ShortDeck, cards: "6S7S8S9STS"},
+		{name: "two is not in the short deck", v: ShortDeck, cards: "2S7S8S9STS", wantErr: true},
+		{name: "too few cards", v: ShortDeck, cards: "6S7S", wantErr: true},
+		{name: "joker", v: Jokers, cards: "ASKSQSJSXX"},
+		{name: "no joker in standard", v: Standard, cards: "ASKSQSJSXX", wantErr: true},
+		{name: "no joker in deuces wild", v: DeucesWild, cards: "ASKSQSJSXX", wantErr: true},
+		{name: "two jokers", v: Bug, cards: "ASKSQSXXXX", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.v.Validate(Hand{Cards: mustCards(t, tt.cards)}); (err != nil) != tt.wantErr {
+				t.Errorf("Variant.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestVariant_EvaluateHands(t *testing.T) {
+	hands := Hands{
+		{HandID: 1, Cards: mustCards(t, "KSKHKDQCQS")},
+		{HandID: 2, Cards: mustCards(t, "AH8H7H6HTH")},
+		{HandID: 3, Cards: mustCards(t, "AS6H7D8C9S")},
+	}
+
+	var got []int
+	for _, r := range ShortDeck.EvaluateHands(hands) {
+		got = append(got, r.HandID)
+	}
+	if want := []int{2, 1, 3}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Variant.EvaluateHands() order = %v, want %v", got, want)
+	}
+}
+
+func TestVariant_BestHand(t *testing.T) {
+	// the board makes a full house with the pair, but the hearts make a stronger flush in short-deck
+	cards := mustCards(t, "AHTH8H7H6HKSKD")
+
+	best, err := ShortDeck.BestHand(1, cards)
+	if err != nil {
+		t.Fatalf("Variant.BestHand() error = %v", err)
+	}
+	if rank, _ := ShortDeck.Evaluate(best); rank != "Flush" {
+		t.Errorf("Variant.BestHand() = %v, %s, want a flush", best.Cards, rank)
+	}
+
+	if _, err := ShortDeck.BestHand(1, cards[:4]); err == nil {
+		t.Errorf("Variant.BestHand() of 4 cards error = nil, want an error")
+	}
+
+	// the joker completes the royal flush, which beats the four aces it could also make
+	best, err = Jokers.BestHand(1, mustCards(t, "XXASKSQSJSAHAD"))
+	if err != nil {
+		t.Fatalf("Variant.BestHand() error = %v", err)
+	}
+	if rank, _ := Jokers.Evaluate(best); rank != "Royal Flush" {
+		t.Errorf("Variant.BestHand() = %v, %s, want a royal flush", best.Cards, rank)
+	}
+}
+
+func TestVariant_CalculateEquity(t *testing.T) {
+	// on a complete board a flush beats a full house only in short-deck
+	flush, fullHouse := mustCards(t, "AHTH"), mustCards(t, "8S8D")
+	board := mustCards(t, "6H7H8HKSKD")
+
+	tests := []struct {
+		name string
+		v    Variant
+		want []float64
+	}{
+		{name: "standard", v: Standard, want: []float64{0, 1}},
+		{name: "short-deck", v: ShortDeck, want: []float64{1, 0}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			equities, err := tt.v.CalculateEquity([][]types.Card{flush, fullHouse}, board, 100, nil)
+			if err != nil {
+				t.Fatalf("Variant.CalculateEquity() error = %v", err)
+			}
+			for i, e := range equities {
+				if e.Equity != tt.want[i] {
+					t.Errorf("Variant.CalculateEquity()[%d] = %+v, want %v", i, e, tt.want[i])
+				}
+			}
+		})
+	}
+
+	if _, err := ShortDeck.CalculateEquity([][]types.Card{mustCards(t, "AS2D"), mustCards(t, "KHKC")}, nil, 100, nil); err == nil {
+		t.Errorf("Variant.CalculateEquity() with a two error = nil, want an error")
+	}
+
+	// 16 hands use 32 of the 36 cards, which leaves 4 cards for a board of 5
+	cards := ShortDeck.NewDeck(nil).Cards
+	holes := make([][]types.Card, 16)
+	for i := range holes {
+		holes[i] = cards[2*i : 2*i+2]
+	}
+	if _, err := ShortDeck.CalculateEquity(holes, nil, 100, nil); err == nil {
+		t.Errorf("Variant.CalculateEquity() of 16 short-deck hands error = nil, want an error")
+	}
+}
+
+func TestVariant_CalculateEquity_Joker(t *testing.T) {
+	// the joker makes five aces with the board, while the kings only make a full house
+	holes := [][]types.Card{mustCards(t, "XXAS"), mustCards(t, "KHKC")}
+	board := mustCards(t, "ADAC7HKS3D")
+
+	equities, err := Jokers.CalculateEquity(holes, board, 100, nil)
+	if err != nil {
+		t.Fatalf("Variant.CalculateEquity() error = %v", err)
+	}
+	if equities[0].Equity != 1 {
+		t.Errorf("Variant.CalculateEquity()[0] = %+v, want 1", equities[0])
+	}
+
+	if _, err := Standard.CalculateEquity(holes, board, 100, nil); err == nil {
+		t.Errorf("Variant.CalculateEquity() with a joker in standard error = nil, want an error")
+	}
+}
+
+func TestVariant_CalculateEquity_Turn(t *testing.T) {
+	// the aces already make the wheel (A, 6, 7, 8, 9), and the river is one of the 28 cards left in the short deck.
+	// The 4 tens make a straight on the board for both, and the 2 aces the wheel for both.
+	// When trips beat a straight, the last 2 kings win for KK, and the aces win with three of a kind.
+	holes := [][]types.Card{mustCards(t, "ASAD"), mustCards(t, "KHKC")}
+	board := mustCards(t, "6C7D8S9H")
+
+	tests := []struct {
+		name     string
+		v        Variant
+		wantWins []int
+		wantTies int
+	}{
+		{name: "short-deck", v: ShortDeck, wantWins: []int{22, 0}, wantTies: 6},
+		{name: "trips beat a straight", v: ShortDeckTrips, wantWins: []int{22, 2}, wantTies: 4},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			equities, err := tt.v.CalculateEquity(holes, board, 1000, nil)
+			if err != nil {
+				t.Fatalf("Variant.CalculateEquity() error = %v", err)
+			}
+			for i, e := range equities {
+				if wins, ties := int(e.Win*28+0.5), int(e.Tie*28+0.5); wins != tt.wantWins[i] || ties != tt.wantTies {
+					t.Errorf("hand %d wins on %d and ties on %d rivers, want %d and %d", i+1, wins, ties, tt.wantWins[i], tt.wantTies)
+				}
+			}
+		})
+	}
+}
+
+func TestVariant_RangeEquity(t *testing.T) {
+	hole, board := mustCards(t, "ASAD"), mustCards(t, "AHACKD")
+
+	// only the pairs from sixes up are in the short deck, and none of them beats four aces
+	r, err := ParseRange("22+")
+	if err != nil {
+		t.Fatalf("ParseRange() error = %v", err)
+	}
+	e, err := ShortDeck.RangeEquity(hole, r, board, 200, nil)
+	if err != nil {
+		t.Fatalf("Variant.RangeEquity() error = %v", err)
+	}
+	if e.Equity != 1 {
+		t.Errorf("Variant.RangeEquity() = %+v, want 1", e)
+	}
+
+	low, err := ParseRange("22-55")
+	if err != nil {
+		t.Fatalf("ParseRange() error = %v", err)
+	}
+	if _, err := ShortDeck.RangeEquity(hole, low, board, 200, nil); err == nil {
+		t.Errorf("Variant.RangeEquity() with a range without short-deck combos error = nil, want an error")
+	}
+}
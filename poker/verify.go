@@ -0,0 +1,132 @@
+package poker
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/YoungsoonLee/poker/types"
+)
+
+// FiveCardHands is the number of distinct five card hands that can be dealt from a deck of 52 cards.
+const FiveCardHands = 2598960
+
+// EquivalenceClasses is the number of distinct values of five card hands.
+// Hands of the same class, e.g. the four royal flushes, always tie.
+const EquivalenceClasses = 7462
+
+// knownFrequencies are the number of hands and equivalence classes of every rank order, starting with 1 for a royal flush.
+var knownFrequencies = [...]struct{ hands, classes int }{
+	{4, 1},
+	{36, 9},
+	{624, 156},
+	{3744, 156},
+	{5108, 1277},
+	{10200, 10},
+	{54912, 858},
+	{123552, 858},
+	{1098240, 2860},
+	{1302540, 1277},
+}
+
+// Frequency is the number of five card hands of a category found by VerifyFiveCardHands,
+// next to the number expected by combinatorics.
+type Frequency struct {
+	RankOrder   int
+	Rank        string
+	Hands       int
+	WantHands   int
+	Classes     int
+	WantClasses int
+}
+
+// Probability returns the probability of being dealt a hand of the category.
+func (f Frequency) Probability() float64 {
+	return float64(f.Hands) / FiveCardHands
+}
+
+// Verification is the result of evaluating every five card hand.
+// Mismatches counts the hands whose category from Evaluate differs from the one of their Score.
+type Verification struct {
+	Hands       int
+	Classes     int
+	Mismatches  int
+	Frequencies []Frequency
+}
+
+// VerifyFiveCardHands enumerates all 2,598,960 five card hands, evaluates each with Evaluate and Score,
+// and counts the hands and the distinct scores of every category.
+// Use Err to compare the counts with the known combinatorial frequencies.
+func VerifyFiveCardHands() Verification {
+	v := Verification{Frequencies: make([]Frequency, len(knownFrequencies))}
+	for i, want := range knownFrequencies {
+		v.Frequencies[i] = Frequency{RankOrder: i + 1, Rank: RankName(i + 1), WantHands: want.hands, WantClasses: want.classes}
+	}
+
+	classes := make([]map[int]bool, len(knownFrequencies))
+	for i := range classes {
+		classes[i] = make(map[int]bool)
+	}
+	all := make(map[int]bool, EquivalenceClasses)
+
+	deck := NewDeck(nil).Cards
+	cards := make([]types.Card, handCardCount)
+	var choose func(start, depth int)
+	choose = func(start, depth int) {
+		if depth == handCardCount {
+			hand := Hand{Cards: cards}
+			_, rankOrder := hand.Evaluate()
+			score := hand.Score()
+			if score>>(scoreKickerBits*handCardCount) != rankOrder {
+				v.Mismatches++
+			}
+
+			v.Hands++
+			v.Frequencies[rankOrder-1].Hands++
+			classes[rankOrder-1][score] = true
+			all[score] = true
+			return
+		}
+
+		for i := start; i <= len(deck)-(handCardCount-depth); i++ {
+			cards[depth] = deck[i]
+			choose(i+1, depth+1)
+		}
+	}
+	choose(0, 0)
+
+	for i := range v.Frequencies {
+		v.Frequencies[i].Classes = len(classes[i])
+	}
+	v.Classes = len(all)
+
+	return v
+}
+
+// Err returns an error describing every count that differs from the known combinatorial frequencies,
+// or nil if the evaluator is right about every hand.
+func (v Verification) Err() error {
+	var problems []string
+	if v.Hands != FiveCardHands {
+		problems = append(problems, fmt.Sprintf("%d hands, want %d", v.Hands, FiveCardHands))
+	}
+	if v.Classes != EquivalenceClasses {
+		problems = append(problems, fmt.Sprintf("%d equivalence classes, want %d", v.Classes, EquivalenceClasses))
+	}
+	if v.Mismatches > 0 {
+		problems = append(problems, fmt.Sprintf("%d hands whose score disagrees with Evaluate", v.Mismatches))
+	}
+	for _, f := range v.Frequencies {
+		if f.Hands != f.WantHands {
+			problems = append(problems, fmt.Sprintf("%d %s hands, want %d", f.Hands, f.Rank, f.WantHands))
+		}
+		if f.Classes != f.WantClasses {
+			problems = append(problems, fmt.Sprintf("%d %s classes, want %d", f.Classes, f.Rank, f.WantClasses))
+		}
+	}
+
+	if len(problems) == 0 {
+		return nil
+	}
+
+	return fmt.Errorf("verification failed: %s", strings.Join(problems, "; "))
+}
@@ -0,0 +1,57 @@
+package poker
+
+import (
+	"math"
+	"testing"
+)
+
+func TestVerifyFiveCardHands(t *testing.T) {
+	if testing.Short() {
+		t.Skip("evaluates all 2,598,960 five card hands")
+	}
+
+	v := VerifyFiveCardHands()
+	if err := v.Err(); err != nil {
+		t.Fatal(err)
+	}
+
+	var sum float64
+	for _, f := range v.Frequencies {
+		sum += f.Probability()
+	}
+	if math.Abs(sum-1) > 1e-9 {
+		t.Errorf("sum of probabilities = %v, want 1", sum)
+	}
+}
+
+func TestVerification_Err(t *testing.T) {
+	valid := func() Verification {
+		v := Verification{Hands: FiveCardHands, Classes: EquivalenceClasses}
+		for i, want := range knownFrequencies {
+			v.Frequencies = append(v.Frequencies, Frequency{RankOrder: i + 1, Rank: RankName(i + 1), Hands: want.hands, WantHands: want.hands, Classes: want.classes, WantClasses: want.classes})
+		}
+		return v
+	}
+
+	tests := []struct {
+		name    string
+		modify  func(v *Verification)
+		wantErr bool
+	}{
+		{name: "valid", modify: func(v *Verification) {}},
+		{name: "missing hands", modify: func(v *Verification) { v.Hands-- }, wantErr: true},
+		{name: "wrong classes", modify: func(v *Verification) { v.Classes = 7461 }, wantErr: true},
+		{name: "score disagrees", modify: func(v *Verification) { v.Mismatches = 1 }, wantErr: true},
+		{name: "wrong category count", modify: func(v *Verification) { v.Frequencies[5].Hands = 10220 }, wantErr: true},
+		{name: "wrong category classes", modify: func(v *Verification) { v.Frequencies[0].Classes = 4 }, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := valid()
+			tt.modify(&v)
+			if err := v.Err(); (err != nil) != tt.wantErr {
+				t.Errorf("Verification.Err() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
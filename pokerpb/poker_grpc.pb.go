@@ -0,0 +1,275 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.4.0
+// - protoc             (unknown)
+// source: poker.proto
+
+// Package poker.v1 is the gRPC API of the poker hand evaluator.
+
+package pokerpb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.62.0 or later.
+const _ = grpc.SupportPackageIsVersion8
+
+const (
+	PokerService_Evaluate_FullMethodName   = "/poker.v1.PokerService/Evaluate"
+	PokerService_Rank_FullMethodName       = "/poker.v1.PokerService/Rank"
+	PokerService_RankStream_FullMethodName = "/poker.v1.PokerService/RankStream"
+	PokerService_Equity_FullMethodName     = "/poker.v1.PokerService/Equity"
+)
+
+// PokerServiceClient is the client API for PokerService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// PokerService evaluates hands and calculates equity.
+// Invalid requests fail with INVALID_ARGUMENT and a google.rpc.BadRequest detail with the problem of every field.
+type PokerServiceClient interface {
+	// Evaluate evaluates the best five card hand of five to seven cards.
+	Evaluate(ctx context.Context, in *EvaluateRequest, opts ...grpc.CallOption) (*EvaluateResponse, error)
+	// Rank ranks the hands of a showdown.
+	Rank(ctx context.Context, in *RankRequest, opts ...grpc.CallOption) (*RankResponse, error)
+	// RankStream ranks a stream of showdowns on a pool of workers, answering in the order of the requests.
+	// An invalid showdown ends the stream with INVALID_ARGUMENT.
+	RankStream(ctx context.Context, opts ...grpc.CallOption) (PokerService_RankStreamClient, error)
+	// Equity calculates the Texas Hold'em equity of hole cards with a board.
+	Equity(ctx context.Context, in *EquityRequest, opts ...grpc.CallOption) (*EquityResponse, error)
+}
+
+type pokerServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewPokerServiceClient(cc grpc.ClientConnInterface) PokerServiceClient {
+	return &pokerServiceClient{cc}
+}
+
+func (c *pokerServiceClient) Evaluate(ctx context.Context, in *EvaluateRequest, opts ...grpc.CallOption) (*EvaluateResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(EvaluateResponse)
+	err := c.cc.Invoke(ctx, PokerService_Evaluate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pokerServiceClient) Rank(ctx context.Context, in *RankRequest, opts ...grpc.CallOption) (*RankResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RankResponse)
+	err := c.cc.Invoke(ctx, PokerService_Rank_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pokerServiceClient) RankStream(ctx context.Context, opts ...grpc.CallOption) (PokerService_RankStreamClient, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &PokerService_ServiceDesc.Streams[0], PokerService_RankStream_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &pokerServiceRankStreamClient{ClientStream: stream}
+	return x, nil
+}
+
+type PokerService_RankStreamClient interface {
+	Send(*RankRequest) error
+	Recv() (*RankResponse, error)
+	grpc.ClientStream
+}
+
+type pokerServiceRankStreamClient struct {
+	grpc.ClientStream
+}
+
+func (x *pokerServiceRankStreamClient) Send(m *RankRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *pokerServiceRankStreamClient) Recv() (*RankResponse, error) {
+	m := new(RankResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *pokerServiceClient) Equity(ctx context.Context, in *EquityRequest, opts ...grpc.CallOption) (*EquityResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(EquityResponse)
+	err := c.cc.Invoke(ctx, PokerService_Equity_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// PokerServiceServer is the server API for PokerService service.
+// All implementations must embed UnimplementedPokerServiceServer
+// for forward compatibility
+//
+// PokerService evaluates hands and calculates equity.
+// Invalid requests fail with INVALID_ARGUMENT and a google.rpc.BadRequest detail with the problem of every field.
+type PokerServiceServer interface {
+	// Evaluate evaluates the best five card hand of five to seven cards.
+	Evaluate(context.Context, *EvaluateRequest) (*EvaluateResponse, error)
+	// Rank ranks the hands of a showdown.
+	Rank(context.Context, *RankRequest) (*RankResponse, error)
+	// RankStream ranks a stream of showdowns on a pool of workers, answering in the order of the requests.
+	// An invalid showdown ends the stream with INVALID_ARGUMENT.
+	RankStream(PokerService_RankStreamServer) error
+	// Equity calculates the Texas Hold'em equity of hole cards with a board.
+	Equity(context.Context, *EquityRequest) (*EquityResponse, error)
+	mustEmbedUnimplementedPokerServiceServer()
+}
+
+// UnimplementedPokerServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedPokerServiceServer struct {
+}
+
+func (UnimplementedPokerServiceServer) Evaluate(context.Context, *EvaluateRequest) (*EvaluateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Evaluate not implemented")
+}
+func (UnimplementedPokerServiceServer) Rank(context.Context, *RankRequest) (*RankResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Rank not implemented")
+}
+func (UnimplementedPokerServiceServer) RankStream(PokerService_RankStreamServer) error {
+	return status.Errorf(codes.Unimplemented, "method RankStream not implemented")
+}
+func (UnimplementedPokerServiceServer) Equity(context.Context, *EquityRequest) (*EquityResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Equity not implemented")
+}
+func (UnimplementedPokerServiceServer) mustEmbedUnimplementedPokerServiceServer() {}
+
+// UnsafePokerServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to PokerServiceServer will
+// result in compilation errors.
+type UnsafePokerServiceServer interface {
+	mustEmbedUnimplementedPokerServiceServer()
+}
+
+func RegisterPokerServiceServer(s grpc.ServiceRegistrar, srv PokerServiceServer) {
+	s.RegisterService(&PokerService_ServiceDesc, srv)
+}
+
+func _PokerService_Evaluate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EvaluateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PokerServiceServer).Evaluate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PokerService_Evaluate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PokerServiceServer).Evaluate(ctx, req.(*EvaluateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PokerService_Rank_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RankRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PokerServiceServer).Rank(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PokerService_Rank_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PokerServiceServer).Rank(ctx, req.(*RankRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PokerService_RankStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(PokerServiceServer).RankStream(&pokerServiceRankStreamServer{ServerStream: stream})
+}
+
+type PokerService_RankStreamServer interface {
+	Send(*RankResponse) error
+	Recv() (*RankRequest, error)
+	grpc.ServerStream
+}
+
+type pokerServiceRankStreamServer struct {
+	grpc.ServerStream
+}
+
+func (x *pokerServiceRankStreamServer) Send(m *RankResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *pokerServiceRankStreamServer) Recv() (*RankRequest, error) {
+	m := new(RankRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _PokerService_Equity_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EquityRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PokerServiceServer).Equity(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PokerService_Equity_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PokerServiceServer).Equity(ctx, req.(*EquityRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// PokerService_ServiceDesc is the grpc.ServiceDesc for PokerService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var PokerService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "poker.v1.PokerService",
+	HandlerType: (*PokerServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Evaluate",
+			Handler:    _PokerService_Evaluate_Handler,
+		},
+		{
+			MethodName: "Rank",
+			Handler:    _PokerService_Rank_Handler,
+		},
+		{
+			MethodName: "Equity",
+			Handler:    _PokerService_Equity_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "RankStream",
+			Handler:       _PokerService_RankStream_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "poker.proto",
+}
@@ -0,0 +1,167 @@
+// Package client calls the gRPC poker service with the types of the poker package.
+package client
+
+import (
+	"context"
+	"io"
+
+	"github.com/YoungsoonLee/poker/poker"
+	"github.com/YoungsoonLee/poker/pokerpb"
+	"github.com/YoungsoonLee/poker/types"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// Client is a client of the poker service.
+type Client struct {
+	conn *grpc.ClientConn
+	pb   pokerpb.PokerServiceClient
+}
+
+// Dial creates a client of the service at target, e.g. "localhost:9090".
+// Without options the connection is not encrypted, as the service is meant to be called from the same network.
+func Dial(target string, opts ...grpc.DialOption) (*Client, error) {
+	if len(opts) == 0 {
+		opts = []grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())}
+	}
+
+	conn, err := grpc.NewClient(target, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Client{conn: conn, pb: pokerpb.NewPokerServiceClient(conn)}, nil
+}
+
+// Close closes the connection of the client.
+func (c *Client) Close() error {
+	return c.conn.Close()
+}
+
+// Evaluate evaluates the best five card hand of five to seven cards.
+func (c *Client) Evaluate(ctx context.Context, cards []types.Card) (poker.HandResult, error) {
+	resp, err := c.pb.Evaluate(ctx, &pokerpb.EvaluateRequest{Hand: &pokerpb.Hand{Cards: toCards(cards)}})
+	if err != nil {
+		return poker.HandResult{}, err
+	}
+
+	return fromResult(resp.GetResult()), nil
+}
+
+// Rank ranks the hands of a showdown like poker.EvaluateHands. Hand ids are the position of the hands, starting at 1.
+func (c *Client) Rank(ctx context.Context, hands poker.Hands) ([]poker.HandResult, error) {
+	resp, err := c.pb.Rank(ctx, rankRequest(0, hands))
+	if err != nil {
+		return nil, err
+	}
+
+	return fromResults(resp.GetResults()), nil
+}
+
+// RankStream ranks the showdowns received from jobs on the server, and calls handle with their results in the order of the jobs.
+// It returns once jobs is closed and every result is handled, or with the first error of the stream or of handle.
+func (c *Client) RankStream(ctx context.Context, jobs <-chan poker.StreamJob, handle func(poker.StreamResult) error) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	stream, err := c.pb.RankStream(ctx)
+	if err != nil {
+		return err
+	}
+
+	sendErr := make(chan error, 1)
+	go func() {
+		for {
+			select {
+			case job, ok := <-jobs:
+				if !ok {
+					sendErr <- stream.CloseSend()
+					return
+				}
+				if err := stream.Send(rankRequest(int64(job.ID), job.Hands)); err != nil {
+					// the error of the stream is returned by Recv
+					sendErr <- nil
+					return
+				}
+			case <-ctx.Done():
+				sendErr <- nil
+				return
+			}
+		}
+	}()
+
+	for {
+		resp, err := stream.Recv()
+		if err == io.EOF {
+			return <-sendErr
+		}
+		if err != nil {
+			return err
+		}
+
+		if err := handle(poker.StreamResult{ID: int(resp.GetId()), Results: fromResults(resp.GetResults())}); err != nil {
+			return err
+		}
+	}
+}
+
+// Equity calculates the Texas Hold'em equity of the hole cards of every player with a board.
+// Iterations defaults to 10000 on the server if it is 0, and a seed other than 0 makes the random run-outs reproducible.
+func (c *Client) Equity(ctx context.Context, holes [][]types.Card, board []types.Card, iterations int, seed int64) ([]poker.Equity, error) {
+	req := &pokerpb.EquityRequest{Board: toCards(board), Iterations: int32(iterations), Seed: seed}
+	for _, h := range holes {
+		req.Holes = append(req.Holes, &pokerpb.Hand{Cards: toCards(h)})
+	}
+
+	resp, err := c.pb.Equity(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	equities := make([]poker.Equity, len(resp.GetEquities()))
+	for i, e := range resp.GetEquities() {
+		equities[i] = poker.Equity{Win: e.GetWin(), Tie: e.GetTie(), Equity: e.GetEquity()}
+	}
+
+	return equities, nil
+}
+
+// rankRequest converts the hands of a showdown to a request.
+func rankRequest(id int64, hands poker.Hands) *pokerpb.RankRequest {
+	req := &pokerpb.RankRequest{Id: id}
+	for _, h := range hands {
+		req.Hands = append(req.Hands, &pokerpb.Hand{Cards: toCards(h.Cards)})
+	}
+
+	return req
+}
+
+// fromResults converts the results of a response.
+func fromResults(pb []*pokerpb.HandResult) []poker.HandResult {
+	results := make([]poker.HandResult, len(pb))
+	for i, r := range pb {
+		results[i] = fromResult(r)
+	}
+
+	return results
+}
+
+// fromResult converts a result of a response.
+func fromResult(r *pokerpb.HandResult) poker.HandResult {
+	result := poker.HandResult{HandID: int(r.GetHandId()), Rank: r.GetRank(), RankOrder: int(r.GetRankOrder()), Score: int(r.GetScore())}
+	for _, c := range r.GetCards() {
+		result.Card = append(result.Card, types.Card{Rank: c.GetRank(), Suit: c.GetSuit()})
+	}
+
+	return result
+}
+
+// toCards converts cards to their messages.
+func toCards(cards []types.Card) []*pokerpb.Card {
+	pb := make([]*pokerpb.Card, len(cards))
+	for i, c := range cards {
+		pb[i] = &pokerpb.Card{Rank: c.Rank, Suit: c.Suit}
+	}
+
+	return pb
+}
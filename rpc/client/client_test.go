@@ -0,0 +1,144 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"math"
+	"net"
+	"testing"
+
+	"github.com/YoungsoonLee/poker/poker"
+	"github.com/YoungsoonLee/poker/pokerpb"
+	"github.com/YoungsoonLee/poker/rpc"
+	"github.com/YoungsoonLee/poker/types"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// newClient serves the service on an in-process listener and returns a client of it.
+func newClient(t *testing.T) *Client {
+	t.Helper()
+
+	lis := bufconn.Listen(1 << 20)
+	s := grpc.NewServer()
+	pokerpb.RegisterPokerServiceServer(s, rpc.NewServer(2))
+	go s.Serve(lis)
+	t.Cleanup(s.Stop)
+
+	c, err := Dial("passthrough:///bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("Dial() error = %v", err)
+	}
+	t.Cleanup(func() { c.Close() })
+
+	return c
+}
+
+// mustCards parses a card string like "AsKd" into cards.
+func mustCards(t *testing.T, s string) []types.Card {
+	t.Helper()
+
+	cards, err := types.ParseCards(s)
+	if err != nil {
+		t.Fatalf("types.ParseCards() error = %v", err)
+	}
+
+	return cards
+}
+
+func TestClient_Evaluate(t *testing.T) {
+	c := newClient(t)
+
+	got, err := c.Evaluate(context.Background(), mustCards(t, "AsKsQsJsTs2h3d"))
+	if err != nil {
+		t.Fatalf("Client.Evaluate() error = %v", err)
+	}
+	if got.Rank != "Royal Flush" || got.RankOrder != 1 || len(got.Card) != 5 {
+		t.Errorf("Client.Evaluate() = %+v, want a royal flush", got)
+	}
+
+	_, err = c.Evaluate(context.Background(), mustCards(t, "AsKs"))
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Client.Evaluate() error = %v, want %v", err, codes.InvalidArgument)
+	}
+}
+
+func TestClient_Rank(t *testing.T) {
+	c := newClient(t)
+
+	hands := poker.Hands{
+		{HandID: 1, Cards: mustCards(t, "3s4h5d6c7s")},
+		{HandID: 2, Cards: mustCards(t, "AsKsQsJsTs")},
+	}
+	got, err := c.Rank(context.Background(), hands)
+	if err != nil {
+		t.Fatalf("Client.Rank() error = %v", err)
+	}
+
+	want := poker.EvaluateHands(hands)
+	if len(got) != len(want) {
+		t.Fatalf("Client.Rank() = %d results, want %d", len(got), len(want))
+	}
+	for i := range got {
+		if got[i].HandID != want[i].HandID || got[i].Score != want[i].Score || got[i].Rank != want[i].Rank {
+			t.Errorf("Client.Rank()[%d] = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestClient_RankStream(t *testing.T) {
+	c := newClient(t)
+
+	showdowns := []string{"3s4h5d6c7s", "AsKsQsJsTs", "2c2d2h3c3d", "AsKdQh9c8s", "7h7d7c7s2h"}
+	jobs := make(chan poker.StreamJob)
+	go func() {
+		defer close(jobs)
+		for i, s := range showdowns {
+			jobs <- poker.StreamJob{ID: i + 100, Hands: poker.Hands{{HandID: 1, Cards: mustCards(t, s)}}}
+		}
+	}()
+
+	var ids []int
+	err := c.RankStream(context.Background(), jobs, func(r poker.StreamResult) error {
+		ids = append(ids, r.ID)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Client.RankStream() error = %v", err)
+	}
+	for i, id := range ids {
+		if id != i+100 {
+			t.Fatalf("Client.RankStream() ids = %v, want 100 to 104 in order", ids)
+		}
+	}
+	if len(ids) != len(showdowns) {
+		t.Errorf("Client.RankStream() = %d results, want %d", len(ids), len(showdowns))
+	}
+
+	// an error of the handler stops the stream
+	stop := errors.New("stop")
+	jobs = make(chan poker.StreamJob, 1)
+	jobs <- poker.StreamJob{Hands: poker.Hands{{HandID: 1, Cards: mustCards(t, "3s4h5d6c7s")}}}
+	err = c.RankStream(context.Background(), jobs, func(r poker.StreamResult) error { return stop })
+	if !errors.Is(err, stop) {
+		t.Errorf("Client.RankStream() error = %v, want %v", err, stop)
+	}
+}
+
+func TestClient_Equity(t *testing.T) {
+	c := newClient(t)
+
+	// every river is enumerated, and of the 44 cards left the 9 hearts, 3 aces and 3 kings win for AhKh
+	got, err := c.Equity(context.Background(), [][]types.Card{mustCards(t, "AhKh"), mustCards(t, "QsQd")}, mustCards(t, "2h7h9c3s"), 0, 0)
+	if err != nil {
+		t.Fatalf("Client.Equity() error = %v", err)
+	}
+	if len(got) != 2 || math.Abs(got[0].Equity-15.0/44) > 1e-9 || math.Abs(got[1].Equity-29.0/44) > 1e-9 {
+		t.Errorf("Client.Equity() = %+v, want %v and %v", got, 15.0/44, 29.0/44)
+	}
+}
@@ -0,0 +1,272 @@
+// Package rpc serves the poker package over gRPC. The service is defined in pokerpb/poker.proto.
+package rpc
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math/rand"
+	"sync"
+
+	"github.com/YoungsoonLee/poker/internal/validate"
+	"github.com/YoungsoonLee/poker/poker"
+	"github.com/YoungsoonLee/poker/pokerpb"
+	"github.com/YoungsoonLee/poker/types"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Server implements pokerpb.PokerServiceServer.
+type Server struct {
+	pokerpb.UnimplementedPokerServiceServer
+	workers int
+}
+
+// NewServer returns the service, ranking the showdowns of RankStream on the given number of workers, or one per CPU if workers is less than 1.
+// Register it with pokerpb.RegisterPokerServiceServer.
+func NewServer(workers int) *Server {
+	return &Server{workers: workers}
+}
+
+// Evaluate evaluates the best five card hand of five to seven cards.
+func (s *Server) Evaluate(ctx context.Context, req *pokerpb.EvaluateRequest) (*pokerpb.EvaluateResponse, error) {
+	v := newValidator()
+	cards := v.cards("hand.cards", req.GetHand().GetCards(), validate.HandCards, validate.MaxCards)
+	if err := v.err(); err != nil {
+		return nil, err
+	}
+
+	hand, err := poker.BestHand(1, cards)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	rank, rankOrder := hand.Evaluate()
+	result := &pokerpb.HandResult{HandId: 1, Cards: toCards(hand.Cards), Rank: rank, RankOrder: int32(rankOrder), Score: int32(hand.Score()), Winner: true}
+
+	return &pokerpb.EvaluateResponse{Result: result}, nil
+}
+
+// Rank ranks the hands of a showdown.
+func (s *Server) Rank(ctx context.Context, req *pokerpb.RankRequest) (*pokerpb.RankResponse, error) {
+	hands, err := rankHands(req)
+	if err != nil {
+		return nil, err
+	}
+
+	return rankResponse(req.GetId(), poker.EvaluateHands(hands)), nil
+}
+
+// RankStream ranks a stream of showdowns on a pool of workers, answering in the order of the requests.
+// An invalid showdown ends the stream with INVALID_ARGUMENT after the answers of the showdowns before it.
+func (s *Server) RankStream(stream pokerpb.PokerService_RankStreamServer) error {
+	ctx, cancel := context.WithCancel(stream.Context())
+	defer cancel()
+
+	// the ids of the showdowns in flight, in the order they are ranked
+	var mu sync.Mutex
+	var ids []int64
+
+	jobs := make(chan poker.StreamJob)
+	recvErr := make(chan error, 1)
+	go func() {
+		defer close(jobs)
+		for {
+			req, err := stream.Recv()
+			if err == io.EOF {
+				recvErr <- nil
+				return
+			}
+			if err != nil {
+				recvErr <- err
+				return
+			}
+
+			hands, err := rankHands(req)
+			if err != nil {
+				recvErr <- err
+				return
+			}
+
+			mu.Lock()
+			ids = append(ids, req.GetId())
+			mu.Unlock()
+
+			select {
+			case jobs <- poker.StreamJob{Hands: hands}:
+			case <-ctx.Done():
+				recvErr <- ctx.Err()
+				return
+			}
+		}
+	}()
+
+	for r := range poker.EvaluateStream(ctx, jobs, s.workers) {
+		mu.Lock()
+		id := ids[0]
+		ids = ids[1:]
+		mu.Unlock()
+
+		if err := stream.Send(rankResponse(id, r.Results)); err != nil {
+			return err
+		}
+	}
+
+	if err := ctx.Err(); err != nil {
+		return status.FromContextError(err).Err()
+	}
+
+	return <-recvErr
+}
+
+// Equity calculates the Texas Hold'em equity of hole cards with a board, in the variant of the request.
+func (s *Server) Equity(ctx context.Context, req *pokerpb.EquityRequest) (*pokerpb.EquityResponse, error) {
+	v := newValidator()
+	holes := make([][]types.Card, len(req.GetHoles()))
+	for i, h := range req.GetHoles() {
+		holes[i] = v.cards(holeField(i), h.GetCards(), validate.HoleCards, validate.HoleCards)
+	}
+	board := v.cards("board", req.GetBoard(), 0, validate.BoardCards)
+
+	iterations, variant := validate.Equity(v.add, holes, holeField, board, int(req.GetIterations()), req.GetVariant())
+	if err := v.err(); err != nil {
+		return nil, err
+	}
+
+	var rng *rand.Rand
+	if req.GetSeed() != 0 {
+		rng = rand.New(rand.NewSource(req.GetSeed()))
+	}
+
+	equities, err := variant.CalculateEquity(holes, board, iterations, rng)
+	if err != nil {
+		v.add("holes", "%v", err)
+		return nil, v.err()
+	}
+
+	resp := &pokerpb.EquityResponse{}
+	for i, e := range equities {
+		resp.Equities = append(resp.Equities, &pokerpb.Equity{
+			Hole:   &pokerpb.Hand{Cards: toCards(holes[i])},
+			Win:    e.Win,
+			Tie:    e.Tie,
+			Equity: e.Equity,
+		})
+	}
+
+	return resp, nil
+}
+
+// holeField names the field of the hole cards of the player at index i.
+func holeField(i int) string {
+	return fmt.Sprintf("holes[%d].cards", i)
+}
+
+// rankHands validates the hands of a showdown.
+func rankHands(req *pokerpb.RankRequest) (poker.Hands, error) {
+	v := newValidator()
+	if len(req.GetHands()) == 0 {
+		v.add("hands", "should have at least 1 hand")
+	}
+
+	hands := make(poker.Hands, len(req.GetHands()))
+	for i, h := range req.GetHands() {
+		hands[i] = poker.Hand{HandID: i + 1, Cards: v.cards(fmt.Sprintf("hands[%d].cards", i), h.GetCards(), validate.HandCards, validate.HandCards)}
+	}
+	if err := v.err(); err != nil {
+		return nil, err
+	}
+
+	return hands, nil
+}
+
+// rankResponse converts the results of EvaluateHands to a response.
+func rankResponse(id int64, results []poker.HandResult) *pokerpb.RankResponse {
+	resp := &pokerpb.RankResponse{Id: id}
+	for _, r := range results {
+		winner := r.Score == results[0].Score
+		resp.Results = append(resp.Results, &pokerpb.HandResult{
+			HandId:    int32(r.HandID),
+			Cards:     toCards(r.Card),
+			Rank:      r.Rank,
+			RankOrder: int32(r.RankOrder),
+			Score:     int32(r.Score),
+			Winner:    winner,
+		})
+		if winner {
+			resp.Winners = append(resp.Winners, int32(r.HandID))
+		}
+	}
+
+	return resp
+}
+
+// toCards converts cards to their messages.
+func toCards(cards []types.Card) []*pokerpb.Card {
+	pb := make([]*pokerpb.Card, len(cards))
+	for i, c := range cards {
+		pb[i] = &pokerpb.Card{Rank: c.Rank, Suit: c.Suit}
+	}
+
+	return pb
+}
+
+// validator collects the problems of the fields of a request.
+// A card may only be used once across all the fields of a request.
+type validator struct {
+	violations []*errdetails.BadRequest_FieldViolation
+	seen       map[types.Card]string
+}
+
+func newValidator() *validator {
+	return &validator{seen: make(map[types.Card]string)}
+}
+
+// add adds the problem of a field.
+func (v *validator) add(field, format string, args ...any) {
+	v.violations = append(v.violations, &errdetails.BadRequest_FieldViolation{Field: field, Description: fmt.Sprintf(format, args...)})
+}
+
+// cards parses the cards of a field, which should have between min and max cards.
+// Ranks and suits are not case sensitive.
+func (v *validator) cards(field string, pb []*pokerpb.Card, min, max int) []types.Card {
+	cards := make([]types.Card, 0, len(pb))
+	for i, c := range pb {
+		card, err := types.ParseCard(c.GetRank() + c.GetSuit())
+		if err != nil {
+			v.add(fmt.Sprintf("%s[%d]", field, i), "%v", err)
+			continue
+		}
+
+		if other, ok := v.seen[card]; ok {
+			v.add(fmt.Sprintf("%s[%d]", field, i), "card %s is also used in %s", card, other)
+			continue
+		}
+		v.seen[card] = field
+		cards = append(cards, card)
+	}
+
+	switch {
+	case min == max && len(pb) != min:
+		v.add(field, "should have %d cards, got %d", min, len(pb))
+	case len(pb) < min || len(pb) > max:
+		v.add(field, "should have %d to %d cards, got %d", min, max, len(pb))
+	}
+
+	return cards
+}
+
+// err returns an INVALID_ARGUMENT status with the problems of every field, or nil if there are none.
+func (v *validator) err() error {
+	if len(v.violations) == 0 {
+		return nil
+	}
+
+	st, err := status.New(codes.InvalidArgument, "invalid request").WithDetails(&errdetails.BadRequest{FieldViolations: v.violations})
+	if err != nil {
+		return status.Error(codes.InvalidArgument, "invalid request")
+	}
+
+	return st.Err()
+}
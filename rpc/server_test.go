@@ -0,0 +1,253 @@
+package rpc
+
+import (
+	"context"
+	"io"
+	"math"
+	"net"
+	"reflect"
+	"testing"
+
+	"github.com/YoungsoonLee/poker/pokerpb"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// newClient serves the service on an in-process listener and returns a client of it.
+func newClient(t *testing.T) pokerpb.PokerServiceClient {
+	t.Helper()
+
+	lis := bufconn.Listen(1 << 20)
+	s := grpc.NewServer()
+	pokerpb.RegisterPokerServiceServer(s, NewServer(2))
+	go s.Serve(lis)
+	t.Cleanup(s.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("grpc.NewClient() error = %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	return pokerpb.NewPokerServiceClient(conn)
+}
+
+// hand parses a card string like "AsKd" into a hand message, two characters per card.
+func hand(s string) *pokerpb.Hand {
+	h := &pokerpb.Hand{}
+	for i := 0; i+1 < len(s); i += 2 {
+		h.Cards = append(h.Cards, &pokerpb.Card{Rank: s[i : i+1], Suit: s[i+1 : i+2]})
+	}
+
+	return h
+}
+
+// violations returns the fields of the bad request detail of an error.
+func violations(t *testing.T, err error) []string {
+	t.Helper()
+
+	st := status.Convert(err)
+	if st.Code() != codes.InvalidArgument {
+		t.Fatalf("code = %v, want %v", st.Code(), codes.InvalidArgument)
+	}
+
+	var fields []string
+	for _, d := range st.Details() {
+		if br, ok := d.(*errdetails.BadRequest); ok {
+			for _, v := range br.GetFieldViolations() {
+				fields = append(fields, v.GetField())
+			}
+		}
+	}
+
+	return fields
+}
+
+func TestServer_Evaluate(t *testing.T) {
+	client := newClient(t)
+
+	tests := []struct {
+		name       string
+		hand       string
+		wantRank   string
+		wantFields []string
+	}{
+		{name: "five cards", hand: "3s4h5d6c7s", wantRank: "Straight"},
+		{name: "best five of seven", hand: "AsKsQsJsTs2h3d", wantRank: "Royal Flush"},
+		{name: "too few cards", hand: "AsKs", wantFields: []string{"hand.cards"}},
+		{name: "invalid card", hand: "AsKsQsJs1s", wantFields: []string{"hand.cards[4]"}},
+		{name: "same card twice", hand: "AsAsQsJsTs", wantFields: []string{"hand.cards[1]"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := client.Evaluate(context.Background(), &pokerpb.EvaluateRequest{Hand: hand(tt.hand)})
+			if tt.wantFields != nil {
+				if got := violations(t, err); !reflect.DeepEqual(got, tt.wantFields) {
+					t.Errorf("violations = %v, want %v", got, tt.wantFields)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Evaluate() error = %v", err)
+			}
+			if resp.GetResult().GetRank() != tt.wantRank {
+				t.Errorf("Evaluate() = %v, want %v", resp.GetResult().GetRank(), tt.wantRank)
+			}
+		})
+	}
+}
+
+func TestServer_Rank(t *testing.T) {
+	client := newClient(t)
+
+	resp, err := client.Rank(context.Background(), &pokerpb.RankRequest{
+		Id:    7,
+		Hands: []*pokerpb.Hand{hand("3s4h5d6c7s"), hand("AsKsQsJsTs"), hand("2c2d2h3c3d")},
+	})
+	if err != nil {
+		t.Fatalf("Rank() error = %v", err)
+	}
+
+	var order []int32
+	for _, r := range resp.GetResults() {
+		order = append(order, r.GetHandId())
+	}
+	if resp.GetId() != 7 || !reflect.DeepEqual(order, []int32{2, 3, 1}) || !reflect.DeepEqual(resp.GetWinners(), []int32{2}) {
+		t.Errorf("Rank() = id %d, order %v, winners %v, want id 7, order [2 3 1], winners [2]", resp.GetId(), order, resp.GetWinners())
+	}
+
+	_, err = client.Rank(context.Background(), &pokerpb.RankRequest{Hands: []*pokerpb.Hand{hand("3s4h5d6c7s"), hand("3s4h5d6c8s")}})
+	if got := violations(t, err); !reflect.DeepEqual(got, []string{"hands[1].cards[0]", "hands[1].cards[1]", "hands[1].cards[2]", "hands[1].cards[3]"}) {
+		t.Errorf("Rank() violations = %v", got)
+	}
+}
+
+func TestServer_RankStream(t *testing.T) {
+	tests := []struct {
+		name     string
+		requests []*pokerpb.RankRequest
+		wantIDs  []int64
+		wantCode codes.Code
+	}{
+		{
+			name: "in order",
+			requests: []*pokerpb.RankRequest{
+				{Id: 10, Hands: []*pokerpb.Hand{hand("3s4h5d6c7s"), hand("AsKsQsJsTs")}},
+				{Id: 20, Hands: []*pokerpb.Hand{hand("2c2d2h3c3d")}},
+				{Id: 30, Hands: []*pokerpb.Hand{hand("AsKdQh9c8s"), hand("AcKhQd9s8c")}},
+			},
+			wantIDs:  []int64{10, 20, 30},
+			wantCode: codes.OK,
+		},
+		{
+			name: "invalid showdown ends the stream",
+			requests: []*pokerpb.RankRequest{
+				{Id: 1, Hands: []*pokerpb.Hand{hand("3s4h5d6c7s")}},
+				{Id: 2, Hands: []*pokerpb.Hand{hand("3s4h5d")}},
+				{Id: 3, Hands: []*pokerpb.Hand{hand("3s4h5d6c7s")}},
+			},
+			wantIDs:  []int64{1},
+			wantCode: codes.InvalidArgument,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stream, err := newClient(t).RankStream(context.Background())
+			if err != nil {
+				t.Fatalf("RankStream() error = %v", err)
+			}
+
+			go func() {
+				for _, req := range tt.requests {
+					if err := stream.Send(req); err != nil {
+						return
+					}
+				}
+				stream.CloseSend()
+			}()
+
+			var ids []int64
+			for {
+				resp, err := stream.Recv()
+				if err == io.EOF {
+					err = nil
+				}
+				if err != nil || resp == nil {
+					if status.Code(err) != tt.wantCode {
+						t.Errorf("RankStream() error = %v, want %v", err, tt.wantCode)
+					}
+					break
+				}
+				ids = append(ids, resp.GetId())
+			}
+
+			if !reflect.DeepEqual(ids, tt.wantIDs) {
+				t.Errorf("RankStream() ids = %v, want %v", ids, tt.wantIDs)
+			}
+		})
+	}
+}
+
+func TestServer_Equity(t *testing.T) {
+	client := newClient(t)
+
+	// every river is enumerated, and of the 44 cards left the 9 hearts, 3 aces and 3 kings win for AhKh
+	resp, err := client.Equity(context.Background(), &pokerpb.EquityRequest{
+		Holes: []*pokerpb.Hand{hand("AhKh"), hand("QsQd")},
+		Board: hand("2h7h9c3s").GetCards(),
+	})
+	if err != nil {
+		t.Fatalf("Equity() error = %v", err)
+	}
+	if got := resp.GetEquities()[0].GetEquity(); math.Abs(got-15.0/44) > 1e-9 {
+		t.Errorf("Equity() = %v, want %v", got, 15.0/44)
+	}
+
+	// in short-deck the ace plays low with the four lowest ranks, so AhKh makes a straight flush on the wheel of the deck
+	resp, err = client.Equity(context.Background(), &pokerpb.EquityRequest{
+		Holes:   []*pokerpb.Hand{hand("AhKh"), hand("QsQd")},
+		Board:   hand("6h7h8h9hJc").GetCards(),
+		Variant: "short-deck",
+	})
+	if err != nil {
+		t.Fatalf("Equity() short-deck error = %v", err)
+	}
+	if got := resp.GetEquities()[0].GetEquity(); got != 1 {
+		t.Errorf("Equity() short-deck = %v, want %v", got, 1)
+	}
+
+	var many []*pokerpb.Hand
+	for _, h := range []string{"Ah2h", "Ad2d", "Ac2c", "As2s", "Kh3h", "Kd3d", "Kc3c", "Ks3s", "Qh4h", "Qd4d", "Qc4c"} {
+		many = append(many, hand(h))
+	}
+
+	tests := []struct {
+		name string
+		req  *pokerpb.EquityRequest
+		want []string
+	}{
+		{name: "one hand", req: &pokerpb.EquityRequest{Holes: []*pokerpb.Hand{hand("AhKh")}, Iterations: -1}, want: []string{"holes", "iterations"}},
+		{name: "too many hands", req: &pokerpb.EquityRequest{Holes: many}, want: []string{"holes"}},
+		{name: "unknown variant", req: &pokerpb.EquityRequest{Holes: []*pokerpb.Hand{hand("AhKh"), hand("QsQd")}, Variant: "razz"}, want: []string{"variant"}},
+		{
+			name: "card not in the short deck",
+			req:  &pokerpb.EquityRequest{Holes: []*pokerpb.Hand{hand("AhKh"), hand("QsQd")}, Board: hand("2h7h9c").GetCards(), Variant: "short-deck"},
+			want: []string{"board"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := client.Equity(context.Background(), tt.req)
+			if got := violations(t, err); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Equity() violations = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,385 @@
+package server
+
+import (
+	"bytes"
+	"fmt"
+	"math/rand"
+	"net/http"
+
+	"github.com/YoungsoonLee/poker/cardimg"
+	"github.com/YoungsoonLee/poker/internal/validate"
+	"github.com/YoungsoonLee/poker/poker"
+	"github.com/YoungsoonLee/poker/types"
+)
+
+// HandResponse is an evaluated five card hand.
+type HandResponse struct {
+	Cards     []string `json:"cards"`
+	Rank      string   `json:"rank"`
+	RankOrder int      `json:"rank_order"`
+	Score     int      `json:"score"`
+}
+
+// EvaluateRequest is the body of /v1/evaluate, five to seven cards like "AsKsQsJsTs9h8h".
+type EvaluateRequest struct {
+	Cards string `json:"cards"`
+}
+
+// RankRequest is the body of /v1/rank, five card hands like ["3s4h5d6c7s", "9H3CTSQSAD"].
+type RankRequest struct {
+	Hands []string `json:"hands"`
+}
+
+// RankedHand is a hand of a RankResponse, numbered from 1 in the order of the request.
+type RankedHand struct {
+	HandID int `json:"hand_id"`
+	HandResponse
+	Winner bool `json:"winner"`
+}
+
+// RankResponse is the answer of /v1/rank.
+// Hands are ordered from the strongest, and Winners lists the ids of the hands that tie for the best score.
+type RankResponse struct {
+	Hands   []RankedHand `json:"hands"`
+	Winners []int        `json:"winners"`
+}
+
+// CompareRequest is the body of /v1/compare, two hands of five to seven cards that are compared by their best five cards.
+type CompareRequest struct {
+	A string `json:"a"`
+	B string `json:"b"`
+}
+
+// CompareResponse is the answer of /v1/compare. Winner is "a", "b" or "tie".
+type CompareResponse struct {
+	Winner string       `json:"winner"`
+	A      HandResponse `json:"a"`
+	B      HandResponse `json:"b"`
+}
+
+// EquityRequest is the body of /v1/equity.
+// Holes are the two hole cards of 2 to 10 players, and Board zero, three, four or five community cards.
+// Iterations defaults to 10000, and a Seed makes the random run-outs reproducible.
+// Variant is "standard", the default, or any name of poker.Variants, e.g. "short-deck" or "deuces-wild".
+// The joker can not be sent as a card, but it is dealt in the run-outs of the variants that have one.
+type EquityRequest struct {
+	Holes      []string `json:"holes"`
+	Board      string   `json:"board"`
+	Iterations int      `json:"iterations"`
+	Seed       int64    `json:"seed"`
+	Variant    string   `json:"variant"`
+}
+
+// PlayerEquity is the equity of the hole cards of a player.
+type PlayerEquity struct {
+	Hole   []string `json:"hole"`
+	Win    float64  `json:"win"`
+	Tie    float64  `json:"tie"`
+	Equity float64  `json:"equity"`
+}
+
+// EquityResponse is the answer of /v1/equity, in the order of the holes of the request.
+type EquityResponse struct {
+	Equities []PlayerEquity `json:"equities"`
+}
+
+// DealRequest is the body of /v1/deal.
+// Hands defaults to 1 and Cards, the number of cards of every hand, to 5. Board is the number of community cards.
+type DealRequest struct {
+	Hands int   `json:"hands"`
+	Cards int   `json:"cards"`
+	Board int   `json:"board"`
+	Seed  int64 `json:"seed"`
+}
+
+// DealResponse is the answer of /v1/deal. Remaining is the number of cards left in the deck.
+type DealResponse struct {
+	Hands     [][]string `json:"hands"`
+	Board     []string   `json:"board"`
+	Remaining int        `json:"remaining"`
+}
+
+// RenderRequest is the body of /v1/render.
+// Hands are hands of one to seven cards drawn under the Board, and two or more hands are ranked with the winners highlighted.
+// Format is "svg", the default, or "png".
+type RenderRequest struct {
+	Hands  []string `json:"hands"`
+	Board  string   `json:"board"`
+	Title  string   `json:"title"`
+	Format string   `json:"format"`
+}
+
+func evaluate(req EvaluateRequest) (any, error) {
+	v := newValidator()
+	cards := v.cards("cards", req.Cards, validate.HandCards, validate.MaxCards)
+	if err := v.err(); err != nil {
+		return nil, err
+	}
+
+	hand, err := poker.BestHand(1, cards)
+	if err != nil {
+		return nil, err
+	}
+
+	return handResponse(hand), nil
+}
+
+func rank(req RankRequest) (any, error) {
+	v := newValidator()
+	if len(req.Hands) == 0 {
+		v.add("hands", "should have at least 1 hand")
+	}
+
+	hands := make(poker.Hands, len(req.Hands))
+	for i, s := range req.Hands {
+		hands[i] = poker.Hand{HandID: i + 1, Cards: v.cards(fmt.Sprintf("hands[%d]", i), s, validate.HandCards, validate.HandCards)}
+	}
+	if err := v.err(); err != nil {
+		return nil, err
+	}
+
+	results := poker.EvaluateHands(hands)
+	resp := RankResponse{Hands: []RankedHand{}, Winners: []int{}}
+	for _, r := range results {
+		h := RankedHand{
+			HandID:       r.HandID,
+			HandResponse: HandResponse{Cards: cardStrings(r.Card), Rank: r.Rank, RankOrder: r.RankOrder, Score: r.Score},
+			Winner:       r.Score == results[0].Score,
+		}
+		if h.Winner {
+			resp.Winners = append(resp.Winners, r.HandID)
+		}
+		resp.Hands = append(resp.Hands, h)
+	}
+
+	return resp, nil
+}
+
+func compare(req CompareRequest) (any, error) {
+	v := newValidator()
+	a := v.cards("a", req.A, validate.HandCards, validate.MaxCards)
+	b := v.cards("b", req.B, validate.HandCards, validate.MaxCards)
+	if err := v.err(); err != nil {
+		return nil, err
+	}
+
+	handA, err := poker.BestHand(1, a)
+	if err != nil {
+		return nil, err
+	}
+	handB, err := poker.BestHand(2, b)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := CompareResponse{Winner: "tie", A: handResponse(handA), B: handResponse(handB)}
+	switch poker.Compare(handA, handB) {
+	case 1:
+		resp.Winner = "a"
+	case -1:
+		resp.Winner = "b"
+	}
+
+	return resp, nil
+}
+
+func equity(req EquityRequest) (any, error) {
+	v := newValidator()
+	holes := make([][]types.Card, len(req.Holes))
+	for i, s := range req.Holes {
+		holes[i] = v.cards(holeField(i), s, validate.HoleCards, validate.HoleCards)
+	}
+	board := v.cards("board", req.Board, 0, validate.BoardCards)
+
+	iterations, variant := validate.Equity(v.add, holes, holeField, board, req.Iterations, req.Variant)
+	if err := v.err(); err != nil {
+		return nil, err
+	}
+
+	var rng *rand.Rand
+	if req.Seed != 0 {
+		rng = rand.New(rand.NewSource(req.Seed))
+	}
+
+	equities, err := variant.CalculateEquity(holes, board, iterations, rng)
+	if err != nil {
+		v.add("holes", "%v", err)
+		return nil, v.err()
+	}
+
+	resp := EquityResponse{Equities: make([]PlayerEquity, len(equities))}
+	for i, e := range equities {
+		resp.Equities[i] = PlayerEquity{Hole: cardStrings(holes[i]), Win: e.Win, Tie: e.Tie, Equity: e.Equity}
+	}
+
+	return resp, nil
+}
+
+// holeField names the field of the hole cards of the player at index i.
+func holeField(i int) string {
+	return fmt.Sprintf("holes[%d]", i)
+}
+
+func deal(req DealRequest) (any, error) {
+	if req.Hands == 0 {
+		req.Hands = 1
+	}
+	if req.Cards == 0 {
+		req.Cards = validate.HandCards
+	}
+
+	v := newValidator()
+	if req.Hands < 1 {
+		v.add("hands", "should be at least 1, got %d", req.Hands)
+	}
+	if req.Cards < 1 {
+		v.add("cards", "should be at least 1, got %d", req.Cards)
+	}
+	if req.Board < 0 || req.Board > validate.BoardCards {
+		v.add("board", "should be between 0 and %d, got %d", validate.BoardCards, req.Board)
+	}
+	if err := v.err(); err != nil {
+		return nil, err
+	}
+
+	var rng *rand.Rand
+	if req.Seed != 0 {
+		rng = rand.New(rand.NewSource(req.Seed))
+	}
+
+	deck := poker.NewDeck(rng)
+	// hands and cards are checked against the deck before multiplying them, so huge counts cannot overflow
+	if req.Hands > deck.Len()/req.Cards || req.Hands*req.Cards+req.Board > deck.Len() {
+		v.add("hands", "%d hands of %d cards and a board of %d cards need more than the %d cards of the deck", req.Hands, req.Cards, req.Board, deck.Len())
+		return nil, v.err()
+	}
+	deck.Shuffle()
+
+	resp := DealResponse{Hands: make([][]string, req.Hands), Board: []string{}}
+	for i := range resp.Hands {
+		cards, err := deck.Deal(req.Cards)
+		if err != nil {
+			return nil, err
+		}
+		resp.Hands[i] = cardStrings(cards)
+	}
+
+	board, err := deck.Deal(req.Board)
+	if err != nil {
+		return nil, err
+	}
+	resp.Board = cardStrings(board)
+	resp.Remaining = deck.Len()
+
+	return resp, nil
+}
+
+func render(req RenderRequest) (any, error) {
+	if req.Format == "" {
+		req.Format = "svg"
+	}
+
+	v := newValidator()
+	if req.Format != "svg" && req.Format != "png" {
+		v.add("format", "should be svg or png, got %s", req.Format)
+	}
+
+	hands := make([][]types.Card, len(req.Hands))
+	for i, h := range req.Hands {
+		hands[i] = v.cards(fmt.Sprintf("hands[%d]", i), h, 1, validate.MaxCards)
+	}
+	board := v.cards("board", req.Board, 0, validate.BoardCards)
+	if err := v.err(); err != nil {
+		return nil, err
+	}
+
+	img, err := cardimg.Showdown(req.Title, hands, board)
+	if err != nil {
+		return nil, &apiError{status: http.StatusBadRequest, message: err.Error()}
+	}
+
+	var b bytes.Buffer
+	contentType := "image/svg+xml"
+	if req.Format == "png" {
+		contentType = "image/png"
+		err = img.WritePNG(&b)
+	} else {
+		err = img.WriteSVG(&b)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return rawResponse{contentType: contentType, body: b.Bytes()}, nil
+}
+
+// handResponse converts an evaluated hand to its response.
+func handResponse(h poker.Hand) HandResponse {
+	rank, rankOrder := h.Evaluate()
+	return HandResponse{Cards: cardStrings(h.Cards), Rank: rank, RankOrder: rankOrder, Score: h.Score()}
+}
+
+// cardStrings returns the cards like "AS".
+func cardStrings(cards []types.Card) []string {
+	s := make([]string, len(cards))
+	for i, c := range cards {
+		s[i] = c.String()
+	}
+
+	return s
+}
+
+// validator collects the problems of the fields of a request.
+// A card may only be used once across all the fields of a request.
+type validator struct {
+	fields []FieldError
+	seen   map[types.Card]string
+}
+
+func newValidator() *validator {
+	return &validator{seen: make(map[types.Card]string)}
+}
+
+// add adds the problem of a field.
+func (v *validator) add(field, format string, args ...any) {
+	v.fields = append(v.fields, FieldError{Field: field, Message: fmt.Sprintf(format, args...)})
+}
+
+// cards parses the cards of a field, which should have between min and max cards.
+func (v *validator) cards(field, s string, min, max int) []types.Card {
+	cards, err := types.ParseCards(s)
+	if err != nil {
+		v.add(field, "%v", err)
+		return nil
+	}
+
+	switch {
+	case min == max && len(cards) != min:
+		v.add(field, "should have %d cards, got %d", min, len(cards))
+	case len(cards) < min || len(cards) > max:
+		v.add(field, "should have %d to %d cards, got %d", min, max, len(cards))
+	}
+
+	for _, c := range cards {
+		if other, ok := v.seen[c]; ok {
+			if other == field {
+				v.add(field, "card %s is used more than once", c)
+			} else {
+				v.add(field, "card %s is also used in %s", c, other)
+			}
+			continue
+		}
+		v.seen[c] = field
+	}
+
+	return cards
+}
+
+// err returns a 400 with the problems of every field, or nil if there are none.
+func (v *validator) err() error {
+	if len(v.fields) == 0 {
+		return nil
+	}
+
+	return &apiError{status: http.StatusBadRequest, message: "invalid request", fields: v.fields}
+}
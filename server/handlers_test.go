@@ -0,0 +1,336 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"image/png"
+	"math"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// fieldNames returns the fields of an error response.
+func fieldNames(t *testing.T, body []byte) []string {
+	t.Helper()
+
+	var resp ErrorResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+
+	var fields []string
+	for _, f := range resp.Fields {
+		fields = append(fields, f.Field)
+	}
+
+	return fields
+}
+
+func TestEvaluate(t *testing.T) {
+	tests := []struct {
+		name       string
+		body       string
+		want       HandResponse
+		wantFields []string
+	}{
+		{
+			name: "five cards",
+			body: `{"cards": "3s4h5d6c7s"}`,
+			want: HandResponse{Cards: []string{"3S", "4H", "5D", "6C", "7S"}, Rank: "Straight", RankOrder: 6, Score: 6815743},
+		},
+		{
+			name: "best five of seven",
+			body: `{"cards": "As Ks Qs Js Ts 2h 3d"}`,
+			want: HandResponse{Cards: []string{"AS", "KS", "QS", "JS", "TS"}, Rank: "Royal Flush", RankOrder: 1, Score: 1114111},
+		},
+		{name: "too few cards", body: `{"cards": "AsKs"}`, wantFields: []string{"cards"}},
+		{name: "invalid card", body: `{"cards": "AsKsQsJs1s"}`, wantFields: []string{"cards"}},
+		{name: "same card twice", body: `{"cards": "AsAsQsJsTs"}`, wantFields: []string{"cards"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := do(t, New(Config{}), http.MethodPost, "/v1/evaluate", tt.body)
+			if tt.wantFields != nil {
+				if w.Code != http.StatusBadRequest {
+					t.Fatalf("status = %d, want %d", w.Code, http.StatusBadRequest)
+				}
+				if got := fieldNames(t, w.Body.Bytes()); !reflect.DeepEqual(got, tt.wantFields) {
+					t.Errorf("fields = %v, want %v", got, tt.wantFields)
+				}
+				return
+			}
+
+			if w.Code != http.StatusOK {
+				t.Fatalf("status = %d, want %d, body %s", w.Code, http.StatusOK, w.Body)
+			}
+			var got HandResponse
+			if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+				t.Fatalf("json.Unmarshal() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("evaluate = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRank(t *testing.T) {
+	tests := []struct {
+		name        string
+		body        string
+		wantOrder   []int
+		wantWinners []int
+		wantFields  []string
+	}{
+		{
+			name:        "strongest first",
+			body:        `{"hands": ["3s4h5d6c7s", "AsKsQsJsTs", "2c2d2h3c3d"]}`,
+			wantOrder:   []int{2, 3, 1},
+			wantWinners: []int{2},
+		},
+		{
+			name:        "tie",
+			body:        `{"hands": ["AsKdQh9c8s", "AcKhQd9s8c"]}`,
+			wantOrder:   []int{1, 2},
+			wantWinners: []int{1, 2},
+		},
+		{name: "no hands", body: `{"hands": []}`, wantFields: []string{"hands"}},
+		{
+			name:       "every invalid hand is reported",
+			body:       `{"hands": ["3s4h5d6c", "AsKsQsJsTs", "AsKdQh9c8s"]}`,
+			wantFields: []string{"hands[0]", "hands[2]"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := do(t, New(Config{}), http.MethodPost, "/v1/rank", tt.body)
+			if tt.wantFields != nil {
+				if w.Code != http.StatusBadRequest {
+					t.Fatalf("status = %d, want %d", w.Code, http.StatusBadRequest)
+				}
+				if got := fieldNames(t, w.Body.Bytes()); !reflect.DeepEqual(got, tt.wantFields) {
+					t.Errorf("fields = %v, want %v", got, tt.wantFields)
+				}
+				return
+			}
+
+			if w.Code != http.StatusOK {
+				t.Fatalf("status = %d, want %d, body %s", w.Code, http.StatusOK, w.Body)
+			}
+			var got RankResponse
+			if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+				t.Fatalf("json.Unmarshal() error = %v", err)
+			}
+
+			var order []int
+			for _, h := range got.Hands {
+				order = append(order, h.HandID)
+			}
+			if !reflect.DeepEqual(order, tt.wantOrder) {
+				t.Errorf("order = %v, want %v", order, tt.wantOrder)
+			}
+			if !reflect.DeepEqual(got.Winners, tt.wantWinners) {
+				t.Errorf("winners = %v, want %v", got.Winners, tt.wantWinners)
+			}
+		})
+	}
+}
+
+func TestCompare(t *testing.T) {
+	tests := []struct {
+		name       string
+		body       string
+		want       string
+		wantFields []string
+	}{
+		{name: "a wins", body: `{"a": "AsAhAdKcKs", "b": "2c3c4c5c7d"}`, want: "a"},
+		{name: "b wins", body: `{"a": "2c3c4c5c7d", "b": "AsAhAdKcKs"}`, want: "b"},
+		{name: "tie", body: `{"a": "AsKdQh9c8s", "b": "AcKhQd9s8c"}`, want: "tie"},
+		{name: "seven cards", body: `{"a": "AsKs2c3d4h9h8h", "b": "QsQh2d3c5h9d8d"}`, want: "b"},
+		{name: "shared card", body: `{"a": "AsKdQh9c8s", "b": "AsKhQd9s8c"}`, wantFields: []string{"b"}},
+		{name: "missing hand", body: `{"a": "AsKdQh9c8s"}`, wantFields: []string{"b"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := do(t, New(Config{}), http.MethodPost, "/v1/compare", tt.body)
+			if tt.wantFields != nil {
+				if w.Code != http.StatusBadRequest {
+					t.Fatalf("status = %d, want %d", w.Code, http.StatusBadRequest)
+				}
+				if got := fieldNames(t, w.Body.Bytes()); !reflect.DeepEqual(got, tt.wantFields) {
+					t.Errorf("fields = %v, want %v", got, tt.wantFields)
+				}
+				return
+			}
+
+			var got CompareResponse
+			if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+				t.Fatalf("json.Unmarshal() error = %v", err)
+			}
+			if got.Winner != tt.want {
+				t.Errorf("winner = %s, want %s", got.Winner, tt.want)
+			}
+		})
+	}
+}
+
+func TestEquity(t *testing.T) {
+	tests := []struct {
+		name       string
+		body       string
+		want       []float64
+		wantFields []string
+	}{
+		{
+			// every river is enumerated, and of the 44 cards left the 9 hearts, 3 aces and 3 kings win for AhKh
+			name: "exact on the turn",
+			body: `{"holes": ["AhKh", "QsQd"], "board": "2h7h9c3s"}`,
+			want: []float64{15.0 / 44, 29.0 / 44},
+		},
+		{name: "one hand", body: `{"holes": ["AhKh"]}`, wantFields: []string{"holes"}},
+		{name: "too many hands", body: `{"holes": ["Ah2h", "Ad2d", "Ac2c", "As2s", "Kh3h", "Kd3d", "Kc3c", "Ks3s", "Qh4h", "Qd4d", "Qc4c"]}`, wantFields: []string{"holes"}},
+		{name: "board of two cards", body: `{"holes": ["AhKh", "QsQd"], "board": "2h7h"}`, wantFields: []string{"board"}},
+		{name: "too many iterations", body: `{"holes": ["AhKh", "QsQd"], "iterations": 1000000000}`, wantFields: []string{"iterations"}},
+		{name: "card on the board and in a hand", body: `{"holes": ["AhKh", "QsQd"], "board": "AhQc2d"}`, wantFields: []string{"board"}},
+		{
+			// the flush beats the full house in short-deck
+			name: "short-deck",
+			body: `{"holes": ["AhTh", "8s8d"], "board": "6h7h8hKsKd", "variant": "short-deck"}`,
+			want: []float64{1, 0},
+		},
+		{name: "card not in the short deck", body: `{"holes": ["AhKh", "QsQd"], "board": "2h7h9c", "variant": "short-deck"}`, wantFields: []string{"board"}},
+		{name: "unknown variant", body: `{"holes": ["AhKh", "QsQd"], "variant": "razz"}`, wantFields: []string{"variant"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := do(t, New(Config{}), http.MethodPost, "/v1/equity", tt.body)
+			if tt.wantFields != nil {
+				if w.Code != http.StatusBadRequest {
+					t.Fatalf("status = %d, want %d", w.Code, http.StatusBadRequest)
+				}
+				if got := fieldNames(t, w.Body.Bytes()); !reflect.DeepEqual(got, tt.wantFields) {
+					t.Errorf("fields = %v, want %v", got, tt.wantFields)
+				}
+				return
+			}
+
+			var got EquityResponse
+			if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+				t.Fatalf("json.Unmarshal() error = %v", err)
+			}
+			if len(got.Equities) != len(tt.want) {
+				t.Fatalf("equities = %+v, want %v", got.Equities, tt.want)
+			}
+			for i, e := range got.Equities {
+				if math.Abs(e.Equity-tt.want[i]) > 1e-9 {
+					t.Errorf("equity[%d] = %v, want %v", i, e.Equity, tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestDeal(t *testing.T) {
+	tests := []struct {
+		name          string
+		body          string
+		wantHands     int
+		wantCards     int
+		wantBoard     int
+		wantRemaining int
+		wantFields    []string
+	}{
+		{name: "defaults", body: `{}`, wantHands: 1, wantCards: 5, wantRemaining: 47},
+		{name: "hold'em", body: `{"hands": 6, "cards": 2, "board": 5, "seed": 1}`, wantHands: 6, wantCards: 2, wantBoard: 5, wantRemaining: 35},
+		{name: "not enough cards", body: `{"hands": 11, "cards": 5}`, wantFields: []string{"hands"}},
+		{name: "hands times cards overflows", body: `{"hands": 4611686018427387904, "cards": 4}`, wantFields: []string{"hands"}},
+		{name: "too many cards per hand", body: `{"cards": 53}`, wantFields: []string{"hands"}},
+		{name: "negative", body: `{"hands": -1, "board": 6}`, wantFields: []string{"hands", "board"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := do(t, New(Config{}), http.MethodPost, "/v1/deal", tt.body)
+			if tt.wantFields != nil {
+				if w.Code != http.StatusBadRequest {
+					t.Fatalf("status = %d, want %d", w.Code, http.StatusBadRequest)
+				}
+				if got := fieldNames(t, w.Body.Bytes()); !reflect.DeepEqual(got, tt.wantFields) {
+					t.Errorf("fields = %v, want %v", got, tt.wantFields)
+				}
+				return
+			}
+
+			var got DealResponse
+			if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+				t.Fatalf("json.Unmarshal() error = %v", err)
+			}
+			if len(got.Hands) != tt.wantHands || len(got.Board) != tt.wantBoard || got.Remaining != tt.wantRemaining {
+				t.Fatalf("deal = %+v, want %d hands, %d board cards and %d remaining", got, tt.wantHands, tt.wantBoard, tt.wantRemaining)
+			}
+
+			seen := make(map[string]bool)
+			for _, cards := range append(got.Hands, got.Board) {
+				for _, c := range cards {
+					if seen[c] {
+						t.Errorf("card %s is dealt twice", c)
+					}
+					seen[c] = true
+				}
+			}
+			for _, h := range got.Hands {
+				if len(h) != tt.wantCards {
+					t.Errorf("hand = %v, want %d cards", h, tt.wantCards)
+				}
+			}
+		})
+	}
+}
+
+func TestRender(t *testing.T) {
+	tests := []struct {
+		name            string
+		body            string
+		wantContentType string
+		wantStatus      int
+		wantFields      []string
+	}{
+		{name: "svg by default", body: `{"hands": ["AhKh", "QsQd"], "board": "2h7h9cTs3h", "title": "River"}`, wantContentType: "image/svg+xml", wantStatus: http.StatusOK},
+		{name: "png", body: `{"hands": ["3s4h5d6c7s"], "format": "png"}`, wantContentType: "image/png", wantStatus: http.StatusOK},
+		{name: "board only", body: `{"board": "2h7h9c"}`, wantContentType: "image/svg+xml", wantStatus: http.StatusOK},
+		{name: "invalid fields", body: `{"hands": ["AhKh", "Ah1d"], "board": "2h7h9cTs3h4d", "format": "gif"}`, wantStatus: http.StatusBadRequest, wantFields: []string{"format", "hands[1]", "board"}},
+		{name: "too few cards to rank", body: `{"hands": ["AhKh", "QsQd"], "board": "2h"}`, wantStatus: http.StatusBadRequest},
+		{name: "nothing", body: `{}`, wantStatus: http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := do(t, New(Config{}), http.MethodPost, "/v1/render", tt.body)
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d, body %s", w.Code, tt.wantStatus, w.Body)
+			}
+			if tt.wantFields != nil {
+				if got := fieldNames(t, w.Body.Bytes()); !reflect.DeepEqual(got, tt.wantFields) {
+					t.Errorf("fields = %v, want %v", got, tt.wantFields)
+				}
+			}
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+
+			if got := w.Header().Get("Content-Type"); got != tt.wantContentType {
+				t.Fatalf("Content-Type = %s, want %s", got, tt.wantContentType)
+			}
+			switch tt.wantContentType {
+			case "image/png":
+				if _, err := png.Decode(bytes.NewReader(w.Body.Bytes())); err != nil {
+					t.Errorf("png.Decode() error = %v", err)
+				}
+			default:
+				if !strings.HasPrefix(w.Body.String(), "<svg") {
+					t.Errorf("body = %.40s, want an SVG document", w.Body)
+				}
+			}
+		})
+	}
+}
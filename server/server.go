@@ -0,0 +1,189 @@
+// Package server exposes the poker package as a JSON API over HTTP.
+//
+// Every endpoint takes a POST with a JSON body and answers with JSON, except /v1/render which answers with an image:
+//
+//   - /v1/evaluate evaluates the best five card hand of five to seven cards.
+//   - /v1/rank ranks several five card hands like EvaluateHands.
+//   - /v1/compare compares two hands.
+//   - /v1/equity calculates the Texas Hold'em equity of hole cards with a board.
+//   - /v1/deal deals hands and a board from a shuffled deck.
+//   - /v1/render draws hands and a board as an SVG or PNG image, with the winners highlighted.
+//
+// Cards are strings like "AsKd" or "As Kd". Invalid requests are answered with 400 and the problems of every field.
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// maxBodySize is the largest request body accepted.
+const maxBodySize = 1 << 20
+
+// Config configures the API handler.
+// AllowOrigins are the origins browsers may call the API from, e.g. "http://localhost:3000", or "*" for any origin.
+type Config struct {
+	AllowOrigins []string
+}
+
+// FieldError is the problem with one field of a request, e.g. {"field": "hands[1]", "message": "invalid card: 1x"}.
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// ErrorResponse is the body of every error response.
+type ErrorResponse struct {
+	Error  string       `json:"error"`
+	Fields []FieldError `json:"fields,omitempty"`
+}
+
+// apiError is an error answered with its status code.
+type apiError struct {
+	status  int
+	message string
+	fields  []FieldError
+}
+
+func (e *apiError) Error() string {
+	return e.message
+}
+
+// rawResponse is a response answered as is instead of as JSON.
+type rawResponse struct {
+	contentType string
+	body        []byte
+}
+
+// New returns the handler of the API.
+func New(cfg Config) http.Handler {
+	mux := http.NewServeMux()
+	mux.Handle("/v1/evaluate", post(evaluate))
+	mux.Handle("/v1/rank", post(rank))
+	mux.Handle("/v1/compare", post(compare))
+	mux.Handle("/v1/equity", post(equity))
+	mux.Handle("/v1/deal", post(deal))
+	mux.Handle("/v1/render", post(render))
+
+	if len(cfg.AllowOrigins) == 0 {
+		return mux
+	}
+
+	return cors(cfg.AllowOrigins, mux)
+}
+
+// post returns a handler that decodes the JSON body of a POST into a request, and answers with the response of handle.
+func post[T any](handle func(T) (any, error)) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.Header().Set("Allow", http.MethodPost)
+			writeError(w, &apiError{status: http.StatusMethodNotAllowed, message: fmt.Sprintf("method %s not allowed", r.Method)})
+			return
+		}
+
+		var req T
+		dec := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxBodySize))
+		dec.DisallowUnknownFields()
+		if err := dec.Decode(&req); err != nil {
+			writeError(w, decodeError(err))
+			return
+		}
+
+		resp, err := handle(req)
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+
+		if raw, ok := resp.(rawResponse); ok {
+			w.Header().Set("Content-Type", raw.contentType)
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write(raw.body)
+			return
+		}
+
+		writeJSON(w, http.StatusOK, resp)
+	})
+}
+
+// decodeError converts an error decoding a request body into a 400, or a 413 if the body is too large.
+func decodeError(err error) error {
+	var typeErr *json.UnmarshalTypeError
+	var sizeErr *http.MaxBytesError
+	switch {
+	case errors.As(err, &typeErr):
+		return &apiError{
+			status:  http.StatusBadRequest,
+			message: "invalid request",
+			fields:  []FieldError{{Field: typeErr.Field, Message: fmt.Sprintf("should be of type %s, got %s", jsonType(typeErr.Type.Kind().String()), typeErr.Value)}},
+		}
+	case errors.As(err, &sizeErr):
+		return &apiError{status: http.StatusRequestEntityTooLarge, message: fmt.Sprintf("request body is larger than %d bytes", sizeErr.Limit)}
+	case strings.HasPrefix(err.Error(), "json: unknown field "):
+		field := strings.Trim(strings.TrimPrefix(err.Error(), "json: unknown field "), `"`)
+		return &apiError{status: http.StatusBadRequest, message: "invalid request", fields: []FieldError{{Field: field, Message: "unknown field"}}}
+	default:
+		return &apiError{status: http.StatusBadRequest, message: "invalid JSON: " + err.Error()}
+	}
+}
+
+// jsonType returns the JSON name of a Go kind.
+func jsonType(kind string) string {
+	switch {
+	case kind == "slice" || kind == "array":
+		return "array"
+	case kind == "struct" || kind == "map":
+		return "object"
+	case strings.HasPrefix(kind, "int") || strings.HasPrefix(kind, "uint") || strings.HasPrefix(kind, "float"):
+		return "number"
+	case kind == "bool":
+		return "boolean"
+	default:
+		return kind
+	}
+}
+
+// writeError answers with the status of the error, or 500 if it is not an API error.
+func writeError(w http.ResponseWriter, err error) {
+	var e *apiError
+	if !errors.As(err, &e) {
+		e = &apiError{status: http.StatusInternalServerError, message: err.Error()}
+	}
+
+	writeJSON(w, e.status, ErrorResponse{Error: e.message, Fields: e.fields})
+}
+
+// writeJSON answers with the status and the value as JSON.
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// cors allows browsers to call the handler from the given origins, and answers their preflight requests.
+func cors(origins []string, next http.Handler) http.Handler {
+	allowed := make(map[string]bool, len(origins))
+	for _, o := range origins {
+		allowed[o] = true
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		origin := r.Header.Get("Origin")
+		if origin != "" && (allowed["*"] || allowed[origin]) {
+			w.Header().Set("Access-Control-Allow-Origin", origin)
+			w.Header().Add("Vary", "Origin")
+
+			if r.Method == http.MethodOptions && r.Header.Get("Access-Control-Request-Method") != "" {
+				w.Header().Set("Access-Control-Allow-Methods", http.MethodPost)
+				w.Header().Set("Access-Control-Allow-Headers", "Content-Type")
+				w.WriteHeader(http.StatusNoContent)
+				return
+			}
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,114 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// do sends a request to the handler and returns the recorded response.
+func do(t *testing.T, h http.Handler, method, path, body string) *httptest.ResponseRecorder {
+	t.Helper()
+
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+
+	return w
+}
+
+func TestPost_Errors(t *testing.T) {
+	tests := []struct {
+		name       string
+		method     string
+		body       string
+		wantStatus int
+		wantFields []FieldError
+	}{
+		{
+			name:       "method not allowed",
+			method:     http.MethodGet,
+			wantStatus: http.StatusMethodNotAllowed,
+		},
+		{
+			name:       "invalid json",
+			method:     http.MethodPost,
+			body:       `{"cards": `,
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "unknown field",
+			method:     http.MethodPost,
+			body:       `{"card": "AsKsQsJsTs"}`,
+			wantStatus: http.StatusBadRequest,
+			wantFields: []FieldError{{Field: "card", Message: "unknown field"}},
+		},
+		{
+			name:       "wrong type",
+			method:     http.MethodPost,
+			body:       `{"cards": 5}`,
+			wantStatus: http.StatusBadRequest,
+			wantFields: []FieldError{{Field: "cards", Message: "should be of type string, got number"}},
+		},
+		{
+			name:       "body too large",
+			method:     http.MethodPost,
+			body:       `{"cards": "` + strings.Repeat(" ", maxBodySize) + `"}`,
+			wantStatus: http.StatusRequestEntityTooLarge,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := do(t, New(Config{}), tt.method, "/v1/evaluate", tt.body)
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d, body %s", w.Code, tt.wantStatus, w.Body)
+			}
+
+			var resp ErrorResponse
+			if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+				t.Fatalf("json.Unmarshal() error = %v", err)
+			}
+			if resp.Error == "" {
+				t.Errorf("error is empty")
+			}
+			if !reflect.DeepEqual(resp.Fields, tt.wantFields) {
+				t.Errorf("fields = %+v, want %+v", resp.Fields, tt.wantFields)
+			}
+		})
+	}
+}
+
+func TestNew_CORS(t *testing.T) {
+	tests := []struct {
+		name       string
+		origins    []string
+		origin     string
+		wantAllow  string
+		wantStatus int
+	}{
+		{name: "allowed origin", origins: []string{"http://localhost:3000"}, origin: "http://localhost:3000", wantAllow: "http://localhost:3000", wantStatus: http.StatusNoContent},
+		{name: "any origin", origins: []string{"*"}, origin: "http://example.com", wantAllow: "http://example.com", wantStatus: http.StatusNoContent},
+		{name: "other origin", origins: []string{"http://localhost:3000"}, origin: "http://example.com", wantStatus: http.StatusMethodNotAllowed},
+		{name: "cors disabled", origin: "http://localhost:3000", wantStatus: http.StatusMethodNotAllowed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodOptions, "/v1/rank", nil)
+			req.Header.Set("Origin", tt.origin)
+			req.Header.Set("Access-Control-Request-Method", http.MethodPost)
+			w := httptest.NewRecorder()
+			New(Config{AllowOrigins: tt.origins}).ServeHTTP(w, req)
+
+			if w.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", w.Code, tt.wantStatus)
+			}
+			if got := w.Header().Get("Access-Control-Allow-Origin"); got != tt.wantAllow {
+				t.Errorf("Access-Control-Allow-Origin = %q, want %q", got, tt.wantAllow)
+			}
+		})
+	}
+}
@@ -0,0 +1,482 @@
+// Package sim runs headless games between strategy bots and reports how each bot performed.
+package sim
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"math"
+	"math/rand"
+	"runtime"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/YoungsoonLee/poker/history"
+	"github.com/YoungsoonLee/poker/table"
+	"github.com/YoungsoonLee/poker/tournament"
+)
+
+// Mode represents the kind of game to simulate.
+type Mode string
+
+const (
+	// Cash plays independent hands, resetting every stack before each hand.
+	Cash Mode = "cash"
+	// SitAndGo plays single table tournaments until one player has all the chips.
+	SitAndGo Mode = "sng"
+)
+
+// cashChunkHands is the number of cash game hands played by a worker at a time.
+const cashChunkHands = 1000
+
+// z95 is the z-score of a 95% confidence interval.
+const z95 = 1.96
+
+// Config configures a simulation.
+// Bots is the list of built-in strategy names, one per seat.
+// Hands is the number of hands to play in cash mode, and Tournaments the number of tournaments in sit-and-go mode.
+// In sit-and-go mode the tournament follows Structure, and at most TableSize bots sit at a table, which makes it a multi-table tournament.
+// Without a structure the blinds double every LevelHands hands, unless it is 0, and the winner takes all.
+// A structure with time based levels needs HandDuration, the simulated time every hand takes, since the wall clock
+// would make the levels depend on the speed of the machine and the number of workers.
+// Seed makes a simulation reproducible; if it is 0 the current time is used.
+// If History is set, every hand is recorded to it. Hands of different jobs may interleave, so each hand gets an id
+// like "cash-3-120" (job, hand number) or "sng-2-1-45" (job, table, hand number).
+type Config struct {
+	Bots          []string
+	Mode          Mode
+	Hands         int
+	Tournaments   int
+	Workers       int
+	StartingStack int
+	SmallBlind    int
+	BigBlind      int
+	LevelHands    int
+	Structure     *tournament.Structure
+	TableSize     int
+	HandDuration  time.Duration
+	Seed          int64
+	History       *history.Writer
+}
+
+// Validate checks the configuration and returns an error describing the first problem found.
+func (c Config) Validate() error {
+	if len(c.Bots) < 2 {
+		return fmt.Errorf("need at least 2 bots, got %d", len(c.Bots))
+	}
+
+	for _, bot := range c.Bots {
+		if _, err := table.NewStrategy(bot, nil); err != nil {
+			return err
+		}
+	}
+
+	switch c.Mode {
+	case Cash:
+		if c.Hands < 1 {
+			return fmt.Errorf("invalid number of hands: %d", c.Hands)
+		}
+	case SitAndGo:
+		if c.Tournaments < 1 {
+			return fmt.Errorf("invalid number of tournaments: %d", c.Tournaments)
+		}
+	default:
+		return fmt.Errorf("invalid mode: %s. mode should be %s or %s", c.Mode, Cash, SitAndGo)
+	}
+
+	if c.SmallBlind < 0 || c.BigBlind < 1 || c.SmallBlind > c.BigBlind {
+		return fmt.Errorf("invalid blinds: %d/%d", c.SmallBlind, c.BigBlind)
+	}
+
+	if c.StartingStack < c.BigBlind {
+		return fmt.Errorf("starting stack %d is smaller than the big blind %d", c.StartingStack, c.BigBlind)
+	}
+
+	if c.Mode == SitAndGo {
+		if c.TableSize == 1 || c.TableSize < 0 {
+			return fmt.Errorf("invalid table size: %d", c.TableSize)
+		}
+		structure := c.structure()
+		if structure.LevelBy == tournament.LevelByTime && c.HandDuration <= 0 {
+			return fmt.Errorf("time based levels need a hand duration, got %v", c.HandDuration)
+		}
+		return structure.Validate()
+	}
+
+	return nil
+}
+
+// PlayerStats is the performance of one seat over the simulation.
+// WinRate is the fraction of hands (cash) or tournaments (sit-and-go) the seat won.
+// In cash mode Wins counts the hands the seat won chips in, and BB100 is the win rate in big blinds per 100 hands
+// with CI95 the half width of its 95% confidence interval.
+// In sit-and-go mode Wins counts the tournaments the seat won, and AvgFinish is its average finishing position.
+// Prizes is the total prize money and bounties won, and ROI the return on the buy-ins and bounties paid.
+type PlayerStats struct {
+	Seat      int
+	Name      string
+	Bot       string
+	Hands     int
+	Wins      int
+	WinRate   float64
+	Net       int
+	BB100     float64
+	CI95      float64
+	AvgFinish float64
+	Prizes    int
+	Knockouts int
+	ROI       float64
+
+	sum   float64
+	sumSq float64
+}
+
+// Report is the result of a simulation.
+// Graph has one row per hand with the chips of each seat after the hand:
+// the cumulative winnings in cash mode, and the stacks of the first tournament in sit-and-go mode.
+type Report struct {
+	Mode        Mode
+	Hands       int
+	Tournaments int
+	Players     []PlayerStats
+	Graph       [][]int
+}
+
+// WriteGraphCSV writes the chip graph as CSV with a header row of the seat names.
+func (r *Report) WriteGraphCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+
+	header := []string{"hand"}
+	for _, p := range r.Players {
+		header = append(header, p.Name)
+	}
+	if err := cw.Write(header); err != nil {
+		return err
+	}
+
+	for i, row := range r.Graph {
+		record := []string{strconv.Itoa(i + 1)}
+		for _, chips := range row {
+			record = append(record, strconv.Itoa(chips))
+		}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+// Run runs the simulation described by cfg on a pool of workers.
+// Every job gets its own random number generator derived from the seed, so results do not depend on the number of workers.
+func Run(ctx context.Context, cfg Config) (*Report, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	if cfg.Seed == 0 {
+		cfg.Seed = time.Now().UnixNano()
+	}
+
+	if cfg.Workers < 1 {
+		cfg.Workers = runtime.NumCPU()
+	}
+
+	report := &Report{Mode: cfg.Mode}
+	for i, bot := range cfg.Bots {
+		report.Players = append(report.Players, PlayerStats{Seat: i, Name: seatName(i, bot), Bot: bot})
+	}
+
+	switch cfg.Mode {
+	case Cash:
+		jobs := (cfg.Hands + cashChunkHands - 1) / cashChunkHands
+		results := make([][][]int, jobs)
+		err := runJobs(ctx, cfg.Workers, jobs, func(job int) error {
+			hands := cashChunkHands
+			if job == jobs-1 {
+				hands = cfg.Hands - job*cashChunkHands
+			}
+
+			nets, err := playCash(ctx, cfg, job, hands)
+			results[job] = nets
+			return err
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		report.addCash(cfg, results)
+	case SitAndGo:
+		results := make([]tournamentResult, cfg.Tournaments)
+		err := runJobs(ctx, cfg.Workers, cfg.Tournaments, func(job int) error {
+			result, err := playSitAndGo(ctx, cfg, job)
+			results[job] = result
+			return err
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		report.addSitAndGo(cfg, results)
+	}
+
+	return report, nil
+}
+
+// seatName returns a unique name for a seat, since several seats may use the same bot.
+func seatName(seat int, bot string) string {
+	return fmt.Sprintf("%s-%d", bot, seat+1)
+}
+
+// runJobs runs the jobs 0 to n-1 on the given number of workers and returns the first error.
+func runJobs(ctx context.Context, workers, n int, run func(job int) error) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	jobs := make(chan int)
+	errs := make(chan error, workers)
+
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for job := range jobs {
+				if err := run(job); err != nil {
+					errs <- err
+					cancel()
+					return
+				}
+			}
+		}()
+	}
+
+	go func() {
+		defer close(jobs)
+		for job := 0; job < n; job++ {
+			select {
+			case jobs <- job:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	wg.Wait()
+	close(errs)
+
+	if err := <-errs; err != nil {
+		return err
+	}
+
+	return ctx.Err()
+}
+
+// newTable creates a table seating the configured bots with the starting stack.
+func newTable(cfg Config, rng *rand.Rand) (*table.Table, error) {
+	seats := make([]*table.Seat, len(cfg.Bots))
+	for i, bot := range cfg.Bots {
+		strategy, err := table.NewStrategy(bot, rng)
+		if err != nil {
+			return nil, err
+		}
+		seats[i] = &table.Seat{Name: seatName(i, bot), Stack: cfg.StartingStack, Strategy: strategy}
+	}
+
+	return table.New(seats, cfg.SmallBlind, cfg.BigBlind, rng)
+}
+
+// playCash plays the given number of cash game hands and returns the net chips of every seat per hand.
+func playCash(ctx context.Context, cfg Config, job, hands int) ([][]int, error) {
+	rng := rand.New(rand.NewSource(cfg.Seed + int64(job)))
+
+	t, err := newTable(cfg, rng)
+	if err != nil {
+		return nil, err
+	}
+	t.Button = job % len(t.Seats)
+
+	nets := make([][]int, 0, hands)
+	for i := 0; i < hands; i++ {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		for _, s := range t.Seats {
+			s.Stack = cfg.StartingStack
+		}
+
+		result, err := t.PlayHand()
+		if err != nil {
+			return nil, err
+		}
+
+		if cfg.History != nil {
+			if err := cfg.History.WriteResult(fmt.Sprintf("cash-%d-%d", job, result.HandNo), result); err != nil {
+				return nil, err
+			}
+		}
+
+		net := make([]int, len(t.Seats))
+		for _, p := range result.Players {
+			net[p.Seat] = p.Net()
+		}
+		nets = append(nets, net)
+	}
+
+	return nets, nil
+}
+
+// addCash aggregates the net chips of every cash game hand, in job order.
+func (r *Report) addCash(cfg Config, results [][][]int) {
+	cumulative := make([]int, len(r.Players))
+
+	for _, nets := range results {
+		for _, net := range nets {
+			r.Hands++
+			for seat, chips := range net {
+				p := &r.Players[seat]
+				p.Hands++
+				p.Net += chips
+				if chips > 0 {
+					p.Wins++
+				}
+
+				bb := float64(chips) / float64(cfg.BigBlind)
+				p.sum += bb
+				p.sumSq += bb * bb
+
+				cumulative[seat] += chips
+			}
+			r.Graph = append(r.Graph, append([]int(nil), cumulative...))
+		}
+	}
+
+	for i := range r.Players {
+		p := &r.Players[i]
+		if p.Hands == 0 {
+			continue
+		}
+
+		n := float64(p.Hands)
+		p.WinRate = float64(p.Wins) / n
+
+		mean := p.sum / n
+		p.BB100 = mean * 100
+
+		if p.Hands > 1 {
+			variance := (p.sumSq - n*mean*mean) / (n - 1)
+			p.CI95 = z95 * math.Sqrt(math.Max(variance, 0)/n) * 100
+		}
+	}
+}
+
+// tournamentResult is the outcome of a tournament.
+// Stacks holds the stacks of every seat after each hand.
+type tournamentResult struct {
+	*tournament.Result
+	Stacks [][]int
+}
+
+// structure returns the tournament structure of the simulation.
+// Without a structure the blinds double every LevelHands hands and the winner takes all.
+func (c Config) structure() tournament.Structure {
+	if c.Structure != nil {
+		return *c.Structure
+	}
+
+	return tournament.DefaultStructure(c.StartingStack, c.SmallBlind, c.BigBlind, c.LevelHands)
+}
+
+// playSitAndGo plays one tournament until a single seat has chips left.
+func playSitAndGo(ctx context.Context, cfg Config, job int) (tournamentResult, error) {
+	rng := rand.New(rand.NewSource(cfg.Seed + int64(job)))
+
+	seats := make(map[string]int)
+	players := make([]tournament.Player, len(cfg.Bots))
+	for i, bot := range cfg.Bots {
+		strategy, err := table.NewStrategy(bot, rng)
+		if err != nil {
+			return tournamentResult{}, err
+		}
+		players[i] = tournament.Player{Name: seatName(i, bot), Strategy: strategy}
+		seats[players[i].Name] = i
+	}
+
+	tableSize := cfg.TableSize
+	if tableSize == 0 {
+		tableSize = len(players)
+	}
+
+	var stacks [][]int
+	var historyErr error
+	structure := cfg.structure()
+	row := make([]int, len(players))
+	for i := range row {
+		row[i] = structure.StartingStack
+	}
+
+	result, err := tournament.Run(ctx, tournament.Config{
+		Structure:    structure,
+		Players:      players,
+		TableSize:    tableSize,
+		HandDuration: cfg.HandDuration,
+		Rng:          rng,
+		OnHand: func(tableID int, hand *table.Result) {
+			for _, p := range hand.Players {
+				row[seats[p.Name]] = p.EndStack
+			}
+			stacks = append(stacks, append([]int(nil), row...))
+
+			if cfg.History != nil && historyErr == nil {
+				historyErr = cfg.History.WriteResult(fmt.Sprintf("sng-%d-%d-%d", job, tableID, hand.HandNo), hand)
+			}
+		},
+	})
+	if err != nil {
+		return tournamentResult{}, err
+	}
+	if historyErr != nil {
+		return tournamentResult{}, historyErr
+	}
+
+	return tournamentResult{Result: result, Stacks: stacks}, nil
+}
+
+// addSitAndGo aggregates the places of every tournament.
+func (r *Report) addSitAndGo(cfg Config, results []tournamentResult) {
+	structure := cfg.structure()
+
+	for k, result := range results {
+		r.Tournaments++
+		r.Hands += result.Hands
+
+		for _, place := range result.Places {
+			p := &r.Players[place.Player]
+			p.Hands += result.Hands
+			p.AvgFinish += float64(place.Position)
+			p.Prizes += place.Prize + place.Bounties
+			p.Knockouts += place.Knockouts
+			if place.Position == 1 {
+				p.Wins++
+			}
+		}
+
+		if k == 0 {
+			r.Graph = result.Stacks
+		}
+	}
+
+	for i := range r.Players {
+		p := &r.Players[i]
+		p.AvgFinish /= float64(r.Tournaments)
+		p.WinRate = float64(p.Wins) / float64(r.Tournaments)
+
+		if cost := (structure.BuyIn + structure.Bounty) * r.Tournaments; cost > 0 {
+			p.ROI = float64(p.Prizes-cost) / float64(cost)
+		}
+	}
+}
@@ -0,0 +1,225 @@
+package sim
+
+import (
+	"bytes"
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/YoungsoonLee/poker/history"
+	"github.com/YoungsoonLee/poker/tournament"
+)
+
+func testConfig(mode Mode) Config {
+	return Config{
+		Bots:          []string{"tag", "calling", "random"},
+		Mode:          mode,
+		Hands:         1500,
+		Tournaments:   5,
+		Workers:       2,
+		StartingStack: 500,
+		SmallBlind:    5,
+		BigBlind:      10,
+		LevelHands:    20,
+		Seed:          42,
+	}
+}
+
+// timeStructure returns the default structure of the test config with levels of 5 minutes.
+func timeStructure(c Config) *tournament.Structure {
+	s := tournament.DefaultStructure(c.StartingStack, c.SmallBlind, c.BigBlind, c.LevelHands)
+	s.LevelBy = tournament.LevelByTime
+	for i := range s.Levels {
+		s.Levels[i].Minutes = 5
+	}
+
+	return &s
+}
+
+func TestConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		modify  func(c *Config)
+		wantErr bool
+	}{
+		{
+			name:    "valid config",
+			modify:  func(c *Config) {},
+			wantErr: false,
+		},
+		{
+			name:    "one bot",
+			modify:  func(c *Config) { c.Bots = []string{"tag"} },
+			wantErr: true,
+		},
+		{
+			name:    "unknown bot",
+			modify:  func(c *Config) { c.Bots = []string{"tag", "shark"} },
+			wantErr: true,
+		},
+		{
+			name:    "unknown mode",
+			modify:  func(c *Config) { c.Mode = "mtt" },
+			wantErr: true,
+		},
+		{
+			name:    "no hands",
+			modify:  func(c *Config) { c.Hands = 0 },
+			wantErr: true,
+		},
+		{
+			name:    "stack smaller than big blind",
+			modify:  func(c *Config) { c.StartingStack = 5 },
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := testConfig(Cash)
+			tt.modify(&c)
+			if err := c.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Config.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	c := testConfig(SitAndGo)
+	c.Structure = timeStructure(c)
+	if err := c.Validate(); err == nil {
+		t.Errorf("Config.Validate() of time based levels without a hand duration error = nil, want error")
+	}
+	c.HandDuration = time.Minute
+	if err := c.Validate(); err != nil {
+		t.Errorf("Config.Validate() of time based levels with a hand duration error = %v", err)
+	}
+}
+
+func TestRun_Cash(t *testing.T) {
+	cfg := testConfig(Cash)
+
+	report, err := Run(context.Background(), cfg)
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	if report.Hands != cfg.Hands || len(report.Graph) != cfg.Hands {
+		t.Errorf("Run() hands = %v, graph = %v, want %v", report.Hands, len(report.Graph), cfg.Hands)
+	}
+
+	net := 0
+	for _, p := range report.Players {
+		net += p.Net
+	}
+	if net != 0 {
+		t.Errorf("Run() nets sum to %v, want 0", net)
+	}
+
+	// results must not depend on the number of workers
+	cfg.Workers = 1
+	again, err := Run(context.Background(), cfg)
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if !reflect.DeepEqual(report.Graph, again.Graph) {
+		t.Errorf("Run() with 1 worker has a different chip graph than with 2 workers")
+	}
+}
+
+func TestRun_SitAndGo(t *testing.T) {
+	cfg := testConfig(SitAndGo)
+
+	report, err := Run(context.Background(), cfg)
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	wins := 0
+	for _, p := range report.Players {
+		wins += p.Wins
+		if p.AvgFinish < 1 || p.AvgFinish > float64(len(cfg.Bots)) {
+			t.Errorf("Run() average finish of %s = %v", p.Name, p.AvgFinish)
+		}
+	}
+	if wins != cfg.Tournaments {
+		t.Errorf("Run() wins = %v, want %v", wins, cfg.Tournaments)
+	}
+}
+
+func TestRun_SitAndGo_TimeLevels(t *testing.T) {
+	cfg := testConfig(SitAndGo)
+	cfg.Structure = timeStructure(cfg)
+	cfg.HandDuration = time.Minute
+
+	report, err := Run(context.Background(), cfg)
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+
+	// simulated hand times make time based levels independent of the machine and the number of workers
+	cfg.Workers = 1
+	again, err := Run(context.Background(), cfg)
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	if !reflect.DeepEqual(report.Players, again.Players) {
+		t.Errorf("Run() with 1 worker = %+v, want %+v as with 2 workers", again.Players, report.Players)
+	}
+}
+
+func TestRun_History(t *testing.T) {
+	for _, mode := range []Mode{Cash, SitAndGo} {
+		t.Run(string(mode), func(t *testing.T) {
+			var buf bytes.Buffer
+			cfg := testConfig(mode)
+			cfg.History = history.NewWriter(&buf)
+
+			report, err := Run(context.Background(), cfg)
+			if err != nil {
+				t.Fatalf("Run() error = %v", err)
+			}
+
+			hands, err := history.ReadAll(&buf)
+			if err != nil {
+				t.Fatalf("ReadAll() error = %v", err)
+			}
+			if len(hands) != report.Hands {
+				t.Errorf("Run() recorded %v hands, want %v", len(hands), report.Hands)
+			}
+
+			ids := make(map[string]bool)
+			for _, h := range hands {
+				if ids[h.ID] {
+					t.Fatalf("Run() recorded hand id %s twice", h.ID)
+				}
+				ids[h.ID] = true
+			}
+		})
+	}
+}
+
+func TestRun_Canceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := Run(ctx, testConfig(Cash)); err == nil {
+		t.Errorf("Run() error = nil, want context canceled")
+	}
+}
+
+func TestReport_WriteGraphCSV(t *testing.T) {
+	report := &Report{
+		Players: []PlayerStats{{Name: "tag-1"}, {Name: "calling-2"}},
+		Graph:   [][]int{{10, -10}, {5, -5}},
+	}
+
+	var buf bytes.Buffer
+	if err := report.WriteGraphCSV(&buf); err != nil {
+		t.Fatalf("Report.WriteGraphCSV() error = %v", err)
+	}
+
+	want := "hand,tag-1,calling-2\n1,10,-10\n2,5,-5\n"
+	if got := buf.String(); got != want {
+		t.Errorf("Report.WriteGraphCSV() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,168 @@
+package stats
+
+import (
+	"sort"
+
+	"github.com/YoungsoonLee/poker/poker"
+)
+
+// positionOrder is the order positions are reported in.
+var positionOrder = append([]string{"SB", "BB"}, append(append([]string(nil), middlePositions...), "BTN")...)
+
+// PlayerStats is the aggregated statistics of a player.
+// VPIP, PFR, ThreeBet, WTSD and WSD are fractions between 0 and 1:
+//   - VPIP is how often the player voluntarily put money in preflop, and PFR how often they raised preflop.
+//   - ThreeBet is how often the player re-raised when they faced a single raise preflop.
+//   - WTSD is how often the player went to showdown after seeing the flop, and WSD how often they won at showdown.
+//
+// AF is the aggression factor after the flop, bets and raises divided by calls.
+// BigBlinds is the net won in big blinds, so it adds up across stakes, and BB100 is the win rate per 100 hands.
+type PlayerStats struct {
+	Player    string
+	Hands     int
+	Net       int
+	BigBlinds float64
+	BB100     float64
+	VPIP      float64
+	PFR       float64
+	ThreeBet  float64
+	WTSD      float64
+	WSD       float64
+	AF        float64
+	Positions []PositionStats
+	Showdowns []Bucket
+}
+
+// PositionStats is the winnings of a player from one position.
+type PositionStats struct {
+	Position  string
+	Hands     int
+	Net       int
+	BigBlinds float64
+}
+
+// Bucket is how often a player went to showdown with a category of hand, and how often they won.
+// Buckets are ordered from the strongest category, and categories never shown are left out.
+type Bucket struct {
+	Category  string
+	Showdowns int
+	Won       int
+}
+
+// Aggregate aggregates the records into the statistics of every player, ordered by the number of hands and then by name.
+func Aggregate(records []Record) []PlayerStats {
+	type counters struct {
+		stats                                                     PlayerStats
+		vpip, pfr, threeBetChances, threeBets, sawFlop, wtsd, wsd int
+		aggressive, calls                                         int
+		positions                                                 map[string]*PositionStats
+		buckets                                                   map[string]*Bucket
+	}
+
+	players := make(map[string]*counters)
+	for _, r := range records {
+		c, ok := players[r.Player]
+		if !ok {
+			c = &counters{
+				stats:     PlayerStats{Player: r.Player},
+				positions: make(map[string]*PositionStats),
+				buckets:   make(map[string]*Bucket),
+			}
+			players[r.Player] = c
+		}
+
+		bb := 0.0
+		if r.BigBlind > 0 {
+			bb = float64(r.Net) / float64(r.BigBlind)
+		}
+
+		c.stats.Hands++
+		c.stats.Net += r.Net
+		c.stats.BigBlinds += bb
+
+		c.vpip += count(r.VPIP)
+		c.pfr += count(r.PFR)
+		c.threeBetChances += count(r.ThreeBetChance)
+		c.threeBets += count(r.ThreeBet)
+		c.sawFlop += count(r.SawFlop)
+		c.wtsd += count(r.WentToShowdown)
+		c.wsd += count(r.WonAtShowdown)
+		c.aggressive += r.Bets + r.Raises
+		c.calls += r.Calls
+
+		p, ok := c.positions[r.Position]
+		if !ok {
+			p = &PositionStats{Position: r.Position}
+			c.positions[r.Position] = p
+		}
+		p.Hands++
+		p.Net += r.Net
+		p.BigBlinds += bb
+
+		if r.Showdown != "" {
+			b, ok := c.buckets[r.Showdown]
+			if !ok {
+				b = &Bucket{Category: r.Showdown}
+				c.buckets[r.Showdown] = b
+			}
+			b.Showdowns++
+			b.Won += count(r.WonAtShowdown)
+		}
+	}
+
+	result := make([]PlayerStats, 0, len(players))
+	for _, c := range players {
+		s := c.stats
+		s.BB100 = s.BigBlinds / float64(s.Hands) * 100
+		s.VPIP = ratio(c.vpip, s.Hands)
+		s.PFR = ratio(c.pfr, s.Hands)
+		s.ThreeBet = ratio(c.threeBets, c.threeBetChances)
+		s.WTSD = ratio(c.wtsd, c.sawFlop)
+		s.WSD = ratio(c.wsd, c.wtsd)
+
+		// without calls the aggression factor is the number of bets and raises
+		s.AF = float64(c.aggressive)
+		if c.calls > 0 {
+			s.AF = float64(c.aggressive) / float64(c.calls)
+		}
+
+		for _, position := range positionOrder {
+			if p, ok := c.positions[position]; ok {
+				s.Positions = append(s.Positions, *p)
+			}
+		}
+
+		for rankOrder := 1; poker.RankName(rankOrder) != ""; rankOrder++ {
+			if b, ok := c.buckets[poker.RankName(rankOrder)]; ok {
+				s.Showdowns = append(s.Showdowns, *b)
+			}
+		}
+
+		result = append(result, s)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Hands != result[j].Hands {
+			return result[i].Hands > result[j].Hands
+		}
+		return result[i].Player < result[j].Player
+	})
+
+	return result
+}
+
+func count(b bool) int {
+	if b {
+		return 1
+	}
+
+	return 0
+}
+
+func ratio(n, d int) float64 {
+	if d == 0 {
+		return 0
+	}
+
+	return float64(n) / float64(d)
+}
@@ -0,0 +1,54 @@
+package stats
+
+import (
+	"math"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestAggregate(t *testing.T) {
+	var records []Record
+	for i := 0; i < 2; i++ {
+		records = append(records, Records(testHand(string(rune('1'+i)), time.Now()))...)
+	}
+	// Dave folds his big blind in a third hand
+	records = append(records, Record{Player: "Dave", Position: "BB", BigBlind: 10, Net: -10})
+
+	stats := Aggregate(records)
+	if len(stats) != 4 || stats[0].Player != "Dave" {
+		t.Fatalf("Aggregate() = %+v, want Dave first with the most hands", stats)
+	}
+
+	dave := stats[0]
+	if dave.Hands != 3 || dave.Net != 1360 || dave.BigBlinds != 136 {
+		t.Errorf("Dave hands = %v, net = %v, big blinds = %v", dave.Hands, dave.Net, dave.BigBlinds)
+	}
+	if math.Abs(dave.BB100-136.0/3*100) > 1e-9 {
+		t.Errorf("Dave bb/100 = %v", dave.BB100)
+	}
+	if math.Abs(dave.VPIP-2.0/3) > 1e-9 || dave.ThreeBet != 1 || dave.WTSD != 1 || dave.WSD != 1 {
+		t.Errorf("Dave VPIP = %v, 3-bet = %v, WTSD = %v, W$SD = %v", dave.VPIP, dave.ThreeBet, dave.WTSD, dave.WSD)
+	}
+	// two bets and two raises without a call
+	if dave.AF != 4 {
+		t.Errorf("Dave AF = %v, want 4", dave.AF)
+	}
+
+	wantPositions := []PositionStats{{Position: "BB", Hands: 1, Net: -10, BigBlinds: -1}, {Position: "BTN", Hands: 2, Net: 1370, BigBlinds: 137}}
+	if !reflect.DeepEqual(dave.Positions, wantPositions) {
+		t.Errorf("Dave positions = %+v, want %+v", dave.Positions, wantPositions)
+	}
+
+	carol := stats[3]
+	if carol.Player != "Carol" || carol.AF != 0.5 || carol.WSD != 0 || carol.PFR != 1 {
+		t.Errorf("Carol = %+v", carol)
+	}
+	if want := []Bucket{{Category: "One Pair", Showdowns: 2}}; !reflect.DeepEqual(carol.Showdowns, want) {
+		t.Errorf("Carol showdowns = %+v, want %+v", carol.Showdowns, want)
+	}
+
+	if got := Aggregate(nil); len(got) != 0 {
+		t.Errorf("Aggregate(nil) = %v, want none", got)
+	}
+}
@@ -0,0 +1,198 @@
+// Package stats aggregates hand histories into player statistics like VPIP, PFR and the winnings by position.
+package stats
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"sort"
+	"time"
+
+	"github.com/YoungsoonLee/poker/history"
+	"github.com/YoungsoonLee/poker/poker"
+	"github.com/YoungsoonLee/poker/table"
+)
+
+// middlePositions are the positions between the big blind and the button, the last one being next to the button.
+var middlePositions = []string{"UTG", "UTG+1", "UTG+2", "MP", "LJ", "HJ", "CO"}
+
+// Record is what a player did in a single hand.
+// Bets, Raises and Calls count the actions after the flop, which make up the aggression factor.
+// Showdown is the category of the player's hand if they went to showdown and their cards are known.
+type Record struct {
+	Hand           string    `json:"hand"`
+	Time           time.Time `json:"time"`
+	SmallBlind     int       `json:"small_blind"`
+	BigBlind       int       `json:"big_blind"`
+	Currency       string    `json:"currency,omitempty"`
+	Player         string    `json:"player"`
+	Position       string    `json:"position"`
+	Net            int       `json:"net"`
+	VPIP           bool      `json:"vpip"`
+	PFR            bool      `json:"pfr"`
+	ThreeBetChance bool      `json:"three_bet_chance"`
+	ThreeBet       bool      `json:"three_bet"`
+	SawFlop        bool      `json:"saw_flop"`
+	WentToShowdown bool      `json:"went_to_showdown"`
+	WonAtShowdown  bool      `json:"won_at_showdown"`
+	Bets           int       `json:"bets"`
+	Raises         int       `json:"raises"`
+	Calls          int       `json:"calls"`
+	Showdown       string    `json:"showdown,omitempty"`
+}
+
+// Records returns the record of every player dealt into the hand.
+func Records(h history.Hand) []Record {
+	positions := Positions(h)
+	preflop, postflop := table.Preflop.String(), map[string]bool{table.Flop.String(): true, table.Turn.String(): true, table.River.String(): true}
+
+	key := Key(h)
+	records := make([]Record, len(h.Seats))
+	index := make(map[int]int)
+	live := 0
+	for i, s := range h.Seats {
+		records[i] = Record{
+			Hand:       key,
+			SmallBlind: h.SmallBlind,
+			BigBlind:   h.BigBlind,
+			Currency:   h.Currency,
+			Player:     s.Name,
+			Position:   positions[s.Seat],
+			Net:        s.EndStack - s.StartStack,
+		}
+		if h.Time != nil {
+			records[i].Time = *h.Time
+		}
+		index[s.Seat] = i
+		if !s.Folded {
+			live++
+		}
+	}
+
+	raises := 0
+	acted := make(map[int]bool)
+	foldedPreflop := make(map[int]bool)
+	for _, a := range h.Actions {
+		i, ok := index[a.Seat]
+		if !ok {
+			continue
+		}
+		r := &records[i]
+		raise := a.Type == table.Bet.String() || a.Type == table.Raise.String()
+
+		switch {
+		case a.Street == preflop:
+			// posting the blinds or an ante is not voluntary, and is not a chance to 3-bet
+			if !raise && a.Type != table.Call.String() && a.Type != table.Check.String() && a.Type != table.Fold.String() {
+				continue
+			}
+
+			r.VPIP = r.VPIP || raise || a.Type == table.Call.String()
+			r.PFR = r.PFR || raise
+			foldedPreflop[a.Seat] = a.Type == table.Fold.String()
+
+			// a player has a chance to 3-bet when they face exactly one raise on their first action
+			if raises == 1 && !acted[a.Seat] {
+				r.ThreeBetChance = true
+				r.ThreeBet = raise
+			}
+			acted[a.Seat] = true
+
+			if raise {
+				raises++
+			}
+		case postflop[a.Street]:
+			switch a.Type {
+			case table.Bet.String():
+				r.Bets++
+			case table.Raise.String():
+				r.Raises++
+			case table.Call.String():
+				r.Calls++
+			}
+		}
+	}
+
+	winners := make(map[int]bool)
+	for _, p := range h.Pots {
+		for _, w := range p.Winners {
+			winners[w] = true
+		}
+	}
+
+	categories := make(map[int]string)
+	for _, s := range h.Showdown {
+		categories[s.Seat] = poker.RankName(s.RankOrder)
+	}
+
+	for i, s := range h.Seats {
+		r := &records[i]
+		r.SawFlop = len(h.Board) >= 3 && !foldedPreflop[s.Seat]
+		r.WentToShowdown = r.SawFlop && !s.Folded && live > 1
+		r.WonAtShowdown = r.WentToShowdown && winners[s.Seat]
+		if r.WentToShowdown {
+			r.Showdown = categories[s.Seat]
+		}
+	}
+
+	return records
+}
+
+// Key returns the key a hand is stored with.
+// Hands of online sites are identified by their site and hand number, and other hands by their id and a hash of the hand,
+// so the hands of different simulations do not clash.
+func Key(h history.Hand) string {
+	if h.Site != "" {
+		return h.Site + "/" + h.ID
+	}
+
+	b, _ := json.Marshal(h)
+	sum := sha256.Sum256(b)
+
+	return h.ID + "/" + hex.EncodeToString(sum[:8])
+}
+
+// Positions returns the position of every seat dealt into the hand, e.g. "BTN", "SB", "BB" or "CO".
+// In heads-up the button posts the small blind and is called "BTN".
+func Positions(h history.Hand) map[int]string {
+	seats := make([]int, 0, len(h.Seats))
+	for _, s := range h.Seats {
+		seats = append(seats, s.Seat)
+	}
+	sort.Ints(seats)
+
+	// the button is the last seat at or before the button seat, as the button may be dead
+	button := len(seats) - 1
+	for i, s := range seats {
+		if s <= h.Button {
+			button = i
+		}
+	}
+
+	n := len(seats)
+	positions := make(map[int]string, n)
+	for k := 0; k < n; k++ {
+		seat := seats[(button+1+k)%n]
+
+		switch {
+		case k == n-1:
+			positions[seat] = "BTN"
+		case n == 2:
+			positions[seat] = "BB"
+		case k == 0:
+			positions[seat] = "SB"
+		case k == 1:
+			positions[seat] = "BB"
+		default:
+			// the middle positions are named from the button backwards
+			fromButton := n - 1 - k
+			if fromButton <= len(middlePositions) {
+				positions[seat] = middlePositions[len(middlePositions)-fromButton]
+			} else {
+				positions[seat] = middlePositions[0]
+			}
+		}
+	}
+
+	return positions
+}
@@ -0,0 +1,148 @@
+package stats
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/YoungsoonLee/poker/history"
+)
+
+// testHand returns a four handed hand where the button 3-bets the cutoff and wins at showdown.
+func testHand(id string, at time.Time) history.Hand {
+	a := func(seat int, street, typ string, amount, total int) history.Action {
+		return history.Action{Seat: seat, Street: street, Type: typ, Amount: amount, Total: total}
+	}
+
+	return history.Hand{
+		Version:    history.SchemaVersion,
+		Game:       history.GameHoldem,
+		ID:         id,
+		Site:       "Test",
+		Time:       &at,
+		Button:     3,
+		SmallBlind: 5,
+		BigBlind:   10,
+		Seats: []history.Seat{
+			{Seat: 0, Name: "Alice", StartStack: 1000, EndStack: 995, Folded: true},
+			{Seat: 1, Name: "Bob", StartStack: 1000, EndStack: 910, Folded: true},
+			{Seat: 2, Name: "Carol", StartStack: 1000, EndStack: 410, Hole: []string{"AS", "QD"}},
+			{Seat: 3, Name: "Dave", StartStack: 1000, EndStack: 1685, Hole: []string{"KS", "7C"}},
+		},
+		Board: []string{"KD", "7H", "2C", "3S", "AH"},
+		Actions: []history.Action{
+			a(0, "preflop", "small blind", 5, 5),
+			a(1, "preflop", "big blind", 10, 10),
+			a(2, "preflop", "raise", 30, 30),
+			a(3, "preflop", "raise", 90, 90),
+			a(0, "preflop", "fold", 0, 5),
+			a(1, "preflop", "call", 80, 90),
+			a(2, "preflop", "call", 60, 90),
+			a(1, "flop", "check", 0, 0),
+			a(2, "flop", "bet", 100, 100),
+			a(3, "flop", "raise", 300, 300),
+			a(1, "flop", "fold", 0, 0),
+			a(2, "flop", "call", 200, 300),
+			a(2, "turn", "check", 0, 0),
+			a(3, "turn", "check", 0, 0),
+			a(2, "river", "check", 0, 0),
+			a(3, "river", "bet", 200, 200),
+			a(2, "river", "call", 200, 200),
+		},
+		Showdown: []history.Showdown{
+			{Seat: 2, Cards: []string{"AS", "AH", "KD", "QD", "7H"}, Rank: "One Pair", RankOrder: 9},
+			{Seat: 3, Cards: []string{"KS", "KD", "7C", "7H", "AH"}, Rank: "Two Pair", RankOrder: 8},
+		},
+		Pots: []history.Pot{{Amount: 1275, Eligible: []int{2, 3}, Winners: []int{3}}},
+	}
+}
+
+func TestRecords(t *testing.T) {
+	at := time.Date(2023, 5, 1, 12, 0, 0, 0, time.UTC)
+	records := Records(testHand("1", at))
+
+	want := []Record{
+		{Player: "Alice", Position: "SB", Net: -5},
+		{Player: "Bob", Position: "BB", Net: -90, VPIP: true, SawFlop: true},
+		{Player: "Carol", Position: "CO", Net: -590, VPIP: true, PFR: true, SawFlop: true, WentToShowdown: true, Bets: 1, Calls: 2, Showdown: "One Pair"},
+		{Player: "Dave", Position: "BTN", Net: 685, VPIP: true, PFR: true, ThreeBetChance: true, ThreeBet: true, SawFlop: true, WentToShowdown: true, WonAtShowdown: true, Bets: 1, Raises: 1, Showdown: "Two Pair"},
+	}
+	for i := range want {
+		want[i].Hand = "Test/1"
+		want[i].Time = at
+		want[i].SmallBlind = 5
+		want[i].BigBlind = 10
+	}
+
+	if !reflect.DeepEqual(records, want) {
+		for i := range records {
+			if !reflect.DeepEqual(records[i], want[i]) {
+				t.Errorf("Records()[%d] = %+v, want %+v", i, records[i], want[i])
+			}
+		}
+	}
+}
+
+func TestKey(t *testing.T) {
+	at := time.Now()
+	h := testHand("1", at)
+	if got := Key(h); got != "Test/1" {
+		t.Errorf("Key() = %v, want Test/1", got)
+	}
+
+	// hands without a site are told apart by their content
+	h.Site = ""
+	other := testHand("1", at)
+	other.Site = ""
+	other.Board = []string{"KD", "7H", "2C", "3S", "4H"}
+	if Key(h) == Key(other) {
+		t.Errorf("Key() is the same for different hands: %v", Key(h))
+	}
+	if Key(h) != Key(h) {
+		t.Errorf("Key() is not stable")
+	}
+}
+
+func TestPositions(t *testing.T) {
+	seats := func(numbers ...int) []history.Seat {
+		var s []history.Seat
+		for _, n := range numbers {
+			s = append(s, history.Seat{Seat: n})
+		}
+		return s
+	}
+
+	tests := []struct {
+		name string
+		hand history.Hand
+		want map[int]string
+	}{
+		{
+			name: "heads-up",
+			hand: history.Hand{Button: 0, Seats: seats(0, 1)},
+			want: map[int]string{0: "BTN", 1: "BB"},
+		},
+		{
+			name: "six handed",
+			hand: history.Hand{Button: 2, Seats: seats(0, 1, 2, 3, 4, 5)},
+			want: map[int]string{3: "SB", 4: "BB", 5: "LJ", 0: "HJ", 1: "CO", 2: "BTN"},
+		},
+		{
+			name: "dead button",
+			hand: history.Hand{Button: 4, Seats: seats(1, 3, 5)},
+			want: map[int]string{5: "SB", 1: "BB", 3: "BTN"},
+		},
+		{
+			name: "button before every seat",
+			hand: history.Hand{Button: 0, Seats: seats(2, 4, 6)},
+			want: map[int]string{2: "SB", 4: "BB", 6: "BTN"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Positions(tt.hand); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Positions() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
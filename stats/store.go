@@ -0,0 +1,162 @@
+package stats
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/YoungsoonLee/poker/history"
+	bolt "go.etcd.io/bbolt"
+)
+
+var (
+	handsBucket   = []byte("hands")
+	recordsBucket = []byte("records")
+)
+
+// DB is a local file-based store of player records.
+type DB struct {
+	bolt *bolt.DB
+}
+
+// Open opens the store in the given file, creating it if it does not exist.
+// Only one process can open a store at a time.
+func Open(path string) (*DB, error) {
+	b, err := bolt.Open(path, 0o600, &bolt.Options{Timeout: time.Second})
+	if err != nil {
+		return nil, fmt.Errorf("open %s: %w", path, err)
+	}
+
+	err = b.Update(func(tx *bolt.Tx) error {
+		for _, name := range [][]byte{handsBucket, recordsBucket} {
+			if _, err := tx.CreateBucketIfNotExists(name); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		b.Close()
+		return nil, err
+	}
+
+	return &DB{bolt: b}, nil
+}
+
+// Close closes the store.
+func (d *DB) Close() error {
+	return d.bolt.Close()
+}
+
+// Add stores the records of the hands and returns the number of hands added.
+// Hands that are already stored are skipped, so the same history can be added again.
+// Stud and Razz hands are skipped too, as the statistics are those of hold'em.
+func (d *DB) Add(hands ...history.Hand) (int, error) {
+	added := 0
+
+	err := d.bolt.Update(func(tx *bolt.Tx) error {
+		handsB, recordsB := tx.Bucket(handsBucket), tx.Bucket(recordsBucket)
+
+		for _, h := range hands {
+			if h.Game == history.GameStud || h.Game == history.GameRazz {
+				continue
+			}
+
+			key := []byte(Key(h))
+			if handsB.Get(key) != nil {
+				continue
+			}
+
+			if err := handsB.Put(key, []byte{1}); err != nil {
+				return err
+			}
+
+			for _, r := range Records(h) {
+				value, err := json.Marshal(r)
+				if err != nil {
+					return err
+				}
+				if err := recordsB.Put([]byte(r.Hand+"\x00"+r.Player), value); err != nil {
+					return err
+				}
+			}
+			added++
+		}
+
+		return nil
+	})
+
+	return added, err
+}
+
+// Hands returns the number of hands stored.
+func (d *DB) Hands() (int, error) {
+	n := 0
+	err := d.bolt.View(func(tx *bolt.Tx) error {
+		n = tx.Bucket(handsBucket).Stats().KeyN
+		return nil
+	})
+
+	return n, err
+}
+
+// Records returns the stored records that match the filter.
+func (d *DB) Records(f Filter) ([]Record, error) {
+	var records []Record
+
+	err := d.bolt.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(recordsBucket).ForEach(func(k, v []byte) error {
+			var r Record
+			if err := json.Unmarshal(v, &r); err != nil {
+				return fmt.Errorf("record %q: %w", k, err)
+			}
+			if f.Match(r) {
+				records = append(records, r)
+			}
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return records, nil
+}
+
+// Filter selects records by the time of the hand, the stakes and the player.
+// Zero fields match every record. Records without a time do not match a time filter.
+// From is inclusive and To is exclusive.
+type Filter struct {
+	From       time.Time
+	To         time.Time
+	SmallBlind int
+	BigBlind   int
+	Players    []string
+}
+
+// Match reports whether the record matches the filter.
+func (f Filter) Match(r Record) bool {
+	if !f.From.IsZero() && (r.Time.IsZero() || r.Time.Before(f.From)) {
+		return false
+	}
+	if !f.To.IsZero() && (r.Time.IsZero() || !r.Time.Before(f.To)) {
+		return false
+	}
+	if f.SmallBlind != 0 && r.SmallBlind != f.SmallBlind {
+		return false
+	}
+	if f.BigBlind != 0 && r.BigBlind != f.BigBlind {
+		return false
+	}
+
+	if len(f.Players) == 0 {
+		return true
+	}
+	for _, p := range f.Players {
+		if p == r.Player {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,89 @@
+package stats
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/YoungsoonLee/poker/history"
+)
+
+func TestDB(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "stats.db")
+
+	db, err := Open(path)
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+
+	may := time.Date(2023, 5, 1, 12, 0, 0, 0, time.UTC)
+	june := time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC)
+	hands := []history.Hand{testHand("1", may), testHand("2", june)}
+	hands[1].SmallBlind, hands[1].BigBlind = 10, 20
+
+	added, err := db.Add(hands...)
+	if err != nil || added != 2 {
+		t.Fatalf("Add() = %v, %v, want 2", added, err)
+	}
+
+	// adding the same hands again does not count them twice
+	if added, err := db.Add(hands...); err != nil || added != 0 {
+		t.Fatalf("Add() again = %v, %v, want 0", added, err)
+	}
+
+	// stud hands have no hold'em statistics
+	razz := testHand("3", june)
+	razz.Game = history.GameRazz
+	if added, err := db.Add(razz); err != nil || added != 0 {
+		t.Fatalf("Add() razz = %v, %v, want 0", added, err)
+	}
+
+	if err := db.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	db, err = Open(path)
+	if err != nil {
+		t.Fatalf("Open() again error = %v", err)
+	}
+	defer db.Close()
+
+	if n, err := db.Hands(); err != nil || n != 2 {
+		t.Errorf("Hands() = %v, %v, want 2", n, err)
+	}
+
+	tests := []struct {
+		name   string
+		filter Filter
+		want   int
+	}{
+		{name: "everything", filter: Filter{}, want: 8},
+		{name: "from june", filter: Filter{From: june}, want: 4},
+		{name: "before june", filter: Filter{To: june}, want: 4},
+		{name: "stakes", filter: Filter{SmallBlind: 10, BigBlind: 20}, want: 4},
+		{name: "big blind only", filter: Filter{BigBlind: 10}, want: 4},
+		{name: "player", filter: Filter{Players: []string{"Dave", "Carol"}}, want: 4},
+		{name: "nothing", filter: Filter{From: june, BigBlind: 10}, want: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			records, err := db.Records(tt.filter)
+			if err != nil {
+				t.Fatalf("Records() error = %v", err)
+			}
+			if len(records) != tt.want {
+				t.Errorf("Records() = %v records, want %v", len(records), tt.want)
+			}
+		})
+	}
+}
+
+func TestFilter_Match(t *testing.T) {
+	// records without a time never match a time filter
+	if (Filter{From: time.Now()}).Match(Record{Player: "Alice"}) {
+		t.Errorf("Match() = true for a record without a time")
+	}
+	if !(Filter{}).Match(Record{Player: "Alice"}) {
+		t.Errorf("Match() = false for an empty filter")
+	}
+}
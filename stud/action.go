@@ -0,0 +1,86 @@
+// Package stud provides a Fixed-Limit Seven-Card Stud and Razz table engine that deals hands between strategies.
+package stud
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/YoungsoonLee/poker/table"
+)
+
+// Game is the game played at a stud table.
+type Game int
+
+const (
+	// Stud is Seven-Card Stud, where the best five card high hand wins.
+	Stud Game = iota
+	// Razz is Seven-Card Stud where the best five card ace-to-five low hand wins.
+	Razz
+)
+
+// String returns the name of the game.
+func (g Game) String() string {
+	switch g {
+	case Stud:
+		return "stud"
+	case Razz:
+		return "razz"
+	default:
+		return fmt.Sprintf("game(%d)", int(g))
+	}
+}
+
+// ParseGame returns the game with the given name, "stud" or "razz".
+func ParseGame(name string) (Game, error) {
+	for g := Stud; g <= Razz; g++ {
+		if strings.EqualFold(g.String(), name) {
+			return g, nil
+		}
+	}
+
+	return 0, fmt.Errorf("invalid game: %s. game should be stud or razz", name)
+}
+
+// Street represents a betting round of a stud hand, named after the number of cards each player has.
+type Street int
+
+const (
+	ThirdStreet Street = iota
+	FourthStreet
+	FifthStreet
+	SixthStreet
+	SeventhStreet
+	Showdown
+)
+
+// String returns the name of the street.
+func (s Street) String() string {
+	switch s {
+	case ThirdStreet:
+		return "third street"
+	case FourthStreet:
+		return "fourth street"
+	case FifthStreet:
+		return "fifth street"
+	case SixthStreet:
+		return "sixth street"
+	case SeventhStreet:
+		return "seventh street"
+	case Showdown:
+		return "showdown"
+	default:
+		return fmt.Sprintf("street(%d)", int(s))
+	}
+}
+
+// ActionLog records an action taken during a hand.
+// Amount is the number of chips the player put into the pot with the action,
+// and Total is the player's total bet on the street after the action.
+type ActionLog struct {
+	Seat   int
+	Street Street
+	Type   table.ActionType
+	Amount int
+	Total  int
+	AllIn  bool
+}
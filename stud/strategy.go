@@ -0,0 +1,99 @@
+package stud
+
+import (
+	"math/rand"
+
+	"github.com/YoungsoonLee/poker/internal/limit"
+	"github.com/YoungsoonLee/poker/internal/registry"
+	"github.com/YoungsoonLee/poker/table"
+	"github.com/YoungsoonLee/poker/types"
+)
+
+// View is what a player can see when it is their turn to act.
+// Down are the player's face down cards and Up their face up cards, and Opponents the up cards of the other players.
+// Bet is the player's bet on the street, ToCall is the number of chips needed to call,
+// and BetSize is the fixed size of a bet or raise on the street. CanRaise is false once the betting is capped.
+type View struct {
+	Seat      int
+	Game      Game
+	Street    Street
+	Down      []types.Card
+	Up        []types.Card
+	Opponents []Opponent
+	Community []types.Card
+	Pot       int
+	Bet       int
+	ToCall    int
+	BetSize   int
+	CanRaise  bool
+	Stack     int
+	Players   int
+}
+
+// Opponent is what a player can see of another player dealt into the hand.
+type Opponent struct {
+	Seat   int
+	Up     []types.Card
+	Stack  int
+	Folded bool
+}
+
+// Strategy decides the actions of a player.
+// The table corrects invalid actions, so a strategy may e.g. raise when the betting is capped to call.
+// A Bet or Raise always puts in one fixed-size bet, so the Amount of the action is ignored.
+type Strategy interface {
+	Act(v View) table.Action
+}
+
+// StrategyFunc is an adapter to allow the use of ordinary functions as strategies.
+type StrategyFunc func(v View) table.Action
+
+// Act calls f(v).
+func (f StrategyFunc) Act(v View) table.Action {
+	return f(v)
+}
+
+// strategies maps the name of a built-in strategy to its constructor.
+var strategies = registry.Registry[Strategy]{
+	"calling": func(*rand.Rand) Strategy { return CallingStation{} },
+	"maniac":  func(*rand.Rand) Strategy { return Maniac{} },
+	"random":  func(rng *rand.Rand) Strategy { return &Random{rng: rng} },
+}
+
+// StrategyNames returns the sorted names of the built-in strategies.
+func StrategyNames() []string {
+	return strategies.Names()
+}
+
+// NewStrategy creates a built-in strategy by name.
+// The random number generator is used by strategies that make random decisions.
+// If rng is nil, a generator seeded with the current time is used.
+func NewStrategy(name string, rng *rand.Rand) (Strategy, error) {
+	return strategies.New(name, rng)
+}
+
+// CallingStation checks or calls every bet and never raises.
+type CallingStation struct{}
+
+// Act implements Strategy.
+func (CallingStation) Act(v View) table.Action {
+	return table.Action{Type: table.Call}
+}
+
+// Maniac bets or raises on every decision.
+type Maniac struct{}
+
+// Act implements Strategy.
+func (Maniac) Act(v View) table.Action {
+	return table.Action{Type: table.Raise}
+}
+
+// Random chooses between folding, calling and raising at random.
+type Random struct {
+	rng *rand.Rand
+}
+
+// Act implements Strategy.
+func (r *Random) Act(v View) table.Action {
+	return limit.RandomAction(r.rng)
+}
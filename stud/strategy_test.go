@@ -0,0 +1,17 @@
+package stud
+
+import (
+	"testing"
+)
+
+func TestNewStrategy(t *testing.T) {
+	for _, name := range StrategyNames() {
+		if _, err := NewStrategy(name, nil); err != nil {
+			t.Errorf("NewStrategy(%s) error = %v", name, err)
+		}
+	}
+
+	if _, err := NewStrategy("tag", nil); err == nil {
+		t.Errorf("NewStrategy(tag) error = nil, want error")
+	}
+}
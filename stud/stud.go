@@ -0,0 +1,428 @@
+package stud
+
+import (
+	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/YoungsoonLee/poker/internal/limit"
+	"github.com/YoungsoonLee/poker/poker"
+	"github.com/YoungsoonLee/poker/table"
+	"github.com/YoungsoonLee/poker/types"
+)
+
+// maxSeats is the number of players a 52 card deck can deal seven cards to, sharing a community card on seventh street if needed.
+const maxSeats = 8
+
+// suitOrder ranks the suits for breaking ties of the bring-in, from clubs, the lowest, to spades.
+var suitOrder = map[string]int{"C": 0, "D": 1, "H": 2, "S": 3}
+
+// Seat represents a player sitting at the table.
+// A seat with an empty stack sits out until it gets chips again.
+type Seat = limit.Seat[Strategy]
+
+// Stakes are the forced bets and the fixed bet sizes of a table.
+// The bring-in is posted on third street by the player with the worst up card, and the next players can complete it to a small bet.
+// Small bets are used on third and fourth street, and big bets from fifth street on.
+type Stakes struct {
+	Ante     int
+	BringIn  int
+	SmallBet int
+	BigBet   int
+}
+
+// validate checks that the stakes can be played.
+func (s Stakes) validate() error {
+	if s.Ante < 0 || s.SmallBet < 1 || s.BigBet < s.SmallBet || s.BringIn < 1 || s.BringIn > s.SmallBet {
+		return fmt.Errorf("invalid stakes: ante %d, bring-in %d, %d/%d", s.Ante, s.BringIn, s.SmallBet, s.BigBet)
+	}
+
+	return nil
+}
+
+// Table deals hands of Fixed-Limit Seven-Card Stud or Razz between the seats.
+// Stud has no button: cards are dealt in seat order, the bring-in is decided by the up cards on third street,
+// and the player with the best up cards acts first on every later street.
+type Table struct {
+	Game   Game
+	Seats  []*Seat
+	Stakes Stakes
+
+	rng    *rand.Rand
+	handNo int
+}
+
+// New creates a new Table with the given game, seats and stakes. A table has at most 8 seats.
+// If rng is nil, a generator seeded with the current time is used for shuffling.
+func New(game Game, seats []*Seat, stakes Stakes, rng *rand.Rand) (*Table, error) {
+	if game != Stud && game != Razz {
+		return nil, fmt.Errorf("invalid game: %s", game)
+	}
+
+	if len(seats) < 2 || len(seats) > maxSeats {
+		return nil, fmt.Errorf("a stud table needs 2 to %d seats, got %d", maxSeats, len(seats))
+	}
+
+	if err := stakes.validate(); err != nil {
+		return nil, err
+	}
+
+	for i, s := range seats {
+		if s.Strategy == nil {
+			return nil, fmt.Errorf("seat %d (%s) has no strategy", i, s.Name)
+		}
+	}
+
+	if rng == nil {
+		rng = rand.New(rand.NewSource(time.Now().UnixNano()))
+	}
+
+	return &Table{Game: game, Seats: seats, Stakes: stakes, rng: rng}, nil
+}
+
+// PlayerResult is the outcome of a hand for one seat.
+// Down are the face down cards of the player and Up the face up cards, in the order they were dealt.
+type PlayerResult struct {
+	Seat       int
+	Name       string
+	StartStack int
+	EndStack   int
+	Down       []types.Card
+	Up         []types.Card
+	Folded     bool
+}
+
+// Net returns the number of chips the player won (positive) or lost (negative) in the hand.
+func (p PlayerResult) Net() int {
+	return p.EndStack - p.StartStack
+}
+
+// Cards returns all the cards of the player, down cards first.
+func (p PlayerResult) Cards() []types.Card {
+	return append(append([]types.Card(nil), p.Down...), p.Up...)
+}
+
+// ShowdownResult is the best hand shown by a seat at showdown.
+// Rank is the name from Hand.Evaluate in Stud, and from Hand.EvaluateLow in Razz, and Score the matching score.
+type ShowdownResult struct {
+	Seat  int
+	Hand  poker.Hand
+	Rank  string
+	Score int
+}
+
+// Result records everything that happened in a hand.
+// Players only contains the seats that were dealt in.
+// Community is the card shared by every player on seventh street when the deck runs out, or empty.
+type Result struct {
+	HandNo      int
+	Game        Game
+	Stakes      Stakes
+	Players     []PlayerResult
+	BringInSeat int
+	Community   []types.Card
+	Actions     []ActionLog
+	Showdown    []ShowdownResult
+	Pots        []table.Pot
+}
+
+// Player returns the result of the given seat, and false if the seat was not dealt in.
+func (r *Result) Player(seat int) (PlayerResult, bool) {
+	for _, p := range r.Players {
+		if p.Seat == seat {
+			return p, true
+		}
+	}
+
+	return PlayerResult{}, false
+}
+
+// ActiveSeats returns the number of seats that have chips.
+func (t *Table) ActiveSeats() int {
+	n := 0
+	for _, s := range t.Seats {
+		if s.Stack > 0 {
+			n++
+		}
+	}
+
+	return n
+}
+
+// HandNo returns the number of hands played at the table.
+func (t *Table) HandNo() int {
+	return t.handNo
+}
+
+// PlayHand deals and plays one hand, and updates the stacks of the seats.
+// It returns table.ErrNotEnoughPlayers if fewer than two seats have chips.
+func (t *Table) PlayHand() (*Result, error) {
+	if t.ActiveSeats() < 2 {
+		return nil, table.ErrNotEnoughPlayers
+	}
+
+	if err := t.Stakes.validate(); err != nil {
+		return nil, err
+	}
+
+	t.handNo++
+
+	h := newHand(t)
+	h.play()
+
+	return h.result, nil
+}
+
+// player is the state of a seat during a hand: its betting and its cards.
+type player struct {
+	*limit.Player[Strategy]
+	down []types.Card
+	up   []types.Card
+}
+
+// cards returns all the cards of the player with the community card, if any.
+func (p *player) cards(community []types.Card) []types.Card {
+	return append(append(append([]types.Card(nil), p.down...), p.up...), community...)
+}
+
+// hand is the state of a hand being played. The players are in seat order, in the same order as the players of the betting.
+type hand struct {
+	t         *Table
+	deck      *poker.Deck
+	betting   *limit.Betting[Strategy]
+	players   []*player
+	community []types.Card
+	street    Street
+	result    *Result
+}
+
+func newHand(t *Table) *hand {
+	h := &hand{
+		t:    t,
+		deck: poker.NewDeck(t.rng),
+		result: &Result{
+			HandNo: t.handNo,
+			Game:   t.Game,
+			Stakes: t.Stakes,
+		},
+	}
+	h.betting = &limit.Betting[Strategy]{
+		BetSize: t.Stakes.SmallBet,
+		Decide:  func(i int) table.Action { return h.players[i].Strategy.Act(h.view(i)) },
+		Record:  h.record,
+	}
+
+	for i, s := range t.Seats {
+		if s.Stack <= 0 {
+			continue
+		}
+		p := &player{Player: &limit.Player[Strategy]{Seat: s, SeatIndex: i}}
+		h.players = append(h.players, p)
+		h.betting.Players = append(h.betting.Players, p.Player)
+		h.result.Players = append(h.result.Players, PlayerResult{Seat: i, Name: s.Name, StartStack: s.Stack})
+	}
+
+	return h
+}
+
+func (h *hand) play() {
+	h.deck.Shuffle()
+
+	b := h.betting
+	if h.t.Stakes.Ante > 0 {
+		for i := range h.players {
+			b.Put(i, table.PostAnte, h.t.Stakes.Ante)
+		}
+	}
+
+	// two down cards and one up card on third street
+	h.deal(false)
+	h.deal(false)
+	h.deal(true)
+
+	bringIn := h.bringInIndex()
+	h.result.BringInSeat = h.players[bringIn].SeatIndex
+	b.Put(bringIn, table.PostBringIn, h.t.Stakes.BringIn)
+	b.CurrentBet = h.t.Stakes.BringIn
+	b.Round(b.Next(bringIn), bringIn)
+
+	for _, street := range []Street{FourthStreet, FifthStreet, SixthStreet, SeventhStreet} {
+		if b.InHand() < 2 {
+			break
+		}
+
+		h.street = street
+		b.NextRound(h.betSize())
+
+		switch {
+		case street < SeventhStreet:
+			h.deal(true)
+		case h.deck.Len() < b.InHand():
+			// there are not enough cards left for everyone, so a single community card is dealt face up
+			h.community, _ = h.deck.Deal(1)
+		default:
+			h.deal(false)
+		}
+
+		if b.AbleToAct() > 1 {
+			b.Round(h.firstToAct())
+		}
+	}
+
+	b.NextRound(0)
+	h.street = Showdown
+	h.awardPots()
+	h.finish()
+}
+
+// deal deals one card to every player still in the hand in seat order, face up or face down.
+func (h *hand) deal(up bool) {
+	for _, p := range h.players {
+		if p.Folded {
+			continue
+		}
+		card, _ := h.deck.Deal(1)
+		if up {
+			p.up = append(p.up, card...)
+		} else {
+			p.down = append(p.down, card...)
+		}
+	}
+}
+
+// bringInIndex returns the index of the player who posts the bring-in on third street:
+// the lowest up card in Stud and the highest in Razz, where the ace is low.
+// Cards of the same rank are ranked by suit from clubs, the lowest, to spades.
+func (h *hand) bringInIndex() int {
+	value := func(c types.Card) int {
+		rank := types.RankMap[c.Rank]
+		if h.t.Game == Razz && c.Rank == "A" {
+			rank = 1
+		}
+		return rank*len(suitOrder) + suitOrder[c.Suit]
+	}
+
+	best := 0
+	for i, p := range h.players {
+		v, bestValue := value(p.up[0]), value(h.players[best].up[0])
+		if (h.t.Game == Stud && v < bestValue) || (h.t.Game == Razz && v > bestValue) {
+			best = i
+		}
+	}
+
+	return best
+}
+
+// firstToAct returns the index of the player who acts first after third street:
+// the player with the best up cards, the highest in Stud and the lowest in Razz.
+// Straights and flushes do not count in the up cards, and ties go to the lowest seat.
+func (h *hand) firstToAct() int {
+	first, firstScore := -1, 0
+	for i, p := range h.players {
+		if !p.CanAct() {
+			continue
+		}
+
+		score := poker.Hand{Cards: p.up}.PartialScore()
+		if h.t.Game == Razz {
+			score = poker.Hand{Cards: p.up}.LowScore()
+		}
+		if first < 0 || score < firstScore {
+			first, firstScore = i, score
+		}
+	}
+
+	return first
+}
+
+// betSize returns the fixed size of a bet on the current street.
+func (h *hand) betSize() int {
+	if h.street < FifthStreet {
+		return h.t.Stakes.SmallBet
+	}
+
+	return h.t.Stakes.BigBet
+}
+
+// record logs an action of the betting on the current street.
+func (h *hand) record(p *limit.Player[Strategy], actionType table.ActionType, amount int) {
+	h.result.Actions = append(h.result.Actions, ActionLog{
+		Seat:   p.SeatIndex,
+		Street: h.street,
+		Type:   actionType,
+		Amount: amount,
+		Total:  p.Bet,
+		AllIn:  p.AllIn,
+	})
+}
+
+// view returns what the player at index i can see.
+func (h *hand) view(i int) View {
+	p := h.players[i]
+
+	var opponents []Opponent
+	for j, other := range h.players {
+		if j != i {
+			opponents = append(opponents, Opponent{Seat: other.SeatIndex, Up: append([]types.Card(nil), other.up...), Stack: other.Stack, Folded: other.Folded})
+		}
+	}
+
+	return View{
+		Seat:      p.SeatIndex,
+		Game:      h.t.Game,
+		Street:    h.street,
+		Down:      append([]types.Card(nil), p.down...),
+		Up:        append([]types.Card(nil), p.up...),
+		Opponents: opponents,
+		Community: append([]types.Card(nil), h.community...),
+		Pot:       h.betting.Pot(),
+		Bet:       p.Bet,
+		ToCall:    h.betting.CurrentBet - p.Bet,
+		BetSize:   h.betting.BetSize,
+		CanRaise:  h.betting.CanRaise(),
+		Stack:     p.Stack,
+		Players:   h.betting.InHand(),
+	}
+}
+
+// bestHand returns the best five card hand of the player for the game with its name and score.
+func (h *hand) bestHand(p *player) ShowdownResult {
+	cards := p.cards(h.community)
+
+	if h.t.Game == Razz {
+		best, _ := poker.BestLowHand(p.SeatIndex, cards)
+		return ShowdownResult{Seat: p.SeatIndex, Hand: best, Rank: best.EvaluateLow(), Score: best.LowScore()}
+	}
+
+	best, _ := poker.BestHand(p.SeatIndex, cards)
+	rank, _ := best.Evaluate()
+	return ShowdownResult{Seat: p.SeatIndex, Hand: best, Rank: rank, Score: best.Score()}
+}
+
+// awardPots decides the winners of each pot by the scores of the best hands and pays them.
+// Odd chips go to the winners in seat order.
+func (h *hand) awardPots() {
+	order := make([]int, len(h.players))
+	scores := make(map[int]int)
+	for i, p := range h.players {
+		order[i] = p.SeatIndex
+		if !p.Folded && h.betting.InHand() > 1 {
+			shown := h.bestHand(p)
+			scores[p.SeatIndex] = shown.Score
+			h.result.Showdown = append(h.result.Showdown, shown)
+		}
+	}
+
+	h.result.Pots = h.betting.Award(scores, order)
+}
+
+// finish fills in the final state of the players in the result.
+func (h *hand) finish() {
+	h.result.Community = h.community
+	for k, p := range h.players {
+		h.result.Players[k].EndStack = p.Stack
+		h.result.Players[k].Down = p.down
+		h.result.Players[k].Up = p.up
+		h.result.Players[k].Folded = p.Folded
+	}
+}
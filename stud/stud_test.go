@@ -0,0 +1,296 @@
+package stud
+
+import (
+	"errors"
+	"math/rand"
+	"testing"
+
+	"github.com/YoungsoonLee/poker/internal/limit"
+	"github.com/YoungsoonLee/poker/internal/pokertest"
+	"github.com/YoungsoonLee/poker/table"
+)
+
+// testStakes are the stakes of the test tables: an ante of 1, a bring-in of 2 and 5/10 limits.
+var testStakes = Stakes{Ante: 1, BringIn: 2, SmallBet: 5, BigBet: 10}
+
+// newTestTable creates a table of the game with one seat per stack, all using the given strategy.
+func newTestTable(t *testing.T, game Game, strategy Strategy, stacks ...int) *Table {
+	t.Helper()
+
+	tb, err := New(game, pokertest.Seats(strategy, stacks...), testStakes, rand.New(rand.NewSource(1)))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	return tb
+}
+
+func TestNew(t *testing.T) {
+	seats := func(n int) []*Seat {
+		stacks := make([]int, n)
+		for i := range stacks {
+			stacks[i] = 100
+		}
+		return pokertest.Seats[Strategy](CallingStation{}, stacks...)
+	}
+
+	tests := []struct {
+		name    string
+		game    Game
+		seats   []*Seat
+		stakes  Stakes
+		wantErr bool
+	}{
+		{name: "valid table", game: Stud, seats: seats(2), stakes: testStakes},
+		{name: "razz with 8 seats", game: Razz, seats: seats(8), stakes: testStakes},
+		{name: "one seat", game: Stud, seats: seats(1), stakes: testStakes, wantErr: true},
+		{name: "9 seats", game: Stud, seats: seats(9), stakes: testStakes, wantErr: true},
+		{name: "bring-in bigger than the small bet", game: Stud, seats: seats(2), stakes: Stakes{BringIn: 6, SmallBet: 5, BigBet: 10}, wantErr: true},
+		{name: "big bet smaller than the small bet", game: Stud, seats: seats(2), stakes: Stakes{BringIn: 2, SmallBet: 5, BigBet: 4}, wantErr: true},
+		{name: "invalid game", game: Game(5), seats: seats(2), stakes: testStakes, wantErr: true},
+		{name: "seat without strategy", game: Stud, seats: []*Seat{{Name: "A", Stack: 100, Strategy: CallingStation{}}, {Name: "B", Stack: 100}}, stakes: testStakes, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := New(tt.game, tt.seats, tt.stakes, nil)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("New() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestParseGame(t *testing.T) {
+	tests := []struct {
+		name    string
+		want    Game
+		wantErr bool
+	}{
+		{name: "stud", want: Stud},
+		{name: "Razz", want: Razz},
+		{name: "holdem", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseGame(tt.name)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseGame() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseGame() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTable_PlayHand_FoldToBringIn(t *testing.T) {
+	tb := newTestTable(t, Stud, StrategyFunc(func(v View) table.Action { return table.Action{Type: table.Fold} }), 100, 100, 100)
+
+	result, err := tb.PlayHand()
+	if err != nil {
+		t.Fatalf("Table.PlayHand() error = %v", err)
+	}
+
+	// the bring-in wins the antes of the other players
+	for _, p := range result.Players {
+		want := -testStakes.Ante
+		if p.Seat == result.BringInSeat {
+			want = 2 * testStakes.Ante
+		}
+		if p.Net() != want {
+			t.Errorf("seat %d net = %d, want %d", p.Seat, p.Net(), want)
+		}
+	}
+	if len(result.Showdown) != 0 {
+		t.Errorf("Showdown = %v, want none", result.Showdown)
+	}
+}
+
+func TestTable_PlayHand_Showdown(t *testing.T) {
+	for _, game := range []Game{Stud, Razz} {
+		t.Run(game.String(), func(t *testing.T) {
+			tb := newTestTable(t, game, CallingStation{}, 1000, 1000, 1000)
+
+			result, err := tb.PlayHand()
+			if err != nil {
+				t.Fatalf("Table.PlayHand() error = %v", err)
+			}
+
+			for _, p := range result.Players {
+				if len(p.Down) != 3 || len(p.Up) != 4 {
+					t.Errorf("seat %d has %d down and %d up cards, want 3 and 4", p.Seat, len(p.Down), len(p.Up))
+				}
+			}
+			if len(result.Showdown) != 3 {
+				t.Fatalf("Showdown has %d hands, want 3", len(result.Showdown))
+			}
+
+			// calling stations put in the ante and call the bring-in, and the best score wins the pot
+			best := result.Showdown[0]
+			for _, s := range result.Showdown {
+				if s.Score < best.Score {
+					best = s
+				}
+			}
+			if winners := result.Pots[0].Winners; len(winners) != 1 || winners[0] != best.Seat {
+				t.Errorf("Winners = %v, want seat %d with %s", winners, best.Seat, best.Rank)
+			}
+			if want := 3 * (testStakes.Ante + testStakes.BringIn); result.Pots[0].Amount != want {
+				t.Errorf("pot = %d, want %d", result.Pots[0].Amount, want)
+			}
+		})
+	}
+}
+
+func TestTable_PlayHand_Capped(t *testing.T) {
+	tb := newTestTable(t, Stud, Maniac{}, 1000, 1000)
+
+	result, err := tb.PlayHand()
+	if err != nil {
+		t.Fatalf("Table.PlayHand() error = %v", err)
+	}
+
+	// every street is capped at a bet and three raises
+	perStreet := map[Street]int{}
+	for _, a := range result.Actions {
+		if a.Type == table.Bet || a.Type == table.Raise {
+			perStreet[a.Street]++
+		}
+	}
+	for street := ThirdStreet; street <= SeventhStreet; street++ {
+		if perStreet[street] != limit.MaxBets {
+			t.Errorf("%s has %d bets and raises, want %d", street, perStreet[street], limit.MaxBets)
+		}
+	}
+
+	// 4 small bets on third and fourth street, and 4 big bets on the other three, plus the ante
+	want := testStakes.Ante + 2*limit.MaxBets*testStakes.SmallBet + 3*limit.MaxBets*testStakes.BigBet
+	if result.Pots[0].Amount != 2*want {
+		t.Errorf("pot = %d, want %d", result.Pots[0].Amount, 2*want)
+	}
+}
+
+func TestTable_PlayHand_CommunityCard(t *testing.T) {
+	tb := newTestTable(t, Stud, CallingStation{}, 100, 100, 100, 100, 100, 100, 100, 100)
+
+	result, err := tb.PlayHand()
+	if err != nil {
+		t.Fatalf("Table.PlayHand() error = %v", err)
+	}
+
+	// 8 players use 48 cards by sixth street, so the last card is shared
+	if len(result.Community) != 1 {
+		t.Fatalf("Community = %v, want one card", result.Community)
+	}
+	for _, s := range result.Showdown {
+		p, _ := result.Player(s.Seat)
+		if len(p.Cards()) != 6 {
+			t.Errorf("seat %d has %d cards, want 6 and the community card", p.Seat, len(p.Cards()))
+		}
+	}
+}
+
+func TestTable_PlayHand_ChipsConserved(t *testing.T) {
+	for _, game := range []Game{Stud, Razz} {
+		t.Run(game.String(), func(t *testing.T) {
+			rng := rand.New(rand.NewSource(2))
+			seats := []*Seat{
+				{Name: "A", Stack: 200, Strategy: &Random{rng: rng}},
+				{Name: "B", Stack: 50, Strategy: Maniac{}},
+				{Name: "C", Stack: 300, Strategy: CallingStation{}},
+				{Name: "D", Stack: 120, Strategy: &Random{rng: rng}},
+			}
+			tb, err := New(game, seats, testStakes, rng)
+			if err != nil {
+				t.Fatalf("New() error = %v", err)
+			}
+
+			for i := 0; i < 200; i++ {
+				result, err := tb.PlayHand()
+				if errors.Is(err, table.ErrNotEnoughPlayers) {
+					break
+				}
+				if err != nil {
+					t.Fatalf("Table.PlayHand() error = %v", err)
+				}
+
+				total, pots := 0, 0
+				for _, s := range seats {
+					if s.Stack < 0 {
+						t.Fatalf("hand %d: seat %s has %d chips", result.HandNo, s.Name, s.Stack)
+					}
+					total += s.Stack
+				}
+				for _, p := range result.Pots {
+					pots += p.Amount
+				}
+				if total != 670 {
+					t.Fatalf("hand %d: total chips = %d, want 670", result.HandNo, total)
+				}
+
+				committed := 0
+				for _, p := range result.Players {
+					committed -= min(p.Net(), 0)
+				}
+				if pots < committed {
+					t.Fatalf("hand %d: pots = %d, less than the %d chips lost", result.HandNo, pots, committed)
+				}
+			}
+		})
+	}
+}
+
+func TestHand_BringInIndex(t *testing.T) {
+	tests := []struct {
+		name string
+		game Game
+		up   []string
+		want int
+	}{
+		{name: "stud lowest card", game: Stud, up: []string{"KS", "3D", "9H"}, want: 1},
+		{name: "stud ace is high", game: Stud, up: []string{"AS", "KD", "QH"}, want: 2},
+		{name: "stud clubs are the lowest suit", game: Stud, up: []string{"2S", "2C", "2H"}, want: 1},
+		{name: "razz highest card", game: Razz, up: []string{"KS", "3D", "9H"}, want: 0},
+		{name: "razz ace is low", game: Razz, up: []string{"AS", "2D", "3H"}, want: 2},
+		{name: "razz spades are the highest suit", game: Razz, up: []string{"KH", "KS", "KC"}, want: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := &hand{t: &Table{Game: tt.game}}
+			for _, up := range tt.up {
+				h.players = append(h.players, &player{Player: &limit.Player[Strategy]{}, up: pokertest.Cards(t, up)})
+			}
+			if got := h.bringInIndex(); got != tt.want {
+				t.Errorf("bringInIndex() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHand_FirstToAct(t *testing.T) {
+	tests := []struct {
+		name string
+		game Game
+		up   []string
+		want int
+	}{
+		{name: "stud pair beats ace high", game: Stud, up: []string{"AS KD", "3D 3H", "9H 8H"}, want: 1},
+		{name: "stud higher pair", game: Stud, up: []string{"4S 4D", "5C 5H"}, want: 1},
+		{name: "stud trips beat two pair", game: Stud, up: []string{"KS KD QH QC", "2S 2D 2H 7C"}, want: 1},
+		{name: "stud ties go to the lowest seat", game: Stud, up: []string{"AS KD", "AH KC"}, want: 0},
+		{name: "stud four flush does not count", game: Stud, up: []string{"2S 4S 6S 8S", "3D 3H 5C 7D"}, want: 1},
+		{name: "razz lowest cards", game: Razz, up: []string{"AS KD", "7D 6H", "8H 2H"}, want: 1},
+		{name: "razz pair is bad", game: Razz, up: []string{"AS AD", "KD QH"}, want: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := &hand{t: &Table{Game: tt.game}}
+			for _, up := range tt.up {
+				h.players = append(h.players, &player{Player: &limit.Player[Strategy]{Seat: &Seat{Stack: 100}}, up: pokertest.Cards(t, up)})
+			}
+			if got := h.firstToAct(); got != tt.want {
+				t.Errorf("firstToAct() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,93 @@
+// Package table provides a No-Limit Texas Hold'em table engine that deals hands between strategies.
+package table
+
+import "fmt"
+
+// Street represents a betting round of a hand.
+type Street int
+
+const (
+	Preflop Street = iota
+	Flop
+	Turn
+	River
+	Showdown
+)
+
+// String returns the name of the street.
+func (s Street) String() string {
+	switch s {
+	case Preflop:
+		return "preflop"
+	case Flop:
+		return "flop"
+	case Turn:
+		return "turn"
+	case River:
+		return "river"
+	case Showdown:
+		return "showdown"
+	default:
+		return fmt.Sprintf("street(%d)", int(s))
+	}
+}
+
+// ActionType represents the kind of an action taken by a player.
+type ActionType int
+
+const (
+	Fold ActionType = iota
+	Check
+	Call
+	Bet
+	Raise
+	PostAnte
+	PostSmallBlind
+	PostBigBlind
+	PostBringIn
+)
+
+// String returns the name of the action type.
+func (a ActionType) String() string {
+	switch a {
+	case Fold:
+		return "fold"
+	case Check:
+		return "check"
+	case Call:
+		return "call"
+	case Bet:
+		return "bet"
+	case Raise:
+		return "raise"
+	case PostAnte:
+		return "ante"
+	case PostSmallBlind:
+		return "small blind"
+	case PostBigBlind:
+		return "big blind"
+	case PostBringIn:
+		return "bring-in"
+	default:
+		return fmt.Sprintf("action(%d)", int(a))
+	}
+}
+
+// Action is a decision made by a strategy.
+// Amount is only used for Bet and Raise, and is the total amount the player wants to have in front of them on this street.
+type Action struct {
+	Type   ActionType
+	Amount int
+}
+
+// ActionLog records an action taken during a hand.
+// Amount is the number of chips the player put into the pot with the action,
+// and Total is the player's total bet on the street after the action.
+type ActionLog struct {
+	Seat   int
+	Street Street
+	Type   ActionType
+	Amount int
+	Total  int
+	AllIn  bool
+}
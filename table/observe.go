@@ -0,0 +1,63 @@
+package table
+
+import (
+	"github.com/YoungsoonLee/poker/types"
+)
+
+// Snapshot is the state of a hand in progress.
+// It includes the hole cards of every player, so it must not be shown to players as it is.
+// Action is the action that was just taken, or nil when cards were just dealt.
+type Snapshot struct {
+	HandNo  int
+	Street  Street
+	Button  int
+	Board   []types.Card
+	Pot     int
+	Players []PlayerSnapshot
+	Action  *ActionLog
+}
+
+// PlayerSnapshot is the state of a seat dealt into a hand in progress.
+// Bet is the player's bet on the street.
+type PlayerSnapshot struct {
+	Seat   int
+	Name   string
+	Stack  int
+	Bet    int
+	Hole   []types.Card
+	Folded bool
+	AllIn  bool
+}
+
+// Observer is called with a snapshot of the hand after the hole cards are dealt, after every action and after every street is dealt,
+// e.g. to show the hand to players as it is played.
+type Observer func(Snapshot)
+
+// observe calls the observer of the table, if any, with a snapshot of the hand.
+func (h *hand) observe(action *ActionLog) {
+	if h.t.Observer == nil {
+		return
+	}
+
+	s := Snapshot{
+		HandNo: h.t.handNo,
+		Street: h.street,
+		Button: h.t.Button,
+		Board:  append([]types.Card(nil), h.board...),
+		Action: action,
+	}
+	for _, p := range h.players {
+		s.Pot += p.committed
+		s.Players = append(s.Players, PlayerSnapshot{
+			Seat:   p.seat,
+			Name:   p.s.Name,
+			Stack:  p.s.Stack,
+			Bet:    p.bet,
+			Hole:   append([]types.Card(nil), p.hole...),
+			Folded: p.folded,
+			AllIn:  p.allIn,
+		})
+	}
+
+	h.t.Observer(s)
+}
@@ -0,0 +1,53 @@
+package table
+
+import (
+	"testing"
+)
+
+func TestTable_Observer(t *testing.T) {
+	tb := newTestTable(t, CallingStation{}, 100, 100, 100)
+
+	var snapshots []Snapshot
+	tb.Observer = func(s Snapshot) {
+		snapshots = append(snapshots, s)
+	}
+
+	result, err := tb.PlayHand()
+	if err != nil {
+		t.Fatalf("Table.PlayHand() error = %v", err)
+	}
+
+	if len(snapshots) == 0 || snapshots[0].Action != nil || snapshots[0].Street != Preflop {
+		t.Fatalf("first snapshot = %+v, want the hole cards dealt preflop", snapshots)
+	}
+	for _, p := range snapshots[0].Players {
+		if len(p.Hole) != holeCardCount {
+			t.Errorf("seat %d has %d hole cards, want %d", p.Seat, len(p.Hole), holeCardCount)
+		}
+	}
+
+	actions, deals := 0, 0
+	for _, s := range snapshots {
+		if s.Action == nil {
+			deals++
+			continue
+		}
+		if *s.Action != result.Actions[actions] {
+			t.Errorf("snapshot action %d = %+v, want %+v", actions, *s.Action, result.Actions[actions])
+		}
+		actions++
+	}
+
+	// the calling stations see every street, so the hole cards, flop, turn and river are dealt
+	if actions != len(result.Actions) || deals != 4 {
+		t.Errorf("snapshots = %d actions and %d deals, want %d actions and 4 deals", actions, deals, len(result.Actions))
+	}
+
+	pot := 0
+	for _, p := range result.Pots {
+		pot += p.Amount
+	}
+	if last := snapshots[len(snapshots)-1]; last.Pot != pot || len(last.Board) != 5 {
+		t.Errorf("last snapshot pot = %d with %d board cards, want %d with 5", last.Pot, len(last.Board), pot)
+	}
+}
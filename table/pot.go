@@ -0,0 +1,102 @@
+package table
+
+import (
+	"slices"
+	"sort"
+)
+
+// Pot is a main or side pot and the seats that won it.
+type Pot struct {
+	Amount   int
+	Eligible []int
+	Winners  []int
+}
+
+// Contribution is the number of chips a seat put into the pot during a hand, and whether it folded.
+type Contribution struct {
+	Seat      int
+	Committed int
+	Folded    bool
+}
+
+// BuildPots splits the chips committed by the seats into a main pot and side pots.
+// Every all-in for less than the others starts a new side pot, and the seats eligible for a pot are
+// the seats that did not fold and committed at least its level, in the order of the contributions.
+// Chips nobody left in the hand can win go to the previous pot.
+func BuildPots(contributions []Contribution) []Pot {
+	levels := make([]int, 0, len(contributions))
+	for _, c := range contributions {
+		if c.Committed > 0 && !slices.Contains(levels, c.Committed) {
+			levels = append(levels, c.Committed)
+		}
+	}
+	sort.Ints(levels)
+
+	var pots []Pot
+	prev := 0
+	for _, level := range levels {
+		pot := Pot{}
+		for _, c := range contributions {
+			pot.Amount += min(c.Committed, level) - min(c.Committed, prev)
+			if !c.Folded && c.Committed >= level {
+				pot.Eligible = append(pot.Eligible, c.Seat)
+			}
+		}
+		prev = level
+
+		// chips nobody left in the hand can win, and levels with the same players, go to the previous pot
+		if len(pots) > 0 && (len(pot.Eligible) == 0 || slices.Equal(pots[len(pots)-1].Eligible, pot.Eligible)) {
+			pots[len(pots)-1].Amount += pot.Amount
+			continue
+		}
+		pots = append(pots, pot)
+	}
+
+	return pots
+}
+
+// Award sets the winners of the pots and returns the chips won by each seat.
+// Scores are the scores of the hands shown at showdown by seat, where the lowest score wins and equal scores split the pot.
+// Without scores, when everybody else folded, the eligible seats win. Odd chips of a split pot go to the winners
+// in the given order of the seats, e.g. starting left of the button.
+func Award(pots []Pot, scores map[int]int, order []int) map[int]int {
+	won := make(map[int]int)
+
+	for k := range pots {
+		pot := &pots[k]
+		pot.Winners = nil
+
+		if len(scores) == 0 {
+			pot.Winners = append(pot.Winners, pot.Eligible...)
+		} else if len(pot.Eligible) > 0 {
+			best := scores[pot.Eligible[0]]
+			for _, seat := range pot.Eligible {
+				best = min(best, scores[seat])
+			}
+			for _, seat := range pot.Eligible {
+				if scores[seat] == best {
+					pot.Winners = append(pot.Winners, seat)
+				}
+			}
+		}
+		sort.Ints(pot.Winners)
+
+		if len(pot.Winners) == 0 {
+			continue
+		}
+
+		share := pot.Amount / len(pot.Winners)
+		odd := pot.Amount % len(pot.Winners)
+		for _, seat := range order {
+			if slices.Contains(pot.Winners, seat) {
+				won[seat] += share
+				if odd > 0 {
+					won[seat]++
+					odd--
+				}
+			}
+		}
+	}
+
+	return won
+}
@@ -0,0 +1,96 @@
+package table
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestBuildPots(t *testing.T) {
+	tests := []struct {
+		name          string
+		contributions []Contribution
+		want          []Pot
+	}{
+		{
+			name:          "one pot",
+			contributions: []Contribution{{Seat: 0, Committed: 100}, {Seat: 1, Committed: 100}, {Seat: 2, Committed: 20, Folded: true}},
+			want:          []Pot{{Amount: 220, Eligible: []int{0, 1}}},
+		},
+		{
+			name:          "short all-in makes a side pot",
+			contributions: []Contribution{{Seat: 0, Committed: 50}, {Seat: 1, Committed: 200}, {Seat: 2, Committed: 200}},
+			want:          []Pot{{Amount: 150, Eligible: []int{0, 1, 2}}, {Amount: 300, Eligible: []int{1, 2}}},
+		},
+		{
+			name:          "folded chips above the last all-in go to the previous pot",
+			contributions: []Contribution{{Seat: 0, Committed: 50}, {Seat: 1, Committed: 80}, {Seat: 2, Committed: 120, Folded: true}},
+			want:          []Pot{{Amount: 150, Eligible: []int{0, 1}}, {Amount: 100, Eligible: []int{1}}},
+		},
+		{
+			name:          "nothing committed",
+			contributions: []Contribution{{Seat: 0}, {Seat: 1}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := BuildPots(tt.contributions); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("BuildPots() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAward(t *testing.T) {
+	tests := []struct {
+		name        string
+		pots        []Pot
+		scores      map[int]int
+		order       []int
+		want        map[int]int
+		wantWinners [][]int
+	}{
+		{
+			name:        "everybody else folded",
+			pots:        []Pot{{Amount: 30, Eligible: []int{2}}},
+			order:       []int{0, 1, 2},
+			want:        map[int]int{2: 30},
+			wantWinners: [][]int{{2}},
+		},
+		{
+			name:        "lowest score wins",
+			pots:        []Pot{{Amount: 300, Eligible: []int{0, 1, 2}}},
+			scores:      map[int]int{0: 50, 1: 10, 2: 30},
+			order:       []int{0, 1, 2},
+			want:        map[int]int{1: 300},
+			wantWinners: [][]int{{1}},
+		},
+		{
+			name:        "odd chip goes to the first winner in order",
+			pots:        []Pot{{Amount: 101, Eligible: []int{0, 1, 2}}},
+			scores:      map[int]int{0: 10, 1: 30, 2: 10},
+			order:       []int{1, 2, 0},
+			want:        map[int]int{0: 50, 2: 51},
+			wantWinners: [][]int{{0, 2}},
+		},
+		{
+			name:        "side pot won by another seat",
+			pots:        []Pot{{Amount: 150, Eligible: []int{0, 1, 2}}, {Amount: 300, Eligible: []int{1, 2}}},
+			scores:      map[int]int{0: 10, 1: 30, 2: 20},
+			order:       []int{0, 1, 2},
+			want:        map[int]int{0: 150, 2: 300},
+			wantWinners: [][]int{{0}, {2}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Award(tt.pots, tt.scores, tt.order); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Award() = %v, want %v", got, tt.want)
+			}
+			for i, pot := range tt.pots {
+				if !reflect.DeepEqual(pot.Winners, tt.wantWinners[i]) {
+					t.Errorf("pot %d winners = %v, want %v", i, pot.Winners, tt.wantWinners[i])
+				}
+			}
+		})
+	}
+}
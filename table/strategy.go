@@ -0,0 +1,182 @@
+package table
+
+import (
+	"math"
+	"math/rand"
+
+	"github.com/YoungsoonLee/poker/internal/registry"
+	"github.com/YoungsoonLee/poker/poker"
+	"github.com/YoungsoonLee/poker/types"
+)
+
+// View is what a player can see when it is their turn to act.
+// Bet is the player's bet on the street, ToCall is the number of chips needed to call,
+// and MinRaise is the smallest total bet a raise can make.
+type View struct {
+	Seat     int
+	Hole     []types.Card
+	Board    []types.Card
+	Street   Street
+	Pot      int
+	Bet      int
+	ToCall   int
+	MinRaise int
+	Stack    int
+	BigBlind int
+	Players  int
+	Button   bool
+}
+
+// Strategy decides the actions of a player.
+// The table corrects invalid actions, so a strategy may e.g. raise more than its stack to go all-in.
+type Strategy interface {
+	Act(v View) Action
+}
+
+// StrategyFunc is an adapter to allow the use of ordinary functions as strategies.
+type StrategyFunc func(v View) Action
+
+// Act calls f(v).
+func (f StrategyFunc) Act(v View) Action {
+	return f(v)
+}
+
+// strategies maps the name of a built-in strategy to its constructor.
+var strategies = registry.Registry[Strategy]{
+	"calling": func(*rand.Rand) Strategy { return CallingStation{} },
+	"maniac":  func(*rand.Rand) Strategy { return Maniac{} },
+	"random":  func(rng *rand.Rand) Strategy { return &Random{rng: rng} },
+	"tag":     func(*rand.Rand) Strategy { return TightAggressive{} },
+}
+
+// StrategyNames returns the sorted names of the built-in strategies.
+func StrategyNames() []string {
+	return strategies.Names()
+}
+
+// NewStrategy creates a built-in strategy by name.
+// The random number generator is used by strategies that make random decisions.
+// If rng is nil, a generator seeded with the current time is used.
+func NewStrategy(name string, rng *rand.Rand) (Strategy, error) {
+	return strategies.New(name, rng)
+}
+
+// CallingStation checks or calls every bet and never raises.
+type CallingStation struct{}
+
+// Act implements Strategy.
+func (CallingStation) Act(v View) Action {
+	return Action{Type: Call}
+}
+
+// Maniac bets or raises the size of the pot on every decision.
+type Maniac struct{}
+
+// Act implements Strategy.
+func (Maniac) Act(v View) Action {
+	return Action{Type: Raise, Amount: potRaise(v)}
+}
+
+// Random chooses between folding, calling and raising at random.
+type Random struct {
+	rng *rand.Rand
+}
+
+// Act implements Strategy.
+func (r *Random) Act(v View) Action {
+	switch n := r.rng.Intn(10); {
+	case n < 2:
+		return Action{Type: Fold}
+	case n < 7:
+		return Action{Type: Call}
+	default:
+		amount := v.MinRaise
+		if limit := potRaise(v); limit > amount {
+			amount += r.rng.Intn(limit - amount + 1)
+		}
+		return Action{Type: Raise, Amount: amount}
+	}
+}
+
+// TightAggressive plays few starting hands and bets its strong made hands.
+// Preflop it uses the Chen formula, and after the flop the rank of its best hand.
+type TightAggressive struct{}
+
+// Act implements Strategy.
+func (TightAggressive) Act(v View) Action {
+	if v.Street == Preflop {
+		score := ChenScore(v.Hole)
+		switch {
+		case score >= 10:
+			return Action{Type: Raise, Amount: v.Bet + v.ToCall + 3*v.BigBlind}
+		case score >= 7 && v.ToCall <= 3*v.BigBlind:
+			return Action{Type: Call}
+		default:
+			return Action{Type: Fold}
+		}
+	}
+
+	best, err := poker.BestHand(v.Seat, append(append([]types.Card(nil), v.Hole...), v.Board...))
+	if err != nil {
+		return Action{Type: Call}
+	}
+
+	_, rankOrder := best.Evaluate()
+	switch {
+	case rankOrder <= 8:
+		return Action{Type: Raise, Amount: potRaise(v)}
+	case rankOrder == 9 && v.ToCall*2 <= v.Pot:
+		return Action{Type: Call}
+	default:
+		return Action{Type: Fold}
+	}
+}
+
+// potRaise returns the total bet of a pot-sized raise.
+func potRaise(v View) int {
+	return v.Bet + v.ToCall + v.Pot + v.ToCall
+}
+
+// ChenScore returns the Chen formula score of two hole cards, from -1 (worst) to 20 (a pair of aces).
+// It returns 0 if the hole does not have exactly two cards.
+func ChenScore(hole []types.Card) int {
+	if len(hole) != 2 {
+		return 0
+	}
+
+	high, low := types.RankMap[hole[0].Rank], types.RankMap[hole[1].Rank]
+	if low > high {
+		high, low = low, high
+	}
+
+	points := map[int]float64{14: 10, 13: 8, 12: 7, 11: 6}
+	score, ok := points[high]
+	if !ok {
+		score = float64(high) / 2
+	}
+
+	if high == low {
+		return int(math.Ceil(math.Max(score*2, 5)))
+	}
+
+	if hole[0].Suit == hole[1].Suit {
+		score += 2
+	}
+
+	switch gap := high - low - 1; {
+	case gap == 1:
+		score--
+	case gap == 2:
+		score -= 2
+	case gap == 3:
+		score -= 4
+	case gap >= 4:
+		score -= 5
+	}
+
+	if high-low <= 2 && high < 12 {
+		score++
+	}
+
+	return int(math.Ceil(score))
+}
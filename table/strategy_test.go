@@ -0,0 +1,65 @@
+package table
+
+import (
+	"testing"
+
+	"github.com/YoungsoonLee/poker/types"
+)
+
+func TestChenScore(t *testing.T) {
+	tests := []struct {
+		name string
+		hole []types.Card
+		want int
+	}{
+		{
+			name: "pair of aces",
+			hole: []types.Card{{Rank: "A", Suit: "S"}, {Rank: "A", Suit: "H"}},
+			want: 20,
+		},
+		{
+			name: "pair of twos",
+			hole: []types.Card{{Rank: "2", Suit: "S"}, {Rank: "2", Suit: "H"}},
+			want: 5,
+		},
+		{
+			name: "ace king suited",
+			hole: []types.Card{{Rank: "A", Suit: "S"}, {Rank: "K", Suit: "S"}},
+			want: 12,
+		},
+		{
+			name: "suited connectors",
+			hole: []types.Card{{Rank: "7", Suit: "H"}, {Rank: "8", Suit: "H"}},
+			want: 7,
+		},
+		{
+			name: "seven two offsuit",
+			hole: []types.Card{{Rank: "7", Suit: "H"}, {Rank: "2", Suit: "C"}},
+			want: -1,
+		},
+		{
+			name: "not a hole",
+			hole: []types.Card{{Rank: "7", Suit: "H"}},
+			want: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ChenScore(tt.hole); got != tt.want {
+				t.Errorf("ChenScore() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewStrategy(t *testing.T) {
+	for _, name := range StrategyNames() {
+		if _, err := NewStrategy(name, nil); err != nil {
+			t.Errorf("NewStrategy(%s) error = %v", name, err)
+		}
+	}
+
+	if _, err := NewStrategy("unknown", nil); err == nil {
+		t.Errorf("NewStrategy(unknown) error = nil, want error")
+	}
+}
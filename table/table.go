@@ -0,0 +1,610 @@
+package table
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/YoungsoonLee/poker/poker"
+	"github.com/YoungsoonLee/poker/types"
+)
+
+// holeCardCount is the number of cards dealt to each player in Texas Hold'em.
+const holeCardCount = 2
+
+// ErrNotEnoughPlayers is returned when fewer than two seats have chips to play a hand.
+var ErrNotEnoughPlayers = errors.New("not enough players with chips to play a hand")
+
+// Seat represents a player sitting at the table.
+// A seat with an empty stack sits out until it gets chips again.
+type Seat struct {
+	Name     string
+	Stack    int
+	Strategy Strategy
+}
+
+// Table deals hands of No-Limit Texas Hold'em between the seats.
+// Button is the index of the seat on the dealer button. It moves to the next seat with chips after every hand.
+// Observer, if set, is called as every hand is played.
+type Table struct {
+	Seats      []*Seat
+	Button     int
+	SmallBlind int
+	BigBlind   int
+	Ante       int
+	Observer   Observer
+
+	rng    *rand.Rand
+	handNo int
+}
+
+// New creates a new Table with the given seats and blinds.
+// If rng is nil, a generator seeded with the current time is used for shuffling.
+func New(seats []*Seat, smallBlind, bigBlind int, rng *rand.Rand) (*Table, error) {
+	if len(seats) < 2 {
+		return nil, fmt.Errorf("a table needs at least 2 seats, got %d", len(seats))
+	}
+
+	if smallBlind < 0 || bigBlind < 1 || smallBlind > bigBlind {
+		return nil, fmt.Errorf("invalid blinds: %d/%d", smallBlind, bigBlind)
+	}
+
+	for i, s := range seats {
+		if s.Strategy == nil {
+			return nil, fmt.Errorf("seat %d (%s) has no strategy", i, s.Name)
+		}
+	}
+
+	if rng == nil {
+		rng = rand.New(rand.NewSource(time.Now().UnixNano()))
+	}
+
+	return &Table{
+		Seats:      seats,
+		SmallBlind: smallBlind,
+		BigBlind:   bigBlind,
+		rng:        rng,
+	}, nil
+}
+
+// PlayerResult is the outcome of a hand for one seat.
+type PlayerResult struct {
+	Seat       int
+	Name       string
+	StartStack int
+	EndStack   int
+	Hole       []types.Card
+	Folded     bool
+}
+
+// Net returns the number of chips the player won (positive) or lost (negative) in the hand.
+func (p PlayerResult) Net() int {
+	return p.EndStack - p.StartStack
+}
+
+// ShowdownResult is the best hand shown by a seat at showdown.
+type ShowdownResult struct {
+	Seat      int
+	Hand      poker.Hand
+	Rank      string
+	RankOrder int
+	Score     int
+}
+
+// Result records everything that happened in a hand.
+// Players only contains the seats that were dealt in.
+type Result struct {
+	HandNo     int
+	Button     int
+	SmallBlind int
+	BigBlind   int
+	Ante       int
+	Players    []PlayerResult
+	Board      []types.Card
+	Actions    []ActionLog
+	Showdown   []ShowdownResult
+	Pots       []Pot
+}
+
+// Player returns the result of the given seat, and false if the seat was not dealt in.
+func (r *Result) Player(seat int) (PlayerResult, bool) {
+	for _, p := range r.Players {
+		if p.Seat == seat {
+			return p, true
+		}
+	}
+
+	return PlayerResult{}, false
+}
+
+// ActiveSeats returns the number of seats that have chips.
+func (t *Table) ActiveSeats() int {
+	n := 0
+	for _, s := range t.Seats {
+		if s.Stack > 0 {
+			n++
+		}
+	}
+
+	return n
+}
+
+// Add seats a new player at the end of the table, e.g. a player moved from another table.
+func (t *Table) Add(s *Seat) {
+	t.Seats = append(t.Seats, s)
+}
+
+// Remove removes the seat at the given index from the table and returns it.
+// The button stays with the same player, or moves to the next seat if the button is removed.
+func (t *Table) Remove(seat int) (*Seat, error) {
+	if seat < 0 || seat >= len(t.Seats) {
+		return nil, fmt.Errorf("invalid seat: %d", seat)
+	}
+
+	s := t.Seats[seat]
+	t.Seats = append(t.Seats[:seat], t.Seats[seat+1:]...)
+
+	if seat < t.Button {
+		t.Button--
+	}
+	if t.Button >= len(t.Seats) {
+		t.Button = 0
+	}
+
+	return s, nil
+}
+
+// SetLevel sets the blinds and ante for the next hands, e.g. when a tournament level changes.
+func (t *Table) SetLevel(smallBlind, bigBlind, ante int) {
+	t.SmallBlind = smallBlind
+	t.BigBlind = bigBlind
+	t.Ante = ante
+}
+
+// HandNo returns the number of hands played at the table.
+func (t *Table) HandNo() int {
+	return t.handNo
+}
+
+// PlayHand deals and plays one hand, and updates the stacks of the seats.
+// It returns ErrNotEnoughPlayers if fewer than two seats have chips.
+func (t *Table) PlayHand() (*Result, error) {
+	if t.ActiveSeats() < 2 {
+		return nil, ErrNotEnoughPlayers
+	}
+
+	if t.BigBlind < 1 || t.SmallBlind < 0 || t.SmallBlind > t.BigBlind || t.Ante < 0 {
+		return nil, fmt.Errorf("invalid blinds: %d/%d ante %d", t.SmallBlind, t.BigBlind, t.Ante)
+	}
+
+	t.Button = t.nextActiveSeat(t.Button - 1)
+	t.handNo++
+
+	h := newHand(t)
+	h.play()
+
+	t.Button = t.nextActiveSeat(t.Button)
+
+	return h.result, nil
+}
+
+// nextActiveSeat returns the index of the first seat with chips after the given seat.
+func (t *Table) nextActiveSeat(seat int) int {
+	n := len(t.Seats)
+	for i := 1; i <= n; i++ {
+		next := ((seat+i)%n + n) % n
+		if t.Seats[next].Stack > 0 {
+			return next
+		}
+	}
+
+	return seat
+}
+
+// player is the state of a seat during a hand.
+type player struct {
+	seat      int
+	s         *Seat
+	hole      []types.Card
+	bet       int
+	committed int
+	folded    bool
+	allIn     bool
+	// acted is set once the player acted on the street, and raisesSeen is the number of full raises of the street at the time
+	acted      bool
+	raisesSeen int
+}
+
+// canAct reports whether the player can still make decisions in the hand.
+func (p *player) canAct() bool {
+	return !p.folded && !p.allIn
+}
+
+// hand is the state of a hand being played.
+// The players are in seat order, and button is the index of the button in players.
+type hand struct {
+	t            *Table
+	deck         *poker.Deck
+	players      []*player
+	button       int
+	board        []types.Card
+	street       Street
+	currentBet   int
+	minRaiseSize int
+	fullRaises   int
+	result       *Result
+}
+
+func newHand(t *Table) *hand {
+	h := &hand{
+		t:    t,
+		deck: poker.NewDeck(t.rng),
+		result: &Result{
+			HandNo:     t.handNo,
+			Button:     t.Button,
+			SmallBlind: t.SmallBlind,
+			BigBlind:   t.BigBlind,
+			Ante:       t.Ante,
+		},
+	}
+
+	for i, s := range t.Seats {
+		if s.Stack <= 0 {
+			continue
+		}
+		if i == t.Button {
+			h.button = len(h.players)
+		}
+		h.players = append(h.players, &player{seat: i, s: s})
+		h.result.Players = append(h.result.Players, PlayerResult{Seat: i, Name: s.Name, StartStack: s.Stack})
+	}
+
+	return h
+}
+
+// next returns the index of the player after i in seat order.
+func (h *hand) next(i int) int {
+	return (i + 1) % len(h.players)
+}
+
+// inHand returns the number of players who have not folded.
+func (h *hand) inHand() int {
+	n := 0
+	for _, p := range h.players {
+		if !p.folded {
+			n++
+		}
+	}
+
+	return n
+}
+
+// ableToAct returns the number of players who have not folded and are not all-in.
+func (h *hand) ableToAct() int {
+	n := 0
+	for _, p := range h.players {
+		if p.canAct() {
+			n++
+		}
+	}
+
+	return n
+}
+
+func (h *hand) play() {
+	h.deck.Shuffle()
+
+	// deal one card at a time starting left of the button
+	for c := 0; c < holeCardCount; c++ {
+		for i := h.next(h.button); ; i = h.next(i) {
+			card, _ := h.deck.Deal(1)
+			h.players[i].hole = append(h.players[i].hole, card...)
+			if i == h.button {
+				break
+			}
+		}
+	}
+
+	h.observe(nil)
+
+	h.postBlinds()
+
+	first := h.next(h.bigBlindIndex())
+	h.bettingRound(first)
+
+	for _, street := range []Street{Flop, Turn, River} {
+		if h.inHand() < 2 {
+			break
+		}
+
+		h.street = street
+		h.collectBets()
+
+		n := 1
+		if street == Flop {
+			n = 3
+		}
+		cards, _ := h.deck.Deal(n)
+		h.board = append(h.board, cards...)
+		h.observe(nil)
+
+		h.bettingRound(h.next(h.button))
+	}
+
+	h.collectBets()
+	h.street = Showdown
+	h.awardPots()
+	h.finish()
+}
+
+// smallBlindIndex returns the index of the player posting the small blind.
+// Heads-up the button posts the small blind.
+func (h *hand) smallBlindIndex() int {
+	if len(h.players) == 2 {
+		return h.button
+	}
+
+	return h.next(h.button)
+}
+
+// bigBlindIndex returns the index of the player posting the big blind.
+func (h *hand) bigBlindIndex() int {
+	return h.next(h.smallBlindIndex())
+}
+
+func (h *hand) postBlinds() {
+	if h.t.Ante > 0 {
+		for i := h.next(h.button); ; i = h.next(i) {
+			h.put(i, PostAnte, h.t.Ante)
+			if i == h.button {
+				break
+			}
+		}
+	}
+
+	h.put(h.smallBlindIndex(), PostSmallBlind, h.t.SmallBlind)
+	h.put(h.bigBlindIndex(), PostBigBlind, h.t.BigBlind)
+
+	h.currentBet = h.t.BigBlind
+	h.minRaiseSize = h.t.BigBlind
+}
+
+// put moves up to amount chips from the player's stack into the pot and records the action.
+// Antes are dead money and do not count as a bet on the street.
+func (h *hand) put(i int, actionType ActionType, amount int) {
+	p := h.players[i]
+	if amount > p.s.Stack {
+		amount = p.s.Stack
+	}
+
+	p.s.Stack -= amount
+	if actionType != PostAnte {
+		p.bet += amount
+	}
+	p.committed += amount
+	if p.s.Stack == 0 {
+		p.allIn = true
+	}
+
+	h.result.Actions = append(h.result.Actions, ActionLog{
+		Seat:   p.seat,
+		Street: h.street,
+		Type:   actionType,
+		Amount: amount,
+		Total:  p.bet,
+		AllIn:  p.allIn,
+	})
+	h.observe(&h.result.Actions[len(h.result.Actions)-1])
+}
+
+// bettingRound asks players to act starting from first until every player has matched the current bet or folded.
+func (h *hand) bettingRound(first int) {
+	needsToAct := make(map[int]bool)
+	for i, p := range h.players {
+		if p.canAct() {
+			needsToAct[i] = true
+		}
+	}
+
+	for i := first; len(needsToAct) > 0; i = h.next(i) {
+		if h.inHand() < 2 {
+			return
+		}
+
+		if !needsToAct[i] {
+			continue
+		}
+		delete(needsToAct, i)
+
+		p := h.players[i]
+		// the last player able to act does not need to act unless they are facing a bet
+		if h.ableToAct() == 1 && p.bet >= h.currentBet {
+			continue
+		}
+
+		// a full raise reopens the betting, while an incomplete all-in raise only asks the others to call the difference
+		raised := h.act(i)
+		for j, other := range h.players {
+			if j != i && other.canAct() && (raised || other.bet < h.currentBet) {
+				needsToAct[j] = true
+			}
+		}
+	}
+}
+
+// act asks the strategy of the player for an action and applies it.
+// Invalid actions are corrected to the closest valid action. A player who already acted on the street may only raise again
+// after a full raise, so facing an incomplete all-in raise a raise is corrected to a call.
+// It returns true if the player made a full bet or raise, which reopens the betting.
+func (h *hand) act(i int) bool {
+	p := h.players[i]
+	toCall := h.currentBet - p.bet
+	canRaise := !p.acted || h.fullRaises > p.raisesSeen
+	defer func() { p.acted, p.raisesSeen = true, h.fullRaises }()
+
+	action := p.s.Strategy.Act(h.view(i))
+
+	switch action.Type {
+	case Fold:
+		if toCall == 0 {
+			h.put(i, Check, 0)
+			return false
+		}
+		p.folded = true
+		h.put(i, Fold, 0)
+		return false
+	case Check, Call:
+		if toCall == 0 {
+			h.put(i, Check, 0)
+			return false
+		}
+		if action.Type == Check {
+			p.folded = true
+			h.put(i, Fold, 0)
+			return false
+		}
+		h.put(i, Call, toCall)
+		return false
+	case Bet, Raise:
+		raiseTo := action.Amount
+		if minTo := h.currentBet + h.minRaiseSize; raiseTo < minTo {
+			raiseTo = minTo
+		}
+		if maxTo := p.bet + p.s.Stack; raiseTo > maxTo {
+			raiseTo = maxTo
+		}
+
+		if raiseTo <= h.currentBet || !canRaise {
+			if toCall == 0 {
+				h.put(i, Check, 0)
+			} else {
+				h.put(i, Call, toCall)
+			}
+			return false
+		}
+
+		actionType := Raise
+		if h.currentBet == 0 {
+			actionType = Bet
+		}
+
+		// an all-in for less than a full raise does not change the size of the next raise
+		full := raiseTo-h.currentBet >= h.minRaiseSize
+		if full {
+			h.minRaiseSize = raiseTo - h.currentBet
+			h.fullRaises++
+		}
+		h.currentBet = raiseTo
+		h.put(i, actionType, raiseTo-p.bet)
+		return full
+	default:
+		if toCall == 0 {
+			h.put(i, Check, 0)
+			return false
+		}
+		p.folded = true
+		h.put(i, Fold, 0)
+		return false
+	}
+}
+
+// view returns what the player at index i can see.
+func (h *hand) view(i int) View {
+	p := h.players[i]
+
+	pot := 0
+	for _, other := range h.players {
+		pot += other.committed
+	}
+
+	return View{
+		Seat:     p.seat,
+		Hole:     append([]types.Card(nil), p.hole...),
+		Board:    append([]types.Card(nil), h.board...),
+		Street:   h.street,
+		Pot:      pot,
+		Bet:      p.bet,
+		ToCall:   h.currentBet - p.bet,
+		MinRaise: h.currentBet + h.minRaiseSize,
+		Stack:    p.s.Stack,
+		BigBlind: h.t.BigBlind,
+		Players:  h.inHand(),
+		Button:   i == h.button,
+	}
+}
+
+// collectBets resets the bets of the street before the next street is dealt.
+func (h *hand) collectBets() {
+	for _, p := range h.players {
+		p.bet = 0
+		p.acted = false
+	}
+	h.currentBet = 0
+	h.minRaiseSize = h.t.BigBlind
+	h.fullRaises = 0
+}
+
+// awardPots decides the winners of each pot by the scores of the best hands and pays them.
+func (h *hand) awardPots() {
+	pots := BuildPots(h.contributions())
+
+	scores := make(map[int]int)
+	if h.inHand() > 1 {
+		for i := h.next(h.button); ; i = h.next(i) {
+			p := h.players[i]
+			if !p.folded {
+				cards := append(append([]types.Card(nil), p.hole...), h.board...)
+				hand, _ := poker.BestHand(p.seat, cards)
+				rank, rankOrder := hand.Evaluate()
+				scores[p.seat] = hand.Score()
+				h.result.Showdown = append(h.result.Showdown, ShowdownResult{
+					Seat:      p.seat,
+					Hand:      hand,
+					Rank:      rank,
+					RankOrder: rankOrder,
+					Score:     scores[p.seat],
+				})
+			}
+			if i == h.button {
+				break
+			}
+		}
+	}
+
+	// odd chips go to the winners closest to the left of the button
+	order := make([]int, 0, len(h.players))
+	for i := h.next(h.button); ; i = h.next(i) {
+		order = append(order, h.players[i].seat)
+		if i == h.button {
+			break
+		}
+	}
+
+	won := Award(pots, scores, order)
+	for _, p := range h.players {
+		p.s.Stack += won[p.seat]
+	}
+
+	h.result.Pots = pots
+}
+
+// contributions returns the chips every player committed to the pot, in seat order.
+func (h *hand) contributions() []Contribution {
+	contributions := make([]Contribution, len(h.players))
+	for i, p := range h.players {
+		contributions[i] = Contribution{Seat: p.seat, Committed: p.committed, Folded: p.folded}
+	}
+
+	return contributions
+}
+
+// finish fills in the final state of the players in the result.
+func (h *hand) finish() {
+	h.result.Board = h.board
+	for k, p := range h.players {
+		h.result.Players[k].EndStack = p.s.Stack
+		h.result.Players[k].Hole = p.hole
+		h.result.Players[k].Folded = p.folded
+	}
+}
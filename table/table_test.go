@@ -0,0 +1,258 @@
+package table
+
+import (
+	"errors"
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+// newTestTable creates a table with one seat per stack, all using the given strategy.
+func newTestTable(t *testing.T, strategy Strategy, stacks ...int) *Table {
+	t.Helper()
+
+	seats := make([]*Seat, len(stacks))
+	for i, stack := range stacks {
+		seats[i] = &Seat{Name: string(rune('A' + i)), Stack: stack, Strategy: strategy}
+	}
+
+	tb, err := New(seats, 5, 10, rand.New(rand.NewSource(1)))
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	return tb
+}
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name       string
+		seats      []*Seat
+		smallBlind int
+		bigBlind   int
+		wantErr    bool
+	}{
+		{
+			name:       "valid table",
+			seats:      []*Seat{{Name: "A", Stack: 100, Strategy: CallingStation{}}, {Name: "B", Stack: 100, Strategy: CallingStation{}}},
+			smallBlind: 5,
+			bigBlind:   10,
+			wantErr:    false,
+		},
+		{
+			name:       "one seat",
+			seats:      []*Seat{{Name: "A", Stack: 100, Strategy: CallingStation{}}},
+			smallBlind: 5,
+			bigBlind:   10,
+			wantErr:    true,
+		},
+		{
+			name:       "small blind bigger than big blind",
+			seats:      []*Seat{{Name: "A", Stack: 100, Strategy: CallingStation{}}, {Name: "B", Stack: 100, Strategy: CallingStation{}}},
+			smallBlind: 20,
+			bigBlind:   10,
+			wantErr:    true,
+		},
+		{
+			name:       "seat without strategy",
+			seats:      []*Seat{{Name: "A", Stack: 100, Strategy: CallingStation{}}, {Name: "B", Stack: 100}},
+			smallBlind: 5,
+			bigBlind:   10,
+			wantErr:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := New(tt.seats, tt.smallBlind, tt.bigBlind, nil)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("New() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestTable_PlayHand_FoldToBigBlind(t *testing.T) {
+	tb := newTestTable(t, StrategyFunc(func(v View) Action { return Action{Type: Fold} }), 100, 100)
+
+	result, err := tb.PlayHand()
+	if err != nil {
+		t.Fatalf("Table.PlayHand() error = %v", err)
+	}
+
+	// heads-up the button posts the small blind and acts first
+	if tb.Seats[0].Stack != 95 || tb.Seats[1].Stack != 105 {
+		t.Errorf("Table.PlayHand() stacks = %v/%v, want %v/%v", tb.Seats[0].Stack, tb.Seats[1].Stack, 95, 105)
+	}
+	if len(result.Showdown) != 0 {
+		t.Errorf("Table.PlayHand() showdown = %v, want none", result.Showdown)
+	}
+	if len(result.Board) != 0 {
+		t.Errorf("Table.PlayHand() board = %v, want none", result.Board)
+	}
+	if tb.Button != 1 {
+		t.Errorf("Table.Button = %v, want %v", tb.Button, 1)
+	}
+}
+
+func TestTable_PlayHand_SidePots(t *testing.T) {
+	tb := newTestTable(t, Maniac{}, 50, 100, 100)
+
+	result, err := tb.PlayHand()
+	if err != nil {
+		t.Fatalf("Table.PlayHand() error = %v", err)
+	}
+
+	var amounts [][]int
+	for _, pot := range result.Pots {
+		amounts = append(amounts, append([]int{pot.Amount}, pot.Eligible...))
+	}
+
+	want := [][]int{{150, 0, 1, 2}, {100, 1, 2}}
+	if !reflect.DeepEqual(amounts, want) {
+		t.Errorf("Table.PlayHand() pots = %v, want %v", amounts, want)
+	}
+	if len(result.Board) != 5 {
+		t.Errorf("Table.PlayHand() board = %v cards, want %v", len(result.Board), 5)
+	}
+	if len(result.Showdown) != 3 {
+		t.Errorf("Table.PlayHand() showdown = %v hands, want %v", len(result.Showdown), 3)
+	}
+}
+
+func TestTable_PlayHand_IncompleteRaise(t *testing.T) {
+	// A raises to 30 and B calls, then C in the big blind raises all-in to 35, which is less than a full raise,
+	// so A and B may only call the 5 more and A's raise is corrected to a call
+	strategy := StrategyFunc(func(v View) Action {
+		switch v.Seat {
+		case 0:
+			return Action{Type: Raise, Amount: 30}
+		case 1:
+			return Action{Type: Call}
+		default:
+			return Action{Type: Raise, Amount: 100}
+		}
+	})
+	tb := newTestTable(t, strategy, 1000, 1000, 35)
+
+	result, err := tb.PlayHand()
+	if err != nil {
+		t.Fatalf("Table.PlayHand() error = %v", err)
+	}
+
+	var got []ActionLog
+	for _, a := range result.Actions {
+		if a.Street == Preflop && a.Type != PostSmallBlind && a.Type != PostBigBlind {
+			got = append(got, a)
+		}
+	}
+
+	want := []ActionLog{
+		{Seat: 0, Street: Preflop, Type: Raise, Amount: 30, Total: 30},
+		{Seat: 1, Street: Preflop, Type: Call, Amount: 25, Total: 30},
+		{Seat: 2, Street: Preflop, Type: Raise, Amount: 25, Total: 35, AllIn: true},
+		{Seat: 0, Street: Preflop, Type: Call, Amount: 5, Total: 35},
+		{Seat: 1, Street: Preflop, Type: Call, Amount: 5, Total: 35},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Table.PlayHand() preflop actions = %+v, want %+v", got, want)
+	}
+}
+
+func TestTable_PlayHand_ChipsConserved(t *testing.T) {
+	rng := rand.New(rand.NewSource(2))
+	tb := newTestTable(t, &Random{rng: rng}, 1000, 1000, 1000, 1000, 1000)
+	tb.Ante = 1
+
+	for i := 0; i < 500; i++ {
+		result, err := tb.PlayHand()
+		if errors.Is(err, ErrNotEnoughPlayers) {
+			break
+		}
+		if err != nil {
+			t.Fatalf("Table.PlayHand() error = %v", err)
+		}
+
+		net := 0
+		for _, p := range result.Players {
+			net += p.Net()
+		}
+		if net != 0 {
+			t.Fatalf("hand %d: nets sum to %v, want 0", result.HandNo, net)
+		}
+
+		total := 0
+		for _, s := range tb.Seats {
+			if s.Stack < 0 {
+				t.Fatalf("hand %d: seat %s has negative stack %v", result.HandNo, s.Name, s.Stack)
+			}
+			total += s.Stack
+		}
+		if total != 5000 {
+			t.Fatalf("hand %d: total chips = %v, want %v", result.HandNo, total, 5000)
+		}
+	}
+}
+
+func TestTable_PlayHand_NotEnoughPlayers(t *testing.T) {
+	tb := newTestTable(t, CallingStation{}, 100, 0)
+
+	if _, err := tb.PlayHand(); !errors.Is(err, ErrNotEnoughPlayers) {
+		t.Errorf("Table.PlayHand() error = %v, want %v", err, ErrNotEnoughPlayers)
+	}
+}
+
+func TestTable_Remove(t *testing.T) {
+	tests := []struct {
+		name       string
+		button     int
+		seat       int
+		wantButton string
+		wantErr    bool
+	}{
+		{
+			name:       "remove seat before the button",
+			button:     2,
+			seat:       0,
+			wantButton: "C",
+			wantErr:    false,
+		},
+		{
+			name:       "remove seat after the button",
+			button:     1,
+			seat:       2,
+			wantButton: "B",
+			wantErr:    false,
+		},
+		{
+			name:       "remove the button on the last seat",
+			button:     2,
+			seat:       2,
+			wantButton: "A",
+			wantErr:    false,
+		},
+		{
+			name:    "invalid seat",
+			button:  0,
+			seat:    3,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tb := newTestTable(t, CallingStation{}, 100, 100, 100)
+			tb.Button = tt.button
+
+			_, err := tb.Remove(tt.seat)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Table.Remove() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			if got := tb.Seats[tb.Button].Name; got != tt.wantButton {
+				t.Errorf("Table.Remove() button = %v, want %v", got, tt.wantButton)
+			}
+		})
+	}
+}
@@ -0,0 +1,232 @@
+// Package tournament runs sit-and-go and multi-table tournaments on the table engine.
+// A Structure describes the blind and ante schedule, the buy-in, the bounty and the payouts,
+// and can be loaded from a YAML or JSON file.
+package tournament
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// LevelByHands changes the level after a number of hands.
+	LevelByHands = "hands"
+	// LevelByTime changes the level after a number of minutes.
+	LevelByTime = "time"
+)
+
+// Level is one step of the blind schedule.
+// Hands or Minutes is the length of the level, depending on the LevelBy of the structure.
+// The last level never ends.
+type Level struct {
+	SmallBlind int     `json:"small_blind" yaml:"small_blind"`
+	BigBlind   int     `json:"big_blind" yaml:"big_blind"`
+	Ante       int     `json:"ante,omitempty" yaml:"ante,omitempty"`
+	Hands      int     `json:"hands,omitempty" yaml:"hands,omitempty"`
+	Minutes    float64 `json:"minutes,omitempty" yaml:"minutes,omitempty"`
+}
+
+// Payout is a payout table used when a tournament has at least Entrants players.
+// Percent is the share of the prize pool paid to each place, starting with the winner.
+type Payout struct {
+	Entrants int       `json:"entrants" yaml:"entrants"`
+	Percent  []float64 `json:"percent" yaml:"percent"`
+}
+
+// Structure describes the rules of a tournament.
+// Every player pays BuyIn into the prize pool and Bounty for the bounty on their head,
+// which is paid to the player who knocks them out.
+type Structure struct {
+	Name          string   `json:"name" yaml:"name"`
+	StartingStack int      `json:"starting_stack" yaml:"starting_stack"`
+	BuyIn         int      `json:"buy_in" yaml:"buy_in"`
+	Bounty        int      `json:"bounty,omitempty" yaml:"bounty,omitempty"`
+	LevelBy       string   `json:"level_by" yaml:"level_by"`
+	Levels        []Level  `json:"levels" yaml:"levels"`
+	Payouts       []Payout `json:"payouts" yaml:"payouts"`
+}
+
+// DefaultStructure returns a structure where the blinds double every levelHands hands and the winner takes all.
+func DefaultStructure(startingStack, smallBlind, bigBlind, levelHands int) Structure {
+	s := Structure{
+		Name:          "Default",
+		StartingStack: startingStack,
+		BuyIn:         100,
+		LevelBy:       LevelByHands,
+		Payouts:       []Payout{{Entrants: 2, Percent: []float64{100}}},
+	}
+
+	if levelHands < 1 {
+		s.Levels = []Level{{SmallBlind: smallBlind, BigBlind: bigBlind}}
+		return s
+	}
+
+	// double the blinds until the big blind is bigger than the starting stack
+	for bb := bigBlind; ; bb *= 2 {
+		s.Levels = append(s.Levels, Level{SmallBlind: smallBlind * bb / bigBlind, BigBlind: bb, Hands: levelHands})
+		if bb > startingStack {
+			break
+		}
+	}
+
+	return s
+}
+
+// LoadStructure reads a structure from a YAML (.yaml, .yml) or JSON (.json) file and validates it.
+func LoadStructure(path string) (Structure, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Structure{}, err
+	}
+
+	var s Structure
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, &s)
+	case ".json":
+		err = json.Unmarshal(data, &s)
+	default:
+		return Structure{}, fmt.Errorf("unsupported structure file: %s. file should be .yaml, .yml or .json", path)
+	}
+	if err != nil {
+		return Structure{}, fmt.Errorf("invalid structure file %s: %w", path, err)
+	}
+
+	if err := s.Validate(); err != nil {
+		return Structure{}, fmt.Errorf("invalid structure file %s: %w", path, err)
+	}
+
+	return s, nil
+}
+
+// Validate checks the structure and returns an error describing the first problem found.
+func (s Structure) Validate() error {
+	if s.StartingStack < 1 {
+		return fmt.Errorf("invalid starting stack: %d", s.StartingStack)
+	}
+
+	if s.BuyIn < 0 || s.Bounty < 0 {
+		return fmt.Errorf("invalid buy-in %d or bounty %d", s.BuyIn, s.Bounty)
+	}
+
+	if s.LevelBy != LevelByHands && s.LevelBy != LevelByTime {
+		return fmt.Errorf("invalid level_by: %s. level_by should be %s or %s", s.LevelBy, LevelByHands, LevelByTime)
+	}
+
+	if len(s.Levels) == 0 {
+		return fmt.Errorf("structure needs at least one level")
+	}
+
+	for i, l := range s.Levels {
+		if l.SmallBlind < 0 || l.BigBlind < 1 || l.SmallBlind > l.BigBlind || l.Ante < 0 {
+			return fmt.Errorf("level %d: invalid blinds %d/%d ante %d", i+1, l.SmallBlind, l.BigBlind, l.Ante)
+		}
+
+		last := i == len(s.Levels)-1
+		if s.LevelBy == LevelByHands && l.Hands < 1 && !last {
+			return fmt.Errorf("level %d: invalid number of hands: %d", i+1, l.Hands)
+		}
+		if s.LevelBy == LevelByTime && l.Minutes <= 0 && !last {
+			return fmt.Errorf("level %d: invalid number of minutes: %v", i+1, l.Minutes)
+		}
+	}
+
+	if len(s.Payouts) == 0 {
+		return fmt.Errorf("structure needs at least one payout table")
+	}
+
+	for _, p := range s.Payouts {
+		if len(p.Percent) == 0 || len(p.Percent) > p.Entrants {
+			return fmt.Errorf("payout for %d entrants: invalid number of places: %d", p.Entrants, len(p.Percent))
+		}
+
+		total := 0.0
+		for _, percent := range p.Percent {
+			if percent < 0 {
+				return fmt.Errorf("payout for %d entrants: invalid percent: %v", p.Entrants, percent)
+			}
+			total += percent
+		}
+		if math.Abs(total-100) > 0.01 {
+			return fmt.Errorf("payout for %d entrants: percents add up to %v, want 100", p.Entrants, total)
+		}
+	}
+
+	return nil
+}
+
+// LevelAt returns the index of the level after the given number of hands and elapsed time.
+// Only the one matching the LevelBy of the structure is used.
+func (s Structure) LevelAt(hands int, elapsed time.Duration) int {
+	for i, l := range s.Levels[:len(s.Levels)-1] {
+		if s.LevelBy == LevelByTime {
+			length := time.Duration(l.Minutes * float64(time.Minute))
+			if elapsed < length {
+				return i
+			}
+			elapsed -= length
+			continue
+		}
+
+		if hands < l.Hands {
+			return i
+		}
+		hands -= l.Hands
+	}
+
+	return len(s.Levels) - 1
+}
+
+// PrizePool returns the prize pool for the given number of entrants.
+func (s Structure) PrizePool(entrants int) int {
+	return s.BuyIn * entrants
+}
+
+// Prizes returns the prize of each place for the given number of entrants, starting with the winner.
+// It uses the payout table with the most entrants that is not bigger than the number of entrants,
+// or the smallest payout table if there is none. Money lost to rounding goes to the winner.
+func (s Structure) Prizes(entrants int) []int {
+	if len(s.Payouts) == 0 || entrants < 1 {
+		return nil
+	}
+
+	var payout *Payout
+	for i := range s.Payouts {
+		p := &s.Payouts[i]
+		if p.Entrants <= entrants && (payout == nil || p.Entrants > payout.Entrants) {
+			payout = p
+		}
+	}
+
+	if payout == nil {
+		payout = &s.Payouts[0]
+		for i := range s.Payouts {
+			if s.Payouts[i].Entrants < payout.Entrants {
+				payout = &s.Payouts[i]
+			}
+		}
+	}
+
+	percents := payout.Percent
+	if len(percents) > entrants {
+		percents = percents[:entrants]
+	}
+
+	pool := s.PrizePool(entrants)
+	prizes := make([]int, len(percents))
+	paid := 0
+	for i, percent := range percents {
+		prizes[i] = int(float64(pool) * percent / 100)
+		paid += prizes[i]
+	}
+	prizes[0] += pool - paid
+
+	return prizes
+}
@@ -0,0 +1,176 @@
+package tournament
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestLoadStructure(t *testing.T) {
+	tests := []struct {
+		name       string
+		path       string
+		wantLevels int
+		wantBounty int
+		wantErr    bool
+	}{
+		{
+			name:       "yaml structure",
+			path:       "testdata/turbo.yaml",
+			wantLevels: 5,
+			wantBounty: 0,
+			wantErr:    false,
+		},
+		{
+			name:       "json structure",
+			path:       "testdata/bounty.json",
+			wantLevels: 3,
+			wantBounty: 20,
+			wantErr:    false,
+		},
+		{
+			name:    "missing file",
+			path:    "testdata/missing.yaml",
+			wantErr: true,
+		},
+		{
+			name:    "unsupported file",
+			path:    "structure.go",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := LoadStructure(tt.path)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("LoadStructure() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if len(got.Levels) != tt.wantLevels || got.Bounty != tt.wantBounty {
+				t.Errorf("LoadStructure() = %v levels, bounty %v, want %v levels, bounty %v", len(got.Levels), got.Bounty, tt.wantLevels, tt.wantBounty)
+			}
+		})
+	}
+}
+
+func TestStructure_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		modify  func(s *Structure)
+		wantErr bool
+	}{
+		{
+			name:    "valid structure",
+			modify:  func(s *Structure) {},
+			wantErr: false,
+		},
+		{
+			name:    "no starting stack",
+			modify:  func(s *Structure) { s.StartingStack = 0 },
+			wantErr: true,
+		},
+		{
+			name:    "unknown level_by",
+			modify:  func(s *Structure) { s.LevelBy = "orbits" },
+			wantErr: true,
+		},
+		{
+			name:    "level without length",
+			modify:  func(s *Structure) { s.Levels[0].Hands = 0 },
+			wantErr: true,
+		},
+		{
+			name:    "small blind bigger than big blind",
+			modify:  func(s *Structure) { s.Levels[1].SmallBlind = 100 },
+			wantErr: true,
+		},
+		{
+			name:    "percents do not add up to 100",
+			modify:  func(s *Structure) { s.Payouts[1].Percent = []float64{60, 30} },
+			wantErr: true,
+		},
+		{
+			name:    "more places than entrants",
+			modify:  func(s *Structure) { s.Payouts[0].Percent = []float64{50, 30, 20} },
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := LoadStructure("testdata/turbo.yaml")
+			if err != nil {
+				t.Fatalf("LoadStructure() error = %v", err)
+			}
+			tt.modify(&s)
+			if err := s.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Structure.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestStructure_LevelAt(t *testing.T) {
+	byHands := Structure{LevelBy: LevelByHands, Levels: []Level{{Hands: 10}, {Hands: 5}, {}}}
+	byTime := Structure{LevelBy: LevelByTime, Levels: []Level{{Minutes: 10}, {Minutes: 0.5}, {}}}
+
+	tests := []struct {
+		name      string
+		structure Structure
+		hands     int
+		elapsed   time.Duration
+		want      int
+	}{
+		{name: "first hand", structure: byHands, hands: 0, want: 0},
+		{name: "last hand of first level", structure: byHands, hands: 9, want: 0},
+		{name: "second level", structure: byHands, hands: 10, want: 1},
+		{name: "last level never ends", structure: byHands, hands: 1000, want: 2},
+		{name: "time is ignored by hands", structure: byHands, elapsed: time.Hour, want: 0},
+		{name: "first minutes", structure: byTime, elapsed: 9 * time.Minute, want: 0},
+		{name: "second level by time", structure: byTime, elapsed: 10*time.Minute + 10*time.Second, want: 1},
+		{name: "last level by time", structure: byTime, elapsed: 11 * time.Minute, want: 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.structure.LevelAt(tt.hands, tt.elapsed); got != tt.want {
+				t.Errorf("Structure.LevelAt() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStructure_Prizes(t *testing.T) {
+	s, err := LoadStructure("testdata/turbo.yaml")
+	if err != nil {
+		t.Fatalf("LoadStructure() error = %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		entrants int
+		want     []int
+	}{
+		{name: "heads-up", entrants: 2, want: []int{200}},
+		{name: "six players", entrants: 6, want: []int{390, 210}},
+		{name: "nine players", entrants: 9, want: []int{450, 270, 180}},
+		{name: "one player uses the smallest table", entrants: 1, want: []int{100}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := s.Prizes(tt.entrants); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Structure.Prizes() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDefaultStructure(t *testing.T) {
+	s := DefaultStructure(1000, 5, 10, 50)
+	if err := s.Validate(); err != nil {
+		t.Fatalf("DefaultStructure().Validate() error = %v", err)
+	}
+
+	last := s.Levels[len(s.Levels)-1]
+	if last.BigBlind <= 1000 {
+		t.Errorf("DefaultStructure() last big blind = %v, want more than the starting stack", last.BigBlind)
+	}
+}
@@ -0,0 +1,322 @@
+package tournament
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/YoungsoonLee/poker/table"
+)
+
+// defaultTableSize is the number of seats per table when Config.TableSize is not set.
+const defaultTableSize = 9
+
+// maxRounds stops a tournament that never finishes, e.g. between bots that never bet.
+const maxRounds = 100000
+
+// Player is an entrant of a tournament.
+type Player struct {
+	Name     string
+	Strategy table.Strategy
+}
+
+// Config configures a tournament.
+// Players are seated at random at tables of at most TableSize seats, and the tables are balanced as players bust.
+// Levels by hands count every hand at every table. Time based levels use the wall clock, unless HandDuration is set,
+// in which case every round of hands takes that long.
+// OnLevel, if set, is called when a level starts, and OnHand after every hand with the index of the table the hand was played at.
+type Config struct {
+	Structure    Structure
+	Players      []Player
+	TableSize    int
+	HandDuration time.Duration
+	Rng          *rand.Rand
+	OnLevel      func(level int, l Level)
+	OnHand       func(tableID int, result *table.Result)
+}
+
+// Place is the final result of a player.
+// Player is the index of the player in Config.Players, and Position is 1 for the winner.
+type Place struct {
+	Player    int
+	Name      string
+	Position  int
+	Prize     int
+	Knockouts int
+	Bounties  int
+}
+
+// Result is the outcome of a tournament.
+// Places are ordered by position, starting with the winner.
+// Rounds counts the rounds where every table played a hand, and Hands counts the hands over all tables.
+type Result struct {
+	Entrants  int
+	PrizePool int
+	Rounds    int
+	Hands     int
+	Level     int
+	Places    []Place
+}
+
+// tournament is the state of a running tournament.
+type tournament struct {
+	cfg       Config
+	tables    []*table.Table
+	players   map[*table.Seat]int
+	places    []Place
+	remaining int
+}
+
+// Run plays a tournament until one player has all the chips.
+func Run(ctx context.Context, cfg Config) (*Result, error) {
+	if err := cfg.Structure.Validate(); err != nil {
+		return nil, err
+	}
+
+	if len(cfg.Players) < 2 {
+		return nil, fmt.Errorf("a tournament needs at least 2 players, got %d", len(cfg.Players))
+	}
+
+	if cfg.TableSize < 1 {
+		cfg.TableSize = defaultTableSize
+	}
+	if cfg.TableSize < 2 {
+		return nil, fmt.Errorf("invalid table size: %d", cfg.TableSize)
+	}
+
+	if cfg.Rng == nil {
+		cfg.Rng = rand.New(rand.NewSource(time.Now().UnixNano()))
+	}
+
+	t, err := newTournament(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &Result{Entrants: len(cfg.Players), PrizePool: cfg.Structure.PrizePool(len(cfg.Players)), Level: -1}
+	start := time.Now()
+
+	for t.remaining > 1 {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		if result.Rounds >= maxRounds {
+			return nil, fmt.Errorf("tournament did not finish after %d rounds", maxRounds)
+		}
+
+		elapsed := time.Since(start)
+		if cfg.HandDuration > 0 {
+			elapsed = time.Duration(result.Rounds) * cfg.HandDuration
+		}
+
+		var out []busted
+		for id, tb := range t.tables {
+			if tb.ActiveSeats() < 2 {
+				continue
+			}
+
+			// levels by hands count every hand at every table, so the level can change in the middle of a round
+			if current := cfg.Structure.LevelAt(result.Hands, elapsed); current != result.Level {
+				result.Level = current
+				if cfg.OnLevel != nil {
+					cfg.OnLevel(current, cfg.Structure.Levels[current])
+				}
+			}
+			level := cfg.Structure.Levels[result.Level]
+
+			tb.SetLevel(level.SmallBlind, level.BigBlind, level.Ante)
+			hand, err := tb.PlayHand()
+			if err != nil {
+				return nil, err
+			}
+			result.Hands++
+
+			if cfg.OnHand != nil {
+				cfg.OnHand(id, hand)
+			}
+
+			out = append(out, t.knockouts(tb, hand)...)
+		}
+		result.Rounds++
+
+		t.eliminate(out)
+		t.balance()
+	}
+
+	for _, tb := range t.tables {
+		for _, s := range tb.Seats {
+			if s.Stack > 0 {
+				t.places[t.players[s]].Position = 1
+			}
+		}
+	}
+
+	prizes := cfg.Structure.Prizes(len(cfg.Players))
+	for i := range t.places {
+		if position := t.places[i].Position; position <= len(prizes) {
+			t.places[i].Prize = prizes[position-1]
+		}
+	}
+
+	result.Places = append([]Place(nil), t.places...)
+	sort.Slice(result.Places, func(i, j int) bool {
+		return result.Places[i].Position < result.Places[j].Position
+	})
+
+	return result, nil
+}
+
+// newTournament seats the players at random at the smallest number of tables.
+func newTournament(cfg Config) (*tournament, error) {
+	t := &tournament{
+		cfg:       cfg,
+		players:   make(map[*table.Seat]int),
+		remaining: len(cfg.Players),
+	}
+
+	tables := (len(cfg.Players) + cfg.TableSize - 1) / cfg.TableSize
+	seats := make([][]*table.Seat, tables)
+	for i, p := range cfg.Rng.Perm(len(cfg.Players)) {
+		player := cfg.Players[p]
+		s := &table.Seat{Name: player.Name, Stack: cfg.Structure.StartingStack, Strategy: player.Strategy}
+		t.players[s] = p
+		seats[i%tables] = append(seats[i%tables], s)
+	}
+
+	level := cfg.Structure.Levels[0]
+	for _, s := range seats {
+		tb, err := table.New(s, level.SmallBlind, level.BigBlind, cfg.Rng)
+		if err != nil {
+			return nil, err
+		}
+		tb.Ante = level.Ante
+		tb.Button = cfg.Rng.Intn(len(s))
+		t.tables = append(t.tables, tb)
+	}
+
+	for i, p := range cfg.Players {
+		t.places = append(t.places, Place{Player: i, Name: p.Name})
+	}
+
+	return t, nil
+}
+
+// busted is a player who lost all their chips in a hand, and the players who knocked them out.
+type busted struct {
+	player     int
+	startStack int
+	by         []int
+}
+
+// knockouts returns the players who busted in the hand.
+// A player is knocked out by the winners of the last pot they were eligible for.
+func (t *tournament) knockouts(tb *table.Table, hand *table.Result) []busted {
+	var out []busted
+	for _, p := range hand.Players {
+		if p.EndStack > 0 {
+			continue
+		}
+
+		b := busted{player: t.players[tb.Seats[p.Seat]], startStack: p.StartStack}
+		for _, pot := range hand.Pots {
+			if slices.Contains(pot.Eligible, p.Seat) && !slices.Contains(pot.Winners, p.Seat) {
+				b.by = b.by[:0]
+				for _, winner := range pot.Winners {
+					b.by = append(b.by, t.players[tb.Seats[winner]])
+				}
+			}
+		}
+		out = append(out, b)
+	}
+
+	return out
+}
+
+// eliminate sets the finishing position of the busted players and pays their bounties.
+// A player who started the hand with more chips finishes higher,
+// and players with the same stack finish in the order they were dealt in.
+func (t *tournament) eliminate(out []busted) {
+	sort.SliceStable(out, func(i, j int) bool {
+		return out[i].startStack < out[j].startStack
+	})
+
+	for _, b := range out {
+		t.places[b.player].Position = t.remaining
+		t.remaining--
+
+		if len(b.by) == 0 {
+			continue
+		}
+
+		share := t.cfg.Structure.Bounty / len(b.by)
+		odd := t.cfg.Structure.Bounty % len(b.by)
+		for i, winner := range b.by {
+			t.places[winner].Knockouts++
+			t.places[winner].Bounties += share
+			if i == 0 {
+				t.places[winner].Bounties += odd
+			}
+		}
+	}
+}
+
+// balance removes the busted seats, breaks tables that are no longer needed
+// and moves players until the tables differ by at most one player.
+func (t *tournament) balance() {
+	for _, tb := range t.tables {
+		for i := len(tb.Seats) - 1; i >= 0; i-- {
+			if tb.Seats[i].Stack == 0 {
+				_, _ = tb.Remove(i)
+			}
+		}
+	}
+
+	needed := (t.remaining + t.cfg.TableSize - 1) / t.cfg.TableSize
+	for len(t.tables) > needed {
+		smallest := t.smallestTable()
+		broken := t.tables[smallest]
+		t.tables = append(t.tables[:smallest], t.tables[smallest+1:]...)
+		for _, s := range broken.Seats {
+			t.tables[t.smallestTable()].Add(s)
+		}
+	}
+
+	for {
+		smallest, largest := t.smallestTable(), t.largestTable()
+		if len(t.tables[largest].Seats)-len(t.tables[smallest].Seats) <= 1 {
+			return
+		}
+
+		from := t.tables[largest]
+		s, _ := from.Remove((from.Button + 1) % len(from.Seats))
+		t.tables[smallest].Add(s)
+	}
+}
+
+// smallestTable returns the index of the table with the fewest seats.
+func (t *tournament) smallestTable() int {
+	smallest := 0
+	for i, tb := range t.tables {
+		if len(tb.Seats) < len(t.tables[smallest].Seats) {
+			smallest = i
+		}
+	}
+
+	return smallest
+}
+
+// largestTable returns the index of the table with the most seats.
+func (t *tournament) largestTable() int {
+	largest := 0
+	for i, tb := range t.tables {
+		if len(tb.Seats) > len(t.tables[largest].Seats) {
+			largest = i
+		}
+	}
+
+	return largest
+}
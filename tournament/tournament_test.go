@@ -0,0 +1,157 @@
+package tournament
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/YoungsoonLee/poker/table"
+)
+
+func testPlayers(n int) []Player {
+	players := make([]Player, n)
+	for i := range players {
+		players[i] = Player{Name: fmt.Sprintf("player-%d", i+1), Strategy: table.Maniac{}}
+	}
+
+	return players
+}
+
+func TestRun(t *testing.T) {
+	bounty, err := LoadStructure("testdata/bounty.json")
+	if err != nil {
+		t.Fatalf("LoadStructure() error = %v", err)
+	}
+
+	turbo, err := LoadStructure("testdata/turbo.yaml")
+	if err != nil {
+		t.Fatalf("LoadStructure() error = %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		structure Structure
+		players   int
+		tableSize int
+	}{
+		{name: "sit and go", structure: turbo, players: 6, tableSize: 6},
+		{name: "heads-up", structure: turbo, players: 2, tableSize: 9},
+		{name: "multi-table bounty", structure: bounty, players: 23, tableSize: 6},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var hands int
+			maxPerTable := 0
+			tableHands := make(map[int]int)
+
+			result, err := Run(context.Background(), Config{
+				Structure:    tt.structure,
+				Players:      testPlayers(tt.players),
+				TableSize:    tt.tableSize,
+				HandDuration: 30 * time.Second,
+				Rng:          rand.New(rand.NewSource(3)),
+				OnHand: func(tableID int, hand *table.Result) {
+					hands++
+					tableHands[tableID]++
+					if len(hand.Players) > maxPerTable {
+						maxPerTable = len(hand.Players)
+					}
+				},
+			})
+			if err != nil {
+				t.Fatalf("Run() error = %v", err)
+			}
+
+			if hands != result.Hands {
+				t.Errorf("Run() hands = %v, OnHand called %v times", result.Hands, hands)
+			}
+			if maxPerTable > tt.tableSize {
+				t.Errorf("Run() dealt %v players at a table, want at most %v", maxPerTable, tt.tableSize)
+			}
+
+			positions := make(map[int]bool)
+			paid, bounties := 0, 0
+			for i, place := range result.Places {
+				if place.Position != i+1 {
+					t.Errorf("Run() place %d has position %v", i, place.Position)
+				}
+				positions[place.Position] = true
+				paid += place.Prize
+				bounties += place.Bounties
+			}
+
+			if len(positions) != tt.players {
+				t.Errorf("Run() positions = %v, want %v distinct positions", len(positions), tt.players)
+			}
+			if paid != result.PrizePool {
+				t.Errorf("Run() paid %v, want prize pool %v", paid, result.PrizePool)
+			}
+			// every busted player is knocked out by someone
+			if want := tt.structure.Bounty * (tt.players - 1); bounties != want {
+				t.Errorf("Run() bounties = %v, want %v", bounties, want)
+			}
+		})
+	}
+}
+
+func TestRun_InvalidConfig(t *testing.T) {
+	turbo, err := LoadStructure("testdata/turbo.yaml")
+	if err != nil {
+		t.Fatalf("LoadStructure() error = %v", err)
+	}
+
+	if _, err := Run(context.Background(), Config{Structure: turbo, Players: testPlayers(1)}); err == nil {
+		t.Errorf("Run() with one player error = nil, want error")
+	}
+
+	if _, err := Run(context.Background(), Config{Structure: Structure{}, Players: testPlayers(2)}); err == nil {
+		t.Errorf("Run() with invalid structure error = nil, want error")
+	}
+}
+
+func TestRun_LevelByHands(t *testing.T) {
+	const levelHands = 5
+
+	tests := []struct {
+		name      string
+		players   int
+		tableSize int
+	}{
+		{name: "one table", players: 6, tableSize: 6},
+		{name: "two tables", players: 12, tableSize: 6},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var hands int
+			starts := make(map[int]int)
+
+			players := testPlayers(tt.players)
+			for i := range players {
+				players[i].Strategy = table.CallingStation{}
+			}
+
+			_, err := Run(context.Background(), Config{
+				Structure: DefaultStructure(1000, 5, 10, levelHands),
+				Players:   players,
+				TableSize: tt.tableSize,
+				Rng:       rand.New(rand.NewSource(3)),
+				OnLevel:   func(level int, l Level) { starts[level] = hands },
+				OnHand:    func(tableID int, hand *table.Result) { hands++ },
+			})
+			if err != nil {
+				t.Fatalf("Run() error = %v", err)
+			}
+
+			if len(starts) < 2 {
+				t.Fatalf("Run() started %d levels, want at least 2", len(starts))
+			}
+			for level, start := range starts {
+				if want := level * levelHands; start != want {
+					t.Errorf("Run() started level %d after %d hands, want %d", level, start, want)
+				}
+			}
+		})
+	}
+}
@@ -16,6 +16,15 @@ var RankMapReverse = map[int]string{
 	2: "2", 3: "3", 4: "4", 5: "5", 6: "6", 7: "7", 8: "8", 9: "9", 10: "T", 11: "J", 12: "Q", 13: "K", 14: "A",
 }
 
+// Ranks lists the ranks of a card in ascending order.
+var Ranks = []string{"2", "3", "4", "5", "6", "7", "8", "9", "T", "J", "Q", "K", "A"}
+
+// ShortDeckRanks lists the ranks of a short-deck (6+) deck in ascending order, without the twos to fives.
+var ShortDeckRanks = []string{"6", "7", "8", "9", "T", "J", "Q", "K", "A"}
+
+// Suits lists the suits of a card in a fixed order.
+var Suits = []string{"S", "H", "D", "C"}
+
 // SuitMap maps the suit of a card to an empty struct.
 var SuitMap = map[string]struct{}{
 	"S": {}, "H": {}, "D": {}, "C": {},
@@ -27,6 +36,9 @@ type Card struct {
 	Suit string // Suit of the card (S, H, D, C)
 }
 
+// Joker is the joker, written "XX". It has no rank or suit of its own and is only accepted by ParseWildCards.
+var Joker = Card{Rank: "X", Suit: "X"}
+
 // NewCard creates a new Card object based on the provided inputCard string.
 // The inputCard string should be in the format "3s4h5d6c7s" or "9H3CTSQSAS",
 // where odd indices represent the rank and even indices represent the suit.
@@ -40,16 +52,16 @@ func NewCard(inputCard string) ([]Card, error) {
 	suit := make([]string, 0)
 	card := make([]Card, 0)
 
-	// even index is rank, odd index is suit
-	for i, c := range inputCard {
+	// even index is rank, odd index is suit.
+	// The string is read byte by byte, so a multi-byte character that upper-cases to a valid rank or suit is rejected.
+	for i := 0; i < len(inputCard); i++ {
+		upper := strings.ToUpper(string(inputCard[i]))
 		if i%2 == 0 {
-			upper := strings.ToUpper(string(c))
 			if _, ok := RankMap[upper]; !ok {
 				return []Card{}, fmt.Errorf("invalid card string: %s. rank should be 2,3,4,5,6,7,8,9,T,J,Q,K,A", inputCard)
 			}
 			rank = append(rank, upper)
 		} else {
-			upper := strings.ToUpper(string(c))
 			if _, ok := SuitMap[upper]; !ok {
 				return []Card{}, fmt.Errorf("invalid card string: %s. suit should be S,H,D,C", inputCard)
 			}
@@ -64,6 +76,74 @@ func NewCard(inputCard string) ([]Card, error) {
 	return card, nil
 }
 
+// ParseCard parses a single card in the two character form, e.g. "As" or "TD".
+// It is not case sensitive.
+func ParseCard(s string) (Card, error) {
+	if len(s) != 2 {
+		return Card{}, fmt.Errorf("invalid card: %s. card should be a rank and a suit like As", s)
+	}
+
+	rank, suit := strings.ToUpper(s[:1]), strings.ToUpper(s[1:])
+	if _, ok := RankMap[rank]; !ok {
+		return Card{}, fmt.Errorf("invalid card: %s. rank should be 2,3,4,5,6,7,8,9,T,J,Q,K,A", s)
+	}
+
+	if _, ok := SuitMap[suit]; !ok {
+		return Card{}, fmt.Errorf("invalid card: %s. suit should be S,H,D,C", s)
+	}
+
+	return Card{Rank: rank, Suit: suit}, nil
+}
+
+// ParseCards parses any number of cards in the two character form, e.g. "AsKd", "As Kd 7h" or "[As,Kd]".
+// Spaces, commas and brackets between cards are ignored. Unlike NewCard, it does not require five cards.
+func ParseCards(s string) ([]Card, error) {
+	return parseCards(s, false)
+}
+
+// ParseWildCards parses cards like ParseCards, and also accepts the joker written "Xx" or "JK", e.g. "AsKdXx".
+func ParseWildCards(s string) ([]Card, error) {
+	return parseCards(s, true)
+}
+
+// parseCards parses cards for ParseCards and ParseWildCards, accepting jokers if jokers is set.
+func parseCards(s string, jokers bool) ([]Card, error) {
+	cleaned := strings.Map(func(r rune) rune {
+		switch r {
+		case ' ', ',', '[', ']', '\t':
+			return -1
+		}
+		return r
+	}, s)
+
+	if len(cleaned)%2 != 0 {
+		return nil, fmt.Errorf("invalid cards: %s. every card should be a rank and a suit like As", s)
+	}
+
+	cards := make([]Card, 0, len(cleaned)/2)
+	for i := 0; i < len(cleaned); i += 2 {
+		if jokers {
+			if upper := strings.ToUpper(cleaned[i : i+2]); upper == "XX" || upper == "JK" {
+				cards = append(cards, Joker)
+				continue
+			}
+		}
+
+		c, err := ParseCard(cleaned[i : i+2])
+		if err != nil {
+			return nil, err
+		}
+		cards = append(cards, c)
+	}
+
+	return cards, nil
+}
+
+// IsJoker reports whether the card is the joker.
+func (c Card) IsJoker() bool {
+	return c == Joker
+}
+
 // String returns a string representation of the card.
 func (c Card) String() string {
 	return c.Rank + c.Suit
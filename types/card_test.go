@@ -2,6 +2,7 @@ package types
 
 import (
 	"reflect"
+	"strings"
 	"testing"
 )
 
@@ -60,3 +61,174 @@ func TestNewCard(t *testing.T) {
 		})
 	}
 }
+
+// TestParseCards tests the ParseCards function.
+func TestParseCards(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    []Card
+		wantErr bool
+	}{
+		{
+			name:  "two cards",
+			input: "AsKd",
+			want:  []Card{{Rank: "A", Suit: "S"}, {Rank: "K", Suit: "D"}},
+		},
+		{
+			name:  "cards with spaces and brackets",
+			input: "[Th 9c, 2S]",
+			want:  []Card{{Rank: "T", Suit: "H"}, {Rank: "9", Suit: "C"}, {Rank: "2", Suit: "S"}},
+		},
+		{
+			name:  "no cards",
+			input: "",
+			want:  []Card{},
+		},
+		{
+			name:    "odd length",
+			input:   "AsK",
+			wantErr: true,
+		},
+		{
+			name:    "invalid rank",
+			input:   "1s",
+			wantErr: true,
+		},
+		{
+			name:    "invalid suit",
+			input:   "Ax",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseCards(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseCards() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseCards() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseWildCards(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    []Card
+		wantErr bool
+	}{
+		{
+			name:  "joker as Xx",
+			input: "AsXx",
+			want:  []Card{{Rank: "A", Suit: "S"}, Joker},
+		},
+		{
+			name:  "joker as JK",
+			input: "[jk, Kd]",
+			want:  []Card{Joker, {Rank: "K", Suit: "D"}},
+		},
+		{
+			name:  "jack of spades is not a joker",
+			input: "JsJK",
+			want:  []Card{{Rank: "J", Suit: "S"}, Joker},
+		},
+		{
+			name:    "invalid card",
+			input:   "Xs",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseWildCards(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseWildCards() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseWildCards() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	if _, err := ParseCards("AsXx"); err == nil {
+		t.Errorf("ParseCards() of a joker error = nil, want an error")
+	}
+}
+
+// validCards reports whether every card has a valid rank and suit in upper case.
+func validCards(cards []Card) bool {
+	for _, c := range cards {
+		if _, ok := RankMap[c.Rank]; !ok {
+			return false
+		}
+		if _, ok := SuitMap[c.Suit]; !ok {
+			return false
+		}
+	}
+
+	return true
+}
+
+// cardsString joins the cards in the two character form, e.g. "ASKD".
+func cardsString(cards []Card) string {
+	s := ""
+	for _, c := range cards {
+		s += c.String()
+	}
+
+	return s
+}
+
+func FuzzNewCard(f *testing.F) {
+	for _, s := range []string{"3s4h5d6c7s", "9H3CTSQSAS", "1s4h5d6c7s", "3x4h5d6c7s", "", "Aſ2s3s4s5s", "3s4h5d6c7s8s"} {
+		f.Add(s)
+	}
+
+	f.Fuzz(func(t *testing.T, s string) {
+		cards, err := NewCard(s)
+		if err != nil {
+			return
+		}
+
+		if len(cards) != 5 || !validCards(cards) {
+			t.Fatalf("NewCard(%q) = %v, want five valid cards", s, cards)
+		}
+		if got := cardsString(cards); got != strings.ToUpper(s) {
+			t.Fatalf("NewCard(%q) = %s, want the same cards", s, got)
+		}
+	})
+}
+
+func FuzzParseCards(f *testing.F) {
+	for _, s := range []string{"AsKd", "[Th 9c, 2S]", "", "AsK", "1s", "Ax", "Aſ", "\xff\xfe"} {
+		f.Add(s)
+	}
+
+	f.Fuzz(func(t *testing.T, s string) {
+		cards, err := ParseCards(s)
+		if err != nil {
+			return
+		}
+
+		if !validCards(cards) {
+			t.Fatalf("ParseCards(%q) = %v, want valid cards", s, cards)
+		}
+
+		// the cards parse back to themselves, one by one and all together
+		again, err := ParseCards(cardsString(cards))
+		if err != nil || !reflect.DeepEqual(again, cards) {
+			t.Fatalf("ParseCards(%q) = %v, %v, want %v", cardsString(cards), again, err, cards)
+		}
+		for _, c := range cards {
+			if got, err := ParseCard(c.String()); err != nil || got != c {
+				t.Fatalf("ParseCard(%q) = %v, %v, want %v", c.String(), got, err, c)
+			}
+		}
+	})
+}
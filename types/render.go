@@ -0,0 +1,146 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Style is a way of rendering cards as text.
+type Style int
+
+const (
+	// Plain renders cards in the two character form, e.g. "AS KH".
+	Plain Style = iota
+	// Unicode renders cards with suit symbols, e.g. "A♠ K♥".
+	Unicode
+	// Box renders cards as ASCII-art boxes side by side, five lines high.
+	Box
+)
+
+// styleNames maps the names of the styles to the styles.
+var styleNames = map[string]Style{"plain": Plain, "unicode": Unicode, "box": Box}
+
+// suitSymbols maps the suit of a card to its symbol.
+var suitSymbols = map[string]string{"S": "♠", "H": "♥", "D": "♦", "C": "♣"}
+
+// ANSI escape codes of the card colors. Cards are drawn on a white background so black suits show on dark terminals.
+const (
+	ansiRed   = "\x1b[31;47m"
+	ansiBlack = "\x1b[30;47m"
+	ansiReset = "\x1b[0m"
+)
+
+// ParseStyle parses the name of a style: plain, unicode or box.
+func ParseStyle(s string) (Style, error) {
+	style, ok := styleNames[strings.ToLower(s)]
+	if !ok {
+		return Plain, fmt.Errorf("invalid style: %s. style should be plain, unicode or box", s)
+	}
+
+	return style, nil
+}
+
+// String returns the name of the style.
+func (s Style) String() string {
+	for name, style := range styleNames {
+		if style == s {
+			return name
+		}
+	}
+
+	return fmt.Sprintf("style(%d)", int(s))
+}
+
+// Renderer renders cards as text in a style.
+// If Color is set, hearts and diamonds are red and spades and clubs are black, using ANSI escape codes.
+type Renderer struct {
+	Style Style
+	Color bool
+}
+
+// Card renders a single card. In the Box style it is five lines high.
+func (r Renderer) Card(c Card) string {
+	return r.Cards([]Card{c})
+}
+
+// Cards renders the cards separated by spaces, e.g. "A♠ K♥".
+// In the Box style the cards are drawn side by side on five lines, without a trailing newline.
+func (r Renderer) Cards(cards []Card) string {
+	if r.Style == Box {
+		return r.boxes(cards)
+	}
+
+	s := make([]string, len(cards))
+	for i, c := range cards {
+		if r.Style == Unicode {
+			s[i] = r.paint(c, displayRank(c)+suitSymbol(c))
+		} else {
+			s[i] = r.paint(c, c.String())
+		}
+	}
+
+	return strings.Join(s, " ")
+}
+
+// boxes draws the cards as boxes like
+//
+//	.-----.
+//	|10   |
+//	|  ♠  |
+//	|   10|
+//	'-----'
+func (r Renderer) boxes(cards []Card) string {
+	lines := make([][]string, 5)
+	for _, c := range cards {
+		rank := displayRank(c)
+		lines[0] = append(lines[0], ".-----.")
+		lines[1] = append(lines[1], "|"+r.paint(c, fmt.Sprintf("%-5s", rank))+"|")
+		lines[2] = append(lines[2], "|"+r.paint(c, "  "+suitSymbol(c)+"  ")+"|")
+		lines[3] = append(lines[3], "|"+r.paint(c, fmt.Sprintf("%5s", rank))+"|")
+		lines[4] = append(lines[4], "'-----'")
+	}
+
+	s := make([]string, len(lines))
+	for i, l := range lines {
+		s[i] = strings.Join(l, " ")
+	}
+
+	return strings.Join(s, "\n")
+}
+
+// paint colors the text of a card if colors are enabled.
+func (r Renderer) paint(c Card, text string) string {
+	if !r.Color {
+		return text
+	}
+
+	if c.Suit == "H" || c.Suit == "D" {
+		return ansiRed + text + ansiReset
+	}
+
+	return ansiBlack + text + ansiReset
+}
+
+// displayRank returns the rank of a card as people write it, with 10 instead of T and JK for the joker.
+func displayRank(c Card) string {
+	switch {
+	case c.Rank == "T":
+		return "10"
+	case c.IsJoker():
+		return "JK"
+	}
+
+	return c.Rank
+}
+
+// suitSymbol returns the symbol of the suit of a card, a star for the joker, or the suit itself if it is unknown.
+func suitSymbol(c Card) string {
+	if c.IsJoker() {
+		return "★"
+	}
+	if s, ok := suitSymbols[c.Suit]; ok {
+		return s
+	}
+
+	return c.Suit
+}
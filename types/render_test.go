@@ -0,0 +1,92 @@
+package types
+
+import (
+	"testing"
+)
+
+func TestRenderer_Cards(t *testing.T) {
+	cards := []Card{{Rank: "A", Suit: "S"}, {Rank: "T", Suit: "H"}}
+
+	tests := []struct {
+		name     string
+		renderer Renderer
+		want     string
+	}{
+		{name: "plain", renderer: Renderer{Style: Plain}, want: "AS TH"},
+		{name: "unicode", renderer: Renderer{Style: Unicode}, want: "A♠ 10♥"},
+		{
+			name:     "unicode with colors",
+			renderer: Renderer{Style: Unicode, Color: true},
+			want:     "\x1b[30;47mA♠\x1b[0m \x1b[31;47m10♥\x1b[0m",
+		},
+		{
+			name:     "plain with colors",
+			renderer: Renderer{Style: Plain, Color: true},
+			want:     "\x1b[30;47mAS\x1b[0m \x1b[31;47mTH\x1b[0m",
+		},
+		{
+			name:     "box",
+			renderer: Renderer{Style: Box},
+			want: ".-----. .-----.\n" +
+				"|A    | |10   |\n" +
+				"|  ♠  | |  ♥  |\n" +
+				"|    A| |   10|\n" +
+				"'-----' '-----'",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.renderer.Cards(cards); got != tt.want {
+				t.Errorf("Renderer.Cards() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRenderer_Cards_Joker(t *testing.T) {
+	cards := []Card{{Rank: "A", Suit: "S"}, Joker}
+
+	tests := []struct {
+		name     string
+		renderer Renderer
+		want     string
+	}{
+		{name: "plain", renderer: Renderer{Style: Plain}, want: "AS XX"},
+		{name: "unicode", renderer: Renderer{Style: Unicode}, want: "A♠ JK★"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.renderer.Cards(cards); got != tt.want {
+				t.Errorf("Renderer.Cards() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseStyle(t *testing.T) {
+	tests := []struct {
+		name    string
+		s       string
+		want    Style
+		wantErr bool
+	}{
+		{name: "plain", s: "plain", want: Plain},
+		{name: "unicode", s: "Unicode", want: Unicode},
+		{name: "box", s: "box", want: Box},
+		{name: "unknown", s: "fancy", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseStyle(tt.s)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseStyle() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseStyle() = %v, want %v", got, tt.want)
+			}
+			if !tt.wantErr && got.String() != tt.s && got.String() != "unicode" {
+				t.Errorf("Style.String() = %v, want %v", got.String(), tt.s)
+			}
+		})
+	}
+}